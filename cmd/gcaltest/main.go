@@ -22,7 +22,7 @@ func main() {
 	}
 
 	gcalClient := mcp.NewClient(gcalTransport, "google-calendar")
-	provider := providers.NewGCalProvider(gcalClient)
+	provider := providers.NewGCalProvider(gcalClient, nil)
 	defer provider.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	cosstate "github.com/szoloth/partner/internal/cos"
+)
+
+const cosUsage = `Usage: partner cos <command> [flags]
+
+Commands:
+  add --type <type> [--company C] [--contact C] [--role R] [--draft PATH] [--description D]
+        Queue a new action
+  list  Show pending actions
+  done <id>
+        Mark a pending action complete
+  skip <id>
+        Skip a pending action
+
+Every command accepts --json for machine-readable output.
+`
+
+// runCoS handles "partner cos ..." and returns the exit code
+func runCoS(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, cosUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "add":
+		return cosAdd(args[1:])
+	case "list":
+		return cosList(args[1:])
+	case "done":
+		return cosResolve("done", args[1:])
+	case "skip":
+		return cosResolve("skip", args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(cosUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown cos command %q\n\n%s", args[0], cosUsage)
+		return 2
+	}
+}
+
+// cosFlagSet is a flag set for one cos command with the shared --json flag
+func cosFlagSet(name string, asJSON *bool) *flag.FlagSet {
+	fs := flag.NewFlagSet("partner cos "+name, flag.ContinueOnError)
+	fs.BoolVar(asJSON, "json", false, "Output in JSON format")
+	return fs
+}
+
+func cosAdd(args []string) int {
+	var asJSON bool
+	var action cosstate.PendingAction
+	fs := cosFlagSet("add", &asJSON)
+	fs.StringVar(&action.Type, "type", "", "Action type (e.g. outreach, follow_up)")
+	fs.StringVar(&action.Company, "company", "", "Company the action concerns")
+	fs.StringVar(&action.Contact, "contact", "", "Person to contact")
+	fs.StringVar(&action.Role, "role", "", "Role being discussed")
+	fs.StringVar(&action.DraftPath, "draft", "", "Path to a prepared draft")
+	fs.StringVar(&action.Description, "description", "", "Free-form description")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	provider := cosstate.NewProvider()
+	state, err := provider.Load()
+	if err != nil {
+		return cosFail(asJSON, err)
+	}
+	if err := provider.AddAction(state, action); err != nil {
+		return cosFail(asJSON, err)
+	}
+	if err := provider.Save(state); err != nil {
+		return cosFail(asJSON, err)
+	}
+
+	added := state.ActionQueue.Pending[len(state.ActionQueue.Pending)-1]
+	if asJSON {
+		writeJSON(added)
+		return 0
+	}
+	fmt.Printf("Added action %d: %s\n", added.ID, describeAction(added))
+	return 0
+}
+
+func cosList(args []string) int {
+	var asJSON bool
+	fs := cosFlagSet("list", &asJSON)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	state, err := cosstate.NewProvider().Load()
+	if err != nil {
+		return cosFail(asJSON, err)
+	}
+	pending := state.ActionQueue.Pending
+
+	if asJSON {
+		if pending == nil {
+			pending = []cosstate.PendingAction{}
+		}
+		writeJSON(pending)
+		return 0
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending actions")
+		return 0
+	}
+	writeActionTable(os.Stdout, pending)
+	return 0
+}
+
+// cosResolve completes (verb "done") or skips (verb "skip") the action
+// whose ID is the first argument
+func cosResolve(verb string, args []string) int {
+	var asJSON bool
+	fs := cosFlagSet(verb, &asJSON)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// Allow flags after the ID too ("done 3 --json")
+	rest := fs.Args()
+	if len(rest) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: partner cos %s <id> [--json]\n", verb)
+		return 2
+	}
+	if err := fs.Parse(rest[1:]); err != nil {
+		return 2
+	}
+	id, err := strconv.Atoi(rest[0])
+	if err != nil {
+		return cosFail(asJSON, fmt.Errorf("invalid action id %q", rest[0]))
+	}
+
+	provider := cosstate.NewProvider()
+	state, err := provider.Load()
+	if err != nil {
+		return cosFail(asJSON, err)
+	}
+	var action *cosstate.PendingAction
+	for i := range state.ActionQueue.Pending {
+		if state.ActionQueue.Pending[i].ID == id {
+			a := state.ActionQueue.Pending[i]
+			action = &a
+			break
+		}
+	}
+	if action == nil {
+		return cosFail(asJSON, fmt.Errorf("no pending action with id %d", id))
+	}
+
+	status, label := "completed", "Completed"
+	if verb == "done" {
+		provider.MarkActionComplete(state, id)
+	} else {
+		status, label = "skipped", "Skipped"
+		provider.MarkActionSkipped(state, id)
+	}
+	if err := provider.Save(state); err != nil {
+		return cosFail(asJSON, err)
+	}
+
+	if asJSON {
+		writeJSON(map[string]interface{}{"status": status, "action": action})
+		return 0
+	}
+	fmt.Printf("%s action %d: %s\n", label, id, describeAction(*action))
+	return 0
+}
+
+// describeAction is a one-line summary such as "outreach - Jane Smith at Acme Corp"
+func describeAction(a cosstate.PendingAction) string {
+	text := a.Type
+	switch {
+	case a.Contact != "" && a.Company != "":
+		text += " - " + a.Contact + " at " + a.Company
+	case a.Contact != "":
+		text += " - " + a.Contact
+	case a.Company != "":
+		text += " - " + a.Company
+	case a.Description != "":
+		text += " - " + a.Description
+	}
+	return text
+}
+
+func writeActionTable(w io.Writer, actions []cosstate.PendingAction) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tCOMPANY\tCONTACT\tDRAFT\tCREATED")
+	for _, a := range actions {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			a.ID, a.Type, a.Company, a.Contact, a.DraftPath, a.CreatedAt.Format("Jan 2 15:04"))
+	}
+	tw.Flush()
+}
+
+func writeJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// cosFail reports err on stderr, or as {"error": ...} with --json
+func cosFail(asJSON bool, err error) int {
+	if asJSON {
+		writeJSON(map[string]interface{}{"error": err.Error()})
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return 1
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/app"
+	"github.com/szoloth/partner/internal/claude"
+	"github.com/szoloth/partner/internal/config"
+	"github.com/szoloth/partner/internal/theme"
+)
+
+// initProbeTimeout bounds each MCP connection test made by "partner init"
+const initProbeTimeout = 20 * time.Second
+
+// initSettings are the answers "partner init" writes to the config file
+type initSettings struct {
+	thingsScript    string
+	gcalCredentials string
+	theme           string
+	claudeModel     string
+}
+
+// runInit handles "partner init [--config PATH]": it asks for the main
+// settings, writes them as TOML, then checks each MCP server and the
+// Claude CLI. An existing config is only updated after confirmation, and
+// keeps every key init doesn't ask about, so running it again just
+// re-tests the connections.
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	path := fs.String("config", config.DefaultPath, "Path to the config file to write")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	target := config.ExpandPath(*path)
+	in := bufio.NewReader(os.Stdin)
+
+	// Existing values become the defaults offered by each prompt
+	loaded, err := config.Load(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring the existing config: %v\n", err)
+		loaded = config.Default()
+	}
+
+	write := true
+	if _, err := os.Stat(target); err == nil {
+		write, err = promptYesNo(in, fmt.Sprintf("%s already exists. Update its settings (other keys are kept)?", target))
+		if err != nil {
+			return initInputError(err)
+		}
+	}
+
+	if write {
+		s, err := promptSettings(in, loaded)
+		if err != nil {
+			return initInputError(err)
+		}
+		if err := writeInitConfig(target, s); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote %s\n", target)
+	}
+
+	// Test what will actually be used, including keys init doesn't ask about
+	cfg, err := config.Load(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+	fmt.Println()
+	if !checkConnections(cfg) {
+		return 1
+	}
+	return 0
+}
+
+// initInputError reports a failed or aborted prompt and returns the exit code
+func initInputError(err error) int {
+	if errors.Is(err, io.EOF) {
+		fmt.Fprintln(os.Stderr, "\nAborted: input closed")
+	} else {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+	}
+	return 1
+}
+
+// promptSettings asks for each setting, re-asking until the theme and
+// model are valid
+func promptSettings(in *bufio.Reader, defaults *config.Config) (initSettings, error) {
+	var s initSettings
+	var err error
+
+	if s.thingsScript, err = prompt(in, "Things MCP script path", defaults.ThingsMCPScript); err != nil {
+		return s, err
+	}
+	if s.gcalCredentials, err = prompt(in, "Google Calendar credentials path", defaults.GCalCredentialsPath); err != nil {
+		return s, err
+	}
+
+	for {
+		label := fmt.Sprintf("Theme (%s)", strings.Join(theme.Names(), ", "))
+		if s.theme, err = prompt(in, label, defaults.Theme); err != nil {
+			return s, err
+		}
+		if _, perr := theme.ParseTheme(s.theme); perr == nil {
+			break
+		}
+		fmt.Printf("  Unknown theme %q\n", s.theme)
+	}
+
+	for {
+		label := fmt.Sprintf("AI model (%s, or empty for the CLI default)", strings.Join(claude.Models, ", "))
+		if s.claudeModel, err = prompt(in, label, defaults.ClaudeModel); err != nil {
+			return s, err
+		}
+		if s.claudeModel == "" || slices.Contains(claude.Models, s.claudeModel) {
+			break
+		}
+		fmt.Printf("  Unknown model %q\n", s.claudeModel)
+	}
+
+	return s, nil
+}
+
+// prompt prints label with its default and returns the trimmed answer,
+// or def when the answer is empty
+func prompt(in *bufio.Reader, label, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// promptYesNo asks a y/N question; anything but y or yes is no
+func promptYesNo(in *bufio.Reader, question string) (bool, error) {
+	answer, err := prompt(in, question+" [y/N]", "")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// writeInitConfig writes s to path as TOML, creating its directory. An
+// existing file keeps its comments and every other key; only the
+// top-level keys init asks about are replaced or added.
+func writeInitConfig(path string, s initSettings) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(existing) == 0 {
+		existing = []byte("# Written by partner init; see the README for every key\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(mergeInitSettings(string(existing), s)), 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// mergeInitSettings sets the init keys in the top-level section of doc,
+// replacing their lines in place and inserting the missing ones before
+// the first table. An empty model removes claude_model.
+func mergeInitSettings(doc string, s initSettings) string {
+	values := map[string]string{
+		"things_mcp_script":     s.thingsScript,
+		"gcal_credentials_path": s.gcalCredentials,
+		"theme":                 s.theme,
+		"claude_model":          s.claudeModel,
+	}
+	order := []string{"things_mcp_script", "gcal_credentials_path", "theme", "claude_model"}
+	line := func(key string) string {
+		return key + " = " + strconv.Quote(values[key])
+	}
+
+	lines := strings.Split(strings.TrimSuffix(doc, "\n"), "\n")
+	var out []string
+	seen := make(map[string]bool)
+	tableAt := -1
+	for _, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if tableAt < 0 && strings.HasPrefix(trimmed, "[") {
+			tableAt = len(out)
+		}
+		if tableAt < 0 {
+			key, _, ok := strings.Cut(trimmed, "=")
+			key = strings.TrimSpace(key)
+			if _, managed := values[key]; ok && managed {
+				if !seen[key] && values[key] != "" {
+					out = append(out, line(key))
+				}
+				seen[key] = true
+				continue
+			}
+		}
+		out = append(out, l)
+	}
+
+	var missing []string
+	for _, key := range order {
+		if !seen[key] && values[key] != "" {
+			missing = append(missing, line(key))
+		}
+	}
+	if tableAt < 0 {
+		out = append(out, missing...)
+	} else if len(missing) > 0 {
+		// Keep the keys above the blank lines that set off the first table
+		at := tableAt
+		for at > 0 && strings.TrimSpace(out[at-1]) == "" {
+			at--
+		}
+		if at == tableAt {
+			missing = append(missing, "")
+		}
+		out = append(out[:at], append(missing, out[at:]...)...)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// checkConnections starts each configured MCP server and lists its tools,
+// then checks the Claude CLI, printing ✓ or ✗ for each. It reports
+// whether everything passed.
+func checkConnections(cfg *config.Config) bool {
+	model := app.NewModel(app.WithConfig(cfg), app.WithHeadless(true))
+
+	type server struct{ id, label string }
+	servers := []server{{"things", "Things"}}
+	if cfg.UsesCalendar("gcal") {
+		servers = append(servers, server{"google-calendar", "Google Calendar"})
+	}
+	if cfg.NotionAPIKey != "" {
+		servers = append(servers, server{"notion", "Notion"})
+	}
+
+	ok := true
+	for _, s := range servers {
+		ctx, cancel := context.WithTimeout(context.Background(), initProbeTimeout)
+		tools, err := model.ProbeServer(ctx, s.id)
+		cancel()
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", s.label, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("✓ %s (%d tools)\n", s.label, len(tools))
+	}
+
+	if err := claude.CheckAvailable(); err != nil {
+		fmt.Printf("✗ Claude CLI: %v\n", err)
+		ok = false
+	} else {
+		fmt.Println("✓ Claude CLI")
+	}
+	return ok
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szoloth/partner/internal/config"
+)
+
+func TestWriteInitConfigOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := `# My settings
+theme = "nord"
+claude_model = "opus"
+notion_api_key = "secret_abc" # keep me
+gcal_calendar_ids = [
+  "primary",
+  "work@example.com",
+]
+
+[keybindings]
+quit = "Q"
+`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := initSettings{
+		thingsScript:    "/opt/things.sh",
+		gcalCredentials: "/opt/creds.json",
+		theme:           "gruvbox",
+	}
+	if err := writeInitConfig(path, s); err != nil {
+		t.Fatalf("writeInitConfig() = %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		data, _ := os.ReadFile(path)
+		t.Fatalf("Load() = %v\n%s", err, data)
+	}
+	if cfg.ThingsMCPScript != s.thingsScript || cfg.GCalCredentialsPath != s.gcalCredentials || cfg.Theme != s.theme {
+		t.Errorf("answers not written: script %q, credentials %q, theme %q", cfg.ThingsMCPScript, cfg.GCalCredentialsPath, cfg.Theme)
+	}
+	if cfg.ClaudeModel != "" {
+		t.Errorf("claude_model = %q, want it removed for the CLI default", cfg.ClaudeModel)
+	}
+	if cfg.NotionAPIKey != "secret_abc" {
+		t.Errorf("notion_api_key = %q, want it kept", cfg.NotionAPIKey)
+	}
+	if len(cfg.GCalCalendarIDs) != 2 {
+		t.Errorf("gcal_calendar_ids = %v, want both kept", cfg.GCalCalendarIDs)
+	}
+	if cfg.Keybindings.Quit != "Q" {
+		t.Errorf("keybindings.quit = %q, want it kept", cfg.Keybindings.Quit)
+	}
+}
+
+func TestWriteInitConfigNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partner", "config.toml")
+	s := initSettings{thingsScript: "/opt/things.sh", gcalCredentials: "/opt/creds.json", theme: "nord", claudeModel: "sonnet"}
+	if err := writeInitConfig(path, s); err != nil {
+		t.Fatalf("writeInitConfig() = %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if cfg.Theme != "nord" || cfg.ClaudeModel != "sonnet" {
+		t.Errorf("theme %q, model %q; want nord and sonnet", cfg.Theme, cfg.ClaudeModel)
+	}
+}
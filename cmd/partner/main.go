@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
 
 	"github.com/szoloth/partner/internal/app"
+	"github.com/szoloth/partner/internal/completion"
+	"github.com/szoloth/partner/internal/config"
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/mcp/transport"
+	"github.com/szoloth/partner/internal/output"
+	"github.com/szoloth/partner/internal/theme"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,29 +27,158 @@ var (
 	version = "0.4.0"
 
 	// CLI flags
-	jsonOutput  bool
-	showVersion bool
-	paneFlag    string
-	refreshFlag bool
+	jsonOutput    bool
+	yamlOutput    bool
+	csvOutput     bool
+	csvNoHeader   bool
+	showVersion   bool
+	paneFlag      string
+	refreshFlag   bool
+	forceRefresh  bool
+	configPath    string
+	noAutoRefresh bool
+	watchFlag     bool
+	watchInterval time.Duration
+	noCache       bool
+	themeFlag     string
+	claudeModel   string
+	completionFor string
+	debugMCP      bool
+	validateCfg   bool
+	listTools     bool
+
+	// Loaded user configuration
+	cfg *config.Config
+
+	// MCP debug logger, set by --debug-mcp
+	mcpDebugLog *slog.Logger
+
+	// Theme parsed from --theme; nil leaves the config's theme
+	startTheme *theme.Theme
 )
 
 func init() {
 	flag.BoolVar(&jsonOutput, "json", false, "Output in JSON format (headless mode)")
-	flag.BoolVar(&showVersion, "version", false, "Show version")
-	flag.StringVar(&paneFlag, "pane", "tasks", "Initial pane to display (tasks, calendar, email, knowledge, crm, projects)")
-	flag.BoolVar(&refreshFlag, "refresh", false, "Refresh data and exit (use with --json)")
+	flag.BoolVar(&yamlOutput, "yaml", false, "Output in YAML format (headless mode)")
+	flag.BoolVar(&csvOutput, "csv", false, "Output the task list as CSV (headless mode, --pane tasks)")
+	flag.BoolVar(&csvNoHeader, "csv-no-header", false, "Omit the CSV header row, for appending to an existing file")
+	flag.BoolVar(&showVersion, "version", false, "Show version (with --json, build metadata as JSON)")
+	flag.StringVar(&paneFlag, "pane", "tasks", "Initial pane to display (tasks, calendar, email, knowledge, crm, projects, cos, log)")
+	flag.BoolVar(&refreshFlag, "refresh", false, "Refresh data and exit (use with --json or --yaml)")
+	flag.BoolVar(&forceRefresh, "force-refresh", false, "Ignore the pane refresh throttle; headless, skip cached responses")
+	flag.StringVar(&configPath, "config", config.DefaultPath, "Path to config file")
+	flag.BoolVar(&noAutoRefresh, "no-auto-refresh", false, "Disable background refresh of active panes")
+	flag.BoolVar(&watchFlag, "watch", false, "Re-fetch and emit NDJSON (or YAML documents) every --interval until interrupted (use with --json or --yaml)")
+	flag.DurationVar(&watchInterval, "interval", 60*time.Second, "Refresh interval for --watch")
+	flag.BoolVar(&noCache, "no-cache", false, "Always query MCP servers instead of the local response cache")
+	flag.StringVar(&themeFlag, "theme", "", "Starting theme, overriding the config")
+	flag.StringVar(&claudeModel, "claude-model", "", "Claude model for AI assist (claude-opus-4-5, claude-sonnet-4-5, claude-haiku-4-5), overriding the config")
+	flag.BoolVar(&debugMCP, "debug-mcp", false, "Log MCP requests and responses to "+transport.DefaultDebugLogPath)
+	flag.BoolVar(&validateCfg, "validate-config", false, "Print the resolved file paths, check they exist, and exit")
+	flag.BoolVar(&listTools, "list-tools", false, "Print the tools of the --pane's MCP server as JSON and exit")
+	flag.StringVar(&completionFor, "completion", "", "Print a shell completion script (bash, zsh, fish) and exit")
+}
+
+// flagSet reports whether a flag was passed explicitly on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
 
 func main() {
+	// Subcommands parse their own flags
+	if len(os.Args) > 1 && os.Args[1] == "cos" {
+		os.Exit(runCoS(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "version" {
+		os.Exit(runVersion(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		os.Exit(runInit(os.Args[2:]))
+	}
+
 	flag.Parse()
 
 	if showVersion {
-		fmt.Printf("partner v%s\n", version)
+		exitVersion()
+	}
+
+	formats := 0
+	for _, set := range []bool{jsonOutput, yamlOutput, csvOutput} {
+		if set {
+			formats++
+		}
+	}
+	if formats > 1 {
+		fmt.Fprintln(os.Stderr, "Error: --json, --yaml and --csv are mutually exclusive")
+		os.Exit(2)
+	}
+	if csvOutput && watchFlag {
+		fmt.Fprintln(os.Stderr, "Error: --watch is not supported with --csv")
+		os.Exit(2)
+	}
+
+	if completionFor != "" {
+		script, err := completion.Generate(completionFor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
 		os.Exit(0)
 	}
 
+	if themeFlag != "" {
+		t, err := theme.ParseTheme(themeFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		startTheme = &t
+	}
+
+	var err error
+	cfg, err = config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if validateCfg {
+		os.Exit(validateConfig())
+	}
+
+	// --claude-model overrides the configured model
+	if claudeModel != "" {
+		cfg.ClaudeModel = claudeModel
+	}
+
+	// --pane overrides the configured initial pane
+	if !flagSet("pane") {
+		paneFlag = cfg.InitialPane
+	}
+
+	if debugMCP {
+		logger, f, err := transport.OpenDebugLog(config.ExpandPath(transport.DefaultDebugLogPath))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		mcpDebugLog = logger
+	}
+
+	if listTools {
+		os.Exit(runListTools())
+	}
+
 	// Headless mode for automation
-	if jsonOutput {
+	if jsonOutput || yamlOutput || csvOutput {
 		runHeadless()
 		return
 	}
@@ -46,33 +187,183 @@ func main() {
 	runInteractive()
 }
 
+// validateConfig prints each resolved file path with its source and
+// returns 1 if any of the files is missing
+func validateConfig() int {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	status := 0
+	for _, p := range cfg.Paths {
+		state := "ok"
+		if _, err := os.Stat(p.Path); err != nil {
+			state = "missing"
+			if !os.IsNotExist(err) {
+				state = err.Error()
+			}
+			status = 1
+		}
+		fmt.Fprintf(tw, "%s\t%s\t(%s)\t%s\n", p.Key, p.Path, p.Source, state)
+	}
+	tw.Flush()
+	return status
+}
+
 func runHeadless() {
 	// Create app in headless mode
-	model := app.NewModel(app.WithHeadless(true), app.WithInitialPane(paneFlag))
+	// --watch wants fresh data on every tick, so it never reads the cache
+	opts := []app.Option{app.WithConfig(cfg), app.WithHeadless(true), app.WithInitialPane(paneFlag),
+		app.WithCache(!noCache && !watchFlag), app.WithForceRefresh(refreshFlag || forceRefresh),
+		app.WithTaskList(csvOutput)}
+	if mcpDebugLog != nil {
+		opts = append(opts, app.WithMCPDebugLog(mcpDebugLog))
+	}
+	model := app.NewModel(opts...)
+
+	if watchFlag {
+		runWatch(model)
+		return
+	}
+
+	if csvOutput {
+		runCSV(model)
+		return
+	}
 
 	// Fetch data
-	data, err := model.FetchCurrentPaneData()
+	data, err := model.FetchCurrentPaneData(context.Background())
 	if err != nil {
 		output := map[string]interface{}{
 			"error": err.Error(),
 			"pane":  paneFlag,
 		}
-		json.NewEncoder(os.Stdout).Encode(output)
+		if yamlOutput {
+			writeYAML(output)
+		} else {
+			json.NewEncoder(os.Stdout).Encode(output)
+		}
 		os.Exit(1)
 	}
 
-	// Output JSON
 	output := map[string]interface{}{
 		"pane": paneFlag,
 		"data": data,
 	}
+	if yamlOutput {
+		writeYAML(output)
+		return
+	}
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	enc.Encode(output)
 }
 
+// runListTools prints every tool of the --pane's MCP server (name,
+// description, inputSchema) as JSON and returns the exit code
+func runListTools() int {
+	opts := []app.Option{app.WithConfig(cfg), app.WithHeadless(true), app.WithInitialPane(paneFlag)}
+	if mcpDebugLog != nil {
+		opts = append(opts, app.WithMCPDebugLog(mcpDebugLog))
+	}
+	tools, err := app.NewModel(opts...).ListServerTools(context.Background())
+	if err != nil {
+		writeJSON(map[string]interface{}{"error": err.Error(), "pane": paneFlag})
+		return 1
+	}
+	if tools == nil {
+		tools = []mcp.Tool{}
+	}
+	writeJSON(tools)
+	return 0
+}
+
+// runCSV writes the task list to stdout as CSV; errors go to stderr
+func runCSV(model *app.Model) {
+	data, err := model.FetchCurrentPaneData(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	tasks, ok := data.([]providers.Task)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: --csv only supports --pane tasks, not %s\n", paneFlag)
+		os.Exit(1)
+	}
+	if err := output.WriteTasksCSV(os.Stdout, tasks, !csvNoHeader); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeYAML prints v as a YAML document ending in a newline
+func writeYAML(v interface{}) {
+	out, err := output.Marshal(v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(out) == 0 || out[len(out)-1] != '\n' {
+		out = append(out, '\n')
+	}
+	os.Stdout.Write(out)
+}
+
+// runWatch emits one JSON object per line every watchInterval until SIGINT;
+// with --yaml each update is a separate "---" document
+func runWatch(model *app.Model) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		output := map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"pane":      paneFlag,
+		}
+		data, err := model.FetchCurrentPaneData(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			output["error"] = err.Error()
+		} else {
+			output["data"] = data
+		}
+		if yamlOutput {
+			fmt.Println("---")
+			writeYAML(output)
+		} else {
+			enc.Encode(output)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
 func runInteractive() {
-	model := app.NewModel(app.WithInitialPane(paneFlag))
+	opts := []app.Option{app.WithConfig(cfg), app.WithInitialPane(paneFlag), app.WithCache(!noCache)}
+	if startTheme != nil {
+		opts = append(opts, app.WithTheme(*startTheme))
+	}
+	if mcpDebugLog != nil {
+		opts = append(opts, app.WithMCPDebugLog(mcpDebugLog))
+	}
+	if noAutoRefresh {
+		opts = append(opts, app.WithRefreshInterval(0))
+	}
+	if forceRefresh {
+		opts = append(opts, app.WithForceRefresh(true))
+	}
+	model := app.NewModel(opts...)
 
 	p := tea.NewProgram(
 		model,
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// Build metadata, set by the Makefile with
+// -ldflags "-X main.commit=... -X main.builtAt=..."; empty in dev builds
+var (
+	commit  string
+	builtAt string
+)
+
+// versionInfo is the --version --json object
+type versionInfo struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	GOOS      string `json:"goos"`
+	GOARCH    string `json:"goarch"`
+	BuiltAt   string `json:"built_at"`
+	Commit    string `json:"commit"`
+}
+
+// printVersion writes the plain "partner vX" line, or the build metadata
+// as JSON
+func printVersion(asJSON bool) {
+	if !asJSON {
+		fmt.Printf("partner v%s\n", version)
+		return
+	}
+	writeJSON(versionInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		BuiltAt:   builtAt,
+		Commit:    commit,
+	})
+}
+
+// runVersion handles "partner version [--json]"
+func runVersion(args []string) int {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Output the build metadata as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	printVersion(*asJSON)
+	return 0
+}
+
+// exitVersion is the --version flag: same output as the subcommand
+func exitVersion() {
+	printVersion(jsonOutput)
+	os.Exit(0)
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/panes/calendar"
+	cospane "github.com/szoloth/partner/internal/panes/cos"
+	"github.com/szoloth/partner/internal/panes/knowledge"
+	"github.com/szoloth/partner/internal/panes/projects"
+	"github.com/szoloth/partner/internal/panes/tasks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// systemContextTTL is how long the assembled pane context is reused
+const systemContextTTL = 5 * time.Minute
+
+// buildSystemContext assembles context summaries from every pane that
+// provides one. The result is cached for systemContextTTL so repeated
+// AI requests don't rebuild it; pane data messages clear it sooner.
+func (m *Model) buildSystemContext() string {
+	if m.systemContext != "" && time.Since(m.systemContextAt) < systemContextTTL {
+		return m.systemContext
+	}
+
+	// Collect summarizers in a stable order
+	var types []panes.PaneType
+	for t, p := range m.paneInstances {
+		if _, ok := p.(panes.ContextSummarizer); ok {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	var parts []string
+	for _, t := range types {
+		if s := buildPaneContext(m.paneInstances[t]); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	m.systemContext = strings.Join(parts, "\n\n")
+	m.systemContextAt = time.Now()
+	return m.systemContext
+}
+
+// invalidateSystemContext drops the cached pane context so the next AI
+// request describes the panes as they are now
+func (m *Model) invalidateSystemContext() {
+	m.systemContext = ""
+}
+
+// changesPaneData reports whether msg loads or changes data a pane
+// summarizes for the AI context
+func changesPaneData(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tasks.TasksLoadedMsg, tasks.TaskCompletedMsg, tasks.TaskUncompletedMsg, tasks.TaskCreatedMsg,
+		tasks.TaskMovedMsg, tasks.TasksBulkCompletedMsg, tasks.ChecklistUpdatedMsg, tasks.NotesSavedMsg,
+		tasks.TaskProjectSetMsg, tasks.TaskDeadlineSetMsg, tasks.TaskTagsSetMsg,
+		calendar.EventsLoadedMsg, calendar.EventCreatedMsg, calendar.RSVPUpdatedMsg,
+		cospane.StateLoadedMsg, cospane.ActionExecutedMsg, cospane.DuplicatesMergedMsg,
+		projects.ProjectsLoadedMsg, knowledge.PagesLoadedMsg:
+		return true
+	}
+	return false
+}
+
+// buildPaneContext renders one pane's context summary under a title
+// header, or "" if the pane has nothing to contribute
+func buildPaneContext(pane panes.Pane) string {
+	summarizer, ok := pane.(panes.ContextSummarizer)
+	if !ok {
+		return ""
+	}
+	summary := summarizer.GetContextSummary()
+	if summary == "" {
+		return ""
+	}
+	return "=== " + pane.Title() + " ===\n" + summary
+}
+
+// visiblePanes returns the panes on screen: every active pane in split and
+// grid layouts, just the focused one otherwise
+func (m *Model) visiblePanes() []panes.Pane {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return nil
+	}
+	if m.layout == LayoutSingle {
+		return []panes.Pane{m.activePanes[m.focusedPane]}
+	}
+	return m.activePanes
+}
+
+// buildVisibleContext joins the context of each visible pane, separated by
+// "---", and lists the panes that contributed
+func (m *Model) buildVisibleContext() (string, []panes.PaneType) {
+	var parts []string
+	var types []panes.PaneType
+	for _, p := range m.visiblePanes() {
+		if c := buildPaneContext(p); c != "" {
+			parts = append(parts, c)
+			types = append(types, p.Type())
+		}
+	}
+	return strings.Join(parts, "\n\n---\n\n"), types
+}
+
+// multiPanePrompt asks about every visible pane at once, e.g. "Given my
+// tasks and today's calendar, what should I focus on next?"
+func multiPanePrompt(types []panes.PaneType) string {
+	var subjects []string
+	for _, t := range types {
+		switch t {
+		case panes.PaneTasks:
+			subjects = append(subjects, "tasks")
+		case panes.PaneCalendar:
+			subjects = append(subjects, "today's calendar")
+		case panes.PaneCoS:
+			subjects = append(subjects, "Chief of Staff state")
+		default:
+			subjects = append(subjects, t.String())
+		}
+	}
+
+	var list string
+	switch n := len(subjects); n {
+	case 0:
+		return "What's the most important thing I should focus on right now?"
+	case 1:
+		list = subjects[0]
+	default:
+		list = strings.Join(subjects[:n-1], ", ") + " and " + subjects[n-1]
+	}
+	return "Given my " + list + ", what should I focus on next? Be brief (2-3 sentences)."
+}
+
+// contextPaneNames renders the panes a request drew context from
+func contextPaneNames(types []panes.PaneType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
@@ -3,22 +3,37 @@ package app
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/szoloth/partner/internal/bus"
+	"github.com/szoloth/partner/internal/cache"
 	"github.com/szoloth/partner/internal/claude"
+	"github.com/szoloth/partner/internal/config"
 	cosstate "github.com/szoloth/partner/internal/cos"
 	"github.com/szoloth/partner/internal/mcp"
 	"github.com/szoloth/partner/internal/mcp/providers"
 	"github.com/szoloth/partner/internal/mcp/transport"
+	"github.com/szoloth/partner/internal/notifications"
 	"github.com/szoloth/partner/internal/panes"
 	"github.com/szoloth/partner/internal/panes/calendar"
 	cospane "github.com/szoloth/partner/internal/panes/cos"
+	"github.com/szoloth/partner/internal/panes/knowledge"
+	logpane "github.com/szoloth/partner/internal/panes/log"
+	"github.com/szoloth/partner/internal/panes/projects"
 	"github.com/szoloth/partner/internal/panes/tasks"
+	"github.com/szoloth/partner/internal/pomodoro"
+	"github.com/szoloth/partner/internal/render"
+	"github.com/szoloth/partner/internal/system"
 	"github.com/szoloth/partner/internal/theme"
+	"github.com/szoloth/partner/internal/webhook"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/sync/errgroup"
 )
 
 // LayoutMode determines pane arrangement
@@ -26,14 +41,36 @@ type LayoutMode int
 
 const (
 	LayoutSingle LayoutMode = iota
-	LayoutSplitH // Horizontal split (side by side)
-	LayoutSplitV // Vertical split (stacked)
-	LayoutGrid   // 2x2 grid
+	LayoutSplitH            // Horizontal split (side by side)
+	LayoutSplitV            // Vertical split (stacked)
+	LayoutGrid              // 2x2 grid
 )
 
+// themeRegistry lists the themes available for cycling, in order
+var themeRegistry = []theme.Theme{
+	theme.CatppuccinMocha,
+	theme.TeenageEngineering,
+	theme.Nord,
+	theme.GruvboxDark,
+	theme.Dracula,
+}
+
 // Option configures the app
 type Option func(*Model)
 
+// WithConfig replaces the built-in defaults with user settings
+func WithConfig(cfg *config.Config) Option {
+	return func(m *Model) {
+		m.cfg = cfg
+		if cfg.InitialPane != "" {
+			m.initialPane = panes.ParsePaneType(cfg.InitialPane)
+		}
+		if layout, ok := parseLayout(cfg.InitialLayout); ok {
+			m.startLayout = layout
+		}
+	}
+}
+
 // WithHeadless sets headless mode
 func WithHeadless(headless bool) Option {
 	return func(m *Model) {
@@ -48,8 +85,94 @@ func WithInitialPane(paneName string) Option {
 	}
 }
 
+// WithTheme selects the starting theme, overriding the config. Callers
+// resolve names with theme.ParseTheme, which reports unknown ones.
+func WithTheme(t theme.Theme) Option {
+	return func(m *Model) {
+		m.startTheme = &t
+	}
+}
+
+// WithMCPDebugLog logs every MCP request and response to logger. Without
+// it transports are used unwrapped.
+func WithMCPDebugLog(logger *slog.Logger) Option {
+	return func(m *Model) {
+		m.mcpDebug = logger
+	}
+}
+
+// WithRefreshInterval sets how often active panes are refreshed in the
+// background; zero disables auto-refresh
+func WithRefreshInterval(d time.Duration) Option {
+	return func(m *Model) {
+		m.tickInterval = d
+	}
+}
+
+// WithForceRefresh disables the pane refresh throttle and, in headless
+// mode, skips reading from the response cache
+func WithForceRefresh(force bool) Option {
+	return func(m *Model) {
+		m.forceRefresh = force
+	}
+}
+
+// WithTaskList makes FetchCurrentPaneData return the Today list as
+// []providers.Task instead of the MCP debug summary, for --csv
+func WithTaskList(enabled bool) Option {
+	return func(m *Model) {
+		m.taskList = enabled
+	}
+}
+
+// WithCache enables or disables the SQLite cache for MCP read calls
+func WithCache(enabled bool) Option {
+	return func(m *Model) {
+		m.cacheEnabled = enabled
+	}
+}
+
+// parseLayout converts a layout name to a LayoutMode
+func parseLayout(name string) (LayoutMode, bool) {
+	switch name {
+	case "single":
+		return LayoutSingle, true
+	case "hsplit":
+		return LayoutSplitH, true
+	case "vsplit":
+		return LayoutSplitV, true
+	case "grid":
+		return LayoutGrid, true
+	default:
+		return LayoutSingle, false
+	}
+}
+
+// String returns the layout name accepted by parseLayout
+func (l LayoutMode) String() string {
+	switch l {
+	case LayoutSplitH:
+		return "hsplit"
+	case LayoutSplitV:
+		return "vsplit"
+	case LayoutGrid:
+		return "grid"
+	default:
+		return "single"
+	}
+}
+
+// lookupTheme finds a theme by name
+func lookupTheme(name string) (theme.Theme, bool) {
+	t, err := theme.ParseTheme(name)
+	return t, err == nil
+}
+
 // Model is the root application model
 type Model struct {
+	// User configuration
+	cfg *config.Config
+
 	// Layout state
 	layout      LayoutMode
 	activePanes []panes.Pane
@@ -57,23 +180,108 @@ type Model struct {
 
 	// All panes (lazily initialized)
 	paneInstances map[panes.PaneType]panes.Pane
+	logPane       *logpane.Model // Also in paneInstances; status history
+
+	// MCP tools modal opened with "?"; nil when closed
+	toolsModal *toolsModal
+
+	// Restarts the MCP servers after the machine wakes (interactive only)
+	sleepWake *system.SleepWakeDetector
+
+	// Messages are dispatched here after Update so panes can react to
+	// each other's messages (see panes.BusSubscriber)
+	bus *bus.EventBus
 
 	// MCP providers
 	thingsProvider   *providers.ThingsProvider
 	calendarProvider providers.CalendarProviderInterface
+	notionProvider   *providers.NotionProvider // Nil unless notion_api_key is set
 
 	// CoS provider (local state file)
 	cosProvider *cosstate.Provider
 
 	// Global state
-	width             int
-	height            int
-	ready             bool
-	status            string
-	headless          bool
+	width         int
+	height        int
+	ready         bool
+	status        string
+	statusWarning bool // Render status with the warning style
+	headless      bool
+	cacheEnabled  bool
+	forceRefresh  bool         // Set by --force-refresh; skips the refresh throttle
+	taskList      bool         // Headless tasks as []providers.Task (see WithTaskList)
+	cache         *cache.Cache // Opened on first provider init
+	mcpDebug      *slog.Logger // Set by --debug-mcp; nil disables logging
+
+	// Receives every AI response when webhook_url is set; nil otherwise
+	webhook *webhook.Dispatcher
+
+	// MCP clients by server ID, for health checks; reconnecting marks
+	// servers whose connection is being restored
+	clientsMu         sync.Mutex
+	mcpClients        map[string]*mcp.Client
+	reconnecting      map[string]bool
 	initialPane       panes.PaneType
+	startLayout       LayoutMode // Layout applied once providers are ready
 	awaitingWindowCmd bool
+	keys              *config.Keybindings
 	previousLayout    LayoutMode // For maximize/restore
+	splitRatio        float64    // First pane's share in split layouts (0.2-0.8)
+
+	// Theme chosen via WithTheme; overrides the config theme
+	startTheme *theme.Theme
+
+	// Background refresh
+	tickInterval  time.Duration
+	lastRefreshed map[panes.PaneType]time.Time
+
+	// Session persistence
+	restoreSession bool
+	session        *Session // Loaded session awaiting panes, nil once applied
+
+	// MCP provider startup state
+	providersReady bool
+	providerErrors map[string]error
+
+	// Global search overlay ("/"), nil when closed
+	search *SearchPane
+
+	// Quick-action palette (ctrl+p), nil when closed
+	palette        *palette
+	paletteActions []PaletteAction
+
+	// Command mode (":" prompt)
+	commandMode bool
+	commandBuf  string
+
+	// Inbox badge (refreshed independently of the tasks pane)
+	inboxCount int
+
+	// Next-meeting countdown; upcomingEvents holds the last calendar load
+	// in start order and nextEvent points into it
+	upcomingEvents []providers.CalendarEvent
+	nextEvent      *providers.CalendarEvent
+
+	// Pomodoro timer shown in the status bar; pomodoroGen invalidates
+	// ticks from earlier runs
+	pomodoro    *pomodoro.Timer
+	pomodoroGen int
+
+	// Keyboard macros (see macro.go). macroRegister is set while recording;
+	// macroRun invalidates steps from earlier playbacks
+	macros         map[string][]macroKey
+	macroRegister  string
+	macroPending   string
+	recordedKeys   []tea.KeyMsg
+	macroPlayback  []macroKey
+	macroRun       int
+	lastMacro      string
+	replayingMacro bool
+
+	// Desktop reminders for upcoming events, keyed by reminderKey
+	notifier           notifications.Notifier
+	scheduledReminders map[string]bool
+	notifiedEvents     map[string]bool
 
 	// AI state
 	claudeClient   *claude.Client
@@ -81,27 +289,80 @@ type Model struct {
 	aiResponse     string
 	aiAction       *claude.Action
 	aiLoading      bool
-	aiUsage        *claude.Usage // Token usage from last call
+	aiUsage        *claude.Usage    // Token usage from last call
+	aiContextPanes []panes.PaneType // Panes the last request drew context from
+
+	// Sent to the CoS pane when the briefing in the modal is closed
+	aiBriefing *cospane.BriefingClosedMsg
+
+	// Cost of every AI call this session, for the ai_cost widget
+	aiCostTotal float64
+
+	// Streaming reply in progress
+	aiStreaming bool
+	aiChunks    <-chan string
+	aiDone      <-chan error
+	aiCursorOn  bool
+
+	// Cached pane context for AI requests (see buildSystemContext)
+	systemContext   string
+	systemContextAt time.Time
 
 	// Styles
 	styles *theme.Styles
 }
 
+// defaultRefreshInterval is how often active panes are refreshed in the background
+const defaultRefreshInterval = 5 * time.Minute
+
 // NewModel creates a new app model
 func NewModel(opts ...Option) *Model {
 	m := &Model{
-		layout:        LayoutSingle,
-		paneInstances: make(map[panes.PaneType]panes.Pane),
-		styles:        theme.NewStyles(),
-		initialPane:   panes.PaneTasks,
-		claudeClient:  claude.NewClient(),
-		cosProvider:   cosstate.NewProvider(),
+		cfg:            config.Default(),
+		layout:         LayoutSingle,
+		splitRatio:     0.5,
+		tickInterval:   defaultRefreshInterval,
+		lastRefreshed:  make(map[panes.PaneType]time.Time),
+		paneInstances:  make(map[panes.PaneType]panes.Pane),
+		styles:         theme.NewStyles(theme.Default),
+		initialPane:    panes.PaneTasks,
+		bus:            bus.New(),
+		cosProvider:    cosstate.NewProvider(),
+		restoreSession: true,
+		cacheEnabled:   true,
+		mcpClients:     make(map[string]*mcp.Client),
+		reconnecting:   make(map[string]bool),
+
+		pomodoro:           pomodoro.New(),
+		notifier:           notifications.NewMacNotifier(),
+		scheduledReminders: make(map[string]bool),
+		notifiedEvents:     make(map[string]bool),
 	}
 
 	for _, opt := range opts {
 		opt(m)
 	}
 
+	m.keys = &m.cfg.Keybindings
+	m.claudeClient = claude.NewClientWithModel(m.cfg.ClaudeModel)
+	m.paletteActions = m.defaultPaletteActions()
+	m.webhook = m.newWebhook()
+
+	// The log pane exists from the start so nothing logged before the
+	// providers come up is lost
+	m.logPane = logpane.New()
+	m.paneInstances[panes.PaneLog] = m.logPane.SetStyles(m.styles)
+
+	if m.restoreSession && !m.headless {
+		m.restoreSessionState()
+	}
+
+	if m.startTheme != nil {
+		m.styles.SetTheme(*m.startTheme)
+	} else if t, ok := lookupTheme(m.cfg.Theme); ok {
+		m.styles.SetTheme(t)
+	}
+
 	return m
 }
 
@@ -109,52 +370,317 @@ func NewModel(opts ...Option) *Model {
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.initMCPProviders(),
+		m.startSleepWakeDetector(),
 		tea.SetWindowTitle("Partner"),
 	)
 }
 
-// initMCPProviders initializes MCP server connections
+// newThingsTransport connects to the Things MCP server over HTTP when a URL
+// is configured, otherwise via the local Python wrapper script
+func (m *Model) newThingsTransport() (mcp.Transport, error) {
+	if m.cfg.ThingsMCPURL != "" {
+		return transport.NewHTTPTransport(m.cfg.ThingsMCPURL, httpOptions(m.cfg.ThingsMCPToken)...), nil
+	}
+	return transport.NewStdioTransport(m.cfg.ThingsMCPScript, nil)
+}
+
+// newGCalTransport connects to the Google Calendar MCP server over HTTP when
+// a URL is configured, otherwise by spawning it with npx
+func (m *Model) newGCalTransport() (mcp.Transport, error) {
+	if m.cfg.GCalMCPURL != "" {
+		return transport.NewHTTPTransport(m.cfg.GCalMCPURL, httpOptions(m.cfg.GCalMCPToken)...), nil
+	}
+	return transport.NewStdioTransport("npx", []string{"-y", "@cocal/google-calendar-mcp"},
+		transport.WithEnv("GOOGLE_OAUTH_CREDENTIALS="+m.cfg.GCalCredentialsPath))
+}
+
+// newNotionTransport spawns the Notion MCP server with npx
+func (m *Model) newNotionTransport() (mcp.Transport, error) {
+	return transport.NewStdioTransport("npx", []string{"-y", "@modelcontextprotocol/server-notion"},
+		transport.WithEnv("NOTION_API_KEY="+m.cfg.NotionAPIKey))
+}
+
+func httpOptions(token string) []transport.HTTPOption {
+	if token == "" {
+		return nil
+	}
+	return []transport.HTTPOption{transport.WithBearerToken(token)}
+}
+
+// How long cached read results are served before hitting the server again
+const (
+	thingsCacheTTL = 5 * time.Minute
+	gcalCacheTTL   = 1 * time.Minute
+	notionCacheTTL = 5 * time.Minute
+)
+
+// minRefreshInterval is the configured pane refresh throttle
+func (m *Model) minRefreshInterval() time.Duration {
+	if m.forceRefresh {
+		return 0
+	}
+	return time.Duration(m.cfg.MinRefreshSeconds) * time.Second
+}
+
+// openCache opens the response cache once. When it can't be opened the app
+// runs uncached.
+func (m *Model) openCache() {
+	if !m.cacheEnabled || m.cache != nil {
+		return
+	}
+	c, err := cache.Open(config.ExpandPath(cache.DefaultPath))
+	if err != nil {
+		m.cacheEnabled = false
+		return
+	}
+	m.cache = c
+}
+
+// toolClient builds the MCP client for a server, cached when enabled
+func (m *Model) toolClient(t mcp.Transport, serverID string, ttl time.Duration) mcp.ToolCaller {
+	if m.mcpDebug != nil {
+		t = transport.NewDebugTransport(t, serverID, m.mcpDebug)
+	}
+	client := mcp.NewClient(t, serverID)
+	m.trackClient(client)
+	if m.cache == nil {
+		return client
+	}
+	return cache.NewCachedClient(client, m.cache, ttl)
+}
+
+// dropPaneCache forgets the cached reads of the server behind pt, so an
+// explicit refresh fetches live data instead of an answer up to a TTL old.
+// Offline snapshots are kept for when the server can't be reached.
+func (m *Model) dropPaneCache(pt panes.PaneType) {
+	if m.cache == nil {
+		return
+	}
+	if server, ok := serverForPane(pt); ok {
+		m.cache.InvalidatePrefix(server + ":")
+	}
+}
+
+// Provider names used as keys in MCPInitializedMsg.ProviderErrors
+const (
+	providerThings = "things"
+	providerGCal   = "gcal"
+	providerNotion = "notion" // Optional; only started with notion_api_key
+)
+
+// providerNames lists MCP providers in status bar order
+var providerNames = []string{providerThings, providerGCal, providerNotion}
+
+// initMCPProviders initializes MCP server connections concurrently. The
+// Cmd only builds providers and panes; Update installs them from the
+// MCPInitializedMsg, since View reads the same fields meanwhile.
 func (m *Model) initMCPProviders() tea.Cmd {
+	// Opened here so the Cmd below only reads m.cache
+	m.openCache()
+
 	return func() tea.Msg {
-		// Initialize Things 3 MCP
-		// Use the local Python Things MCP server via wrapper script
-thingsTransport, err := transport.NewStdioTransport("/Users/samuelz/partner/scripts/things-mcp.sh", nil)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to create Things transport: %w", err)}
-		}
+		var (
+			thingsProvider   *providers.ThingsProvider
+			calendarProvider providers.CalendarProviderInterface
+			thingsErr        error
+			gcalErr          error
+		)
+
+		// Errors are collected per provider rather than returned so that one
+		// failing server doesn't cancel the other
+		var g errgroup.Group
+
+		g.Go(func() error {
+			// Initialize Things 3 MCP
+			thingsTransport, err := m.newThingsTransport()
+			if err != nil {
+				thingsErr = fmt.Errorf("failed to create Things transport: %w", err)
+				return nil
+			}
+			thingsProvider = providers.NewThingsProvider(m.toolClient(thingsTransport, "things", thingsCacheTTL))
+			return nil
+		})
 
-		thingsClient := mcp.NewClient(thingsTransport, "things")
-		m.thingsProvider = providers.NewThingsProvider(thingsClient)
+		g.Go(func() error {
+			// Initialize Google Calendar MCP provider
+			if !m.cfg.UsesCalendar("gcal") {
+				return nil
+			}
+			gcalTransport, err := m.newGCalTransport()
+			if err != nil {
+				gcalErr = fmt.Errorf("failed to create Google Calendar transport: %w", err)
+				return nil
+			}
+			gcal := providers.NewGCalProvider(m.toolClient(gcalTransport, "google-calendar", gcalCacheTTL),
+				m.cfg.GCalCalendarIDs, providers.WithTimezone(m.cfg.HomeTimezone))
+			gcal.SetUserEmail(m.cfg.UserEmail)
+			calendarProvider = gcal
+			return nil
+		})
 
-		// Initialize Google Calendar MCP provider
-		gcalTransport, err := transport.NewStdioTransport("npx", []string{"-y", "@cocal/google-calendar-mcp"},
-			transport.WithEnv(`GOOGLE_OAUTH_CREDENTIALS=/Users/samuelz/Documents/LLM CONTEXT/credentials.json`))
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to create Google Calendar transport: %w", err)}
+		g.Wait()
+
+		msg := MCPInitializedMsg{
+			ProviderErrors: make(map[string]error),
+			Panes:          make(map[panes.PaneType]panes.Pane),
 		}
-		gcalClient := mcp.NewClient(gcalTransport, "google-calendar")
-		m.calendarProvider = providers.NewGCalProvider(gcalClient)
 
-		// Create panes
-		tasksPane := tasks.New(m.thingsProvider)
-		m.paneInstances[panes.PaneTasks] = tasksPane
+		// Create panes for the providers that came up
+		if thingsErr == nil {
+			msg.Things = thingsProvider
+			msg.Panes[panes.PaneTasks] = tasks.New(thingsProvider)
+			msg.Panes[panes.PaneProjects] = projects.New(thingsProvider)
+		} else {
+			msg.ProviderErrors[providerThings] = thingsErr
+		}
 
-		calendarPane := calendar.New(m.calendarProvider)
-		m.paneInstances[panes.PaneCalendar] = calendarPane
+		if gcalErr != nil {
+			msg.ProviderErrors[providerGCal] = gcalErr
+		}
+		// Apple Calendar still fills the pane when Google Calendar failed
+		if merged := m.mergeCalendars(calendarProvider); merged != nil {
+			msg.Calendar = merged
+			cal := calendar.New(merged)
+			cal.SetTimezone(m.cfg.HomeLocation())
+			if thingsErr == nil {
+				cal.SetTaskProvider(thingsProvider)
+			}
+			msg.Panes[panes.PaneCalendar] = cal
+		}
 
 		// Create CoS pane (no MCP required - uses local state file)
-		cosPane := cospane.New()
-		m.paneInstances[panes.PaneCoS] = cosPane
+		msg.Panes[panes.PaneCoS] = cospane.New()
+
+		// The Knowledge pane shows Notion when it is configured and
+		// otherwise an Obsidian vault, if one is set
+		if m.cfg.NotionAPIKey != "" {
+			if notionTransport, err := m.newNotionTransport(); err != nil {
+				msg.ProviderErrors[providerNotion] = fmt.Errorf("failed to create Notion transport: %w", err)
+			} else {
+				msg.Notion = providers.NewNotionProvider(m.toolClient(notionTransport, "notion", notionCacheTTL))
+				msg.Panes[panes.PaneKnowledge] = knowledge.New(msg.Notion)
+			}
+		} else if m.cfg.ObsidianVaultPath != "" {
+			vault := providers.NewObsidianProvider(m.cfg.ObsidianVaultPath)
+			msg.Panes[panes.PaneKnowledge] = knowledge.NewObsidian(vault)
+		}
+
+		return msg
+	}
+}
+
+// MCPInitializedMsg indicates MCP providers are ready and carries what
+// initMCPProviders built
+type MCPInitializedMsg struct {
+	ProviderErrors map[string]error // Providers that failed to start, keyed by name
+	Things         *providers.ThingsProvider
+	Calendar       providers.CalendarProviderInterface
+	Notion         *providers.NotionProvider
+	Panes          map[panes.PaneType]panes.Pane
+}
+
+// installProviders adopts the providers and panes from initMCPProviders
+// and focuses the initial pane
+func (m *Model) installProviders(msg MCPInitializedMsg) {
+	m.thingsProvider = msg.Things
+	m.calendarProvider = msg.Calendar
+	m.notionProvider = msg.Notion
+	for pt, pane := range msg.Panes {
+		m.paneInstances[pt] = pane
+	}
+
+	for _, pane := range m.paneInstances {
+		if t, ok := pane.(panes.RefreshThrottler); ok {
+			t.SetMinRefreshInterval(m.minRefreshInterval())
+		}
+	}
 
-		// Start with tasks focused
-		m.activePanes = []panes.Pane{tasksPane.Focus().(panes.Pane)}
+	// Start with the configured pane focused, falling back to the
+	// first one that is available
+	initial, ok := m.paneInstances[m.initialPane]
+	for _, pt := range []panes.PaneType{panes.PaneTasks, panes.PaneCalendar, panes.PaneCoS} {
+		if ok {
+			break
+		}
+		initial, ok = m.paneInstances[pt]
+	}
+	m.activePanes = []panes.Pane{initial.Focus().(panes.Pane)}
+}
+
+// inboxCountInterval is how often the inbox badge is refreshed
+const inboxCountInterval = 60 * time.Second
+
+// InboxCountMsg carries the latest inbox task count
+type InboxCountMsg struct {
+	Count int
+	Err   error
+}
+
+// inboxCountTickMsg triggers a background inbox count refresh
+type inboxCountTickMsg struct{}
+
+// fetchInboxCount fetches the inbox count without touching the task list
+func (m *Model) fetchInboxCount() tea.Cmd {
+	provider := m.thingsProvider
+	if provider == nil {
+		return nil
+	}
 
-		return MCPInitializedMsg{}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		count, err := provider.GetInboxCount(ctx)
+		return InboxCountMsg{Count: count, Err: err}
 	}
 }
 
-// MCPInitializedMsg indicates MCP providers are ready
-type MCPInitializedMsg struct{}
+// scheduleAutoRefresh waits for the next background refresh of active panes
+func (m *Model) scheduleAutoRefresh() tea.Cmd {
+	if m.tickInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.tickInterval, func(time.Time) tea.Msg {
+		return RefreshMsg{Pane: allActive}
+	})
+}
+
+// refreshActivePanes refreshes each active pane once; with staleOnly set,
+// only those whose data is older than tickInterval; without it, cached
+// reads are dropped first so the panes show live data. Results arrive as
+// regular load messages, so input in progress is not disturbed.
+func (m *Model) refreshActivePanes(staleOnly bool) tea.Cmd {
+	var cmds []tea.Cmd
+	seen := make(map[panes.PaneType]bool)
+	for _, pane := range m.activePanes {
+		pt := pane.Type()
+		if seen[pt] {
+			continue
+		}
+		seen[pt] = true
+		if staleOnly && time.Since(m.lastRefreshed[pt]) < m.tickInterval {
+			continue
+		}
+		if !staleOnly {
+			m.dropPaneCache(pt)
+		}
+		if instance, ok := m.paneInstances[pt]; ok {
+			cmds = append(cmds, instance.Refresh())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// scheduleInboxCount waits for the next inbox count refresh
+func scheduleInboxCount() tea.Cmd {
+	return tea.Tick(inboxCountInterval, func(time.Time) tea.Msg {
+		return inboxCountTickMsg{}
+	})
+}
+
+// AIStreamChunkMsg carries the next piece of a streaming reply
+type AIStreamChunkMsg struct {
+	Chunk string
+}
 
 // AIResponseMsg carries Claude's response
 type AIResponseMsg struct {
@@ -163,65 +689,173 @@ type AIResponseMsg struct {
 	Err       error
 	SessionID string
 	Usage     *claude.Usage
+
+	// Briefing is sent to the CoS pane when the modal closes; set for
+	// morning briefings only
+	Briefing *cospane.BriefingClosedMsg
 }
 
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if changesPaneData(msg) {
+		m.invalidateSystemContext()
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if cmd, handled := m.handleMacroKey(msg); handled {
+			return m, cmd
+		}
+
+		// The search overlay owns the keyboard while open
+		if m.search != nil {
+			open, cmd := m.search.Update(msg)
+			if !open {
+				m.search = nil
+			}
+			return m, cmd
+		}
+
+		// So does the action palette
+		if m.palette != nil {
+			return m, m.handlePaletteKey(msg)
+		}
+
+		// And the MCP tools modal
+		if m.toolsModal != nil {
+			return m, m.handleToolsKey(msg)
+		}
+
+		// Command mode swallows all keys until Enter/Esc
+		if m.commandMode {
+			return m, m.handleCommandKey(msg)
+		}
+
+		// A pane with an open text input gets every key
+		if m.focusedCapturingInput() {
+			pane := m.activePanes[m.focusedPane]
+			updated, cmd := pane.Update(msg)
+			m.activePanes[m.focusedPane] = updated.(panes.Pane)
+			return m, cmd
+		}
+
 		// Global keybindings
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		key := msg.String()
+		if consumed, done := m.matchMaximize(key); consumed {
+			if done {
+				return m, m.maximizePane()
+			}
+			return m, nil
+		}
 
-		case "tab":
+		// ctrl+arrows drag the split boundary
+		if m.resizeSplit(key) {
+			return m, nil
+		}
+
+		// Pane number shortcuts (direct, no modifier needed). Keys naming
+		// the focused pane or one that isn't available fall through to the
+		// pane.
+		if target, ok := m.paneShortcut(key); ok && m.canSwitchTo(target) {
+			return m, m.switchToPane(target)
+		}
+
+		// Keys the focused pane wants for itself skip the global bindings
+		if m.focusedClaims(key) {
+			pane := m.activePanes[m.focusedPane]
+			updated, cmd := pane.Update(msg)
+			m.activePanes[m.focusedPane] = updated.(panes.Pane)
+			return m, cmd
+		}
+
+		// ctrl+h/j/k/l move focus across a split or grid; a key with no
+		// pane in its direction falls through to the focused pane
+		if dir, ok := m.focusKeyDirection(key); ok && m.focusDirection(dir) {
+			return m, nil
+		}
+
+		switch key {
+		case m.keys.Quit, "ctrl+c":
+			return m, m.quit()
+
+		case m.keys.FocusNext:
 			m.focusNext()
 			return m, nil
 
-		case "shift+tab":
+		case m.keys.FocusPrev:
 			m.focusPrev()
 			return m, nil
 
-		// Pane number shortcuts (direct, no modifier needed)
-		case "0":
-			return m, m.switchToPane(panes.PaneCoS) // Chief of Staff - primary pane
-		case "1":
-			return m, m.switchToPane(panes.PaneTasks)
-		case "2":
-			return m, m.switchToPane(panes.PaneCalendar)
-		case "3":
-			return m, m.switchToPane(panes.PaneEmail)
-		case "4":
-			return m, m.switchToPane(panes.PaneKnowledge)
-		case "5":
-			return m, m.switchToPane(panes.PaneCRM)
-		case "6":
-			return m, m.switchToPane(panes.PaneProjects)
-
 		// Layout toggles
-		case "\\":
-			return m, m.toggleSplit()
-		case "|":
+		case m.keys.ToggleSplit:
 			return m, m.toggleSplit()
 
-		// Maximize current pane (Ctrl+w o)
-		case "ctrl+w":
-			m.awaitingWindowCmd = true
+		// Split ratio
+		case m.keys.ShrinkSplit:
+			if m.layout == LayoutSplitH || m.layout == LayoutSplitV {
+				m.setSplitRatio(m.splitRatio - splitRatioStep)
+				return m, nil
+			}
+		case m.keys.GrowSplit:
+			if m.layout == LayoutSplitH || m.layout == LayoutSplitV {
+				m.setSplitRatio(m.splitRatio + splitRatioStep)
+				return m, nil
+			}
+
+		// Theme cycling
+		case m.keys.CycleTheme:
+			return m, m.cycleTheme()
+
+		// Global search
+		case m.keys.Search:
+			return m, m.openSearch()
+
+		// Quick-action palette
+		case m.keys.Palette:
+			return m, m.openPalette()
+
+		// Tools offered by the focused pane's MCP server
+		case m.keys.ListTools:
+			return m, m.openToolsModal()
+
+		// Markdown export of the focused pane
+		case m.keys.Export:
+			if cmd := m.exportFocused(); cmd != nil {
+				return m, cmd
+			}
+
+		// Command mode
+		case m.keys.CommandMode:
+			m.commandMode = true
+			m.commandBuf = ""
 			return m, nil
-		case "o":
-			if m.awaitingWindowCmd {
-				m.awaitingWindowCmd = false
-				return m, m.maximizePane()
+
+		// Pomodoro timer
+		case m.keys.Pomodoro:
+			return m, m.togglePomodoro()
+		case m.keys.PomodoroReset:
+			m.resetPomodoro()
+			return m, nil
+
+		// Refresh the focused pane
+		case m.keys.Refresh:
+			if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
+				m.dropPaneCache(m.activePanes[m.focusedPane].Type())
+				if cmd := m.activePanes[m.focusedPane].Refresh(); cmd != nil {
+					return m, cmd
+				}
+				m.status = "Refreshed just now"
+				return m, clearStatusAfter(m.status, 2*time.Second)
 			}
 
 		// AI assist
-		case "a":
+		case m.keys.AIAssist:
 			if m.aiModalVisible {
 				// Close modal
 				m.aiModalVisible = false
-				return m, nil
+				return m, m.closeBriefing()
 			}
 			// Trigger AI assist based on current pane
 			return m, m.triggerAIAssist()
@@ -233,6 +867,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.aiModalVisible = false
 				return m, m.executeAIAction()
 			}
+		case "s":
+			if m.aiModalVisible && m.aiAction != nil && m.aiAction.Type == claude.ActionSaveReview {
+				m.aiModalVisible = false
+				return m, m.saveWeeklyReview(m.aiAction)
+			}
 		case "c":
 			if m.aiModalVisible {
 				// Continue conversation - prompt for follow-up
@@ -246,7 +885,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Clear session when closing modal
 				m.claudeClient.ClearSession()
 				m.status = "AI session cleared"
-				return m, nil
+				return m, m.closeBriefing()
 			}
 		}
 
@@ -254,37 +893,238 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
 			pane := m.activePanes[m.focusedPane]
 			updated, cmd := pane.Update(msg)
-			m.activePanes[m.focusedPane] = updated.(panes.Pane)
+			m.activePanes[m.focusedPane] = updated.(panes.Pane)
+			cmds = append(cmds, cmd)
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.ready = true
+		m.redistributeSpace()
+
+	case MCPInitializedMsg:
+		m.installProviders(msg)
+		m.providersReady = true
+		m.providerErrors = msg.ProviderErrors
+		for _, pane := range m.paneInstances {
+			if s, ok := pane.(panes.BusSubscriber); ok {
+				s.SubscribeBus(m.bus)
+			}
+		}
+		m.status = "Connected"
+		m.statusWarning = false
+		if len(msg.ProviderErrors) > 0 {
+			m.status = fmt.Sprintf("Connected (%d provider(s) failed)", len(msg.ProviderErrors))
+			m.statusWarning = true
+		}
+		// Restore the saved session, else apply the configured layout, or
+		// refresh the initial pane
+		if cmd, ok := m.applyPendingSession(); ok {
+			cmds = append(cmds, cmd)
+		} else if m.startLayout != LayoutSingle {
+			cmds = append(cmds, m.setLayout(m.startLayout))
+		} else if len(m.activePanes) > 0 {
+			cmds = append(cmds, m.activePanes[0].Refresh())
+		}
+		cmds = append(cmds, m.fetchInboxCount(), scheduleInboxCount(), m.scheduleAutoRefresh(), scheduleCountdown(),
+			m.startHealthChecks(), m.checkThingsHealth())
+
+	case ThingsHealthMsg:
+		m.handleThingsHealth(msg)
+
+	case ConnectionStateMsg:
+		cmds = append(cmds, m.handleConnectionState(msg))
+
+	case ToolsLoadedMsg:
+		m.handleToolsLoaded(msg)
+
+	case SystemWokeMsg:
+		cmds = append(cmds, m.handleSystemWoke())
+
+	case ProvidersReinitializedMsg:
+		cmds = append(cmds, m.handleProvidersReinitialized(msg))
+
+	case ExportedMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, m.commandError(fmt.Sprintf("Export failed: %v", msg.Err)))
+		} else {
+			m.status = "Exported to " + msg.Path
+			m.statusWarning = false
+			cmds = append(cmds, clearStatusAfter(m.status, 3*time.Second))
+		}
+
+	case RefreshMsg:
+		if msg.Pane == allActive {
+			cmds = append(cmds, m.refreshActivePanes(true), m.scheduleAutoRefresh())
+		} else if pane, ok := m.paneInstances[msg.Pane]; ok {
+			cmds = append(cmds, pane.Refresh())
+		}
+
+	case inboxCountTickMsg:
+		cmds = append(cmds, m.fetchInboxCount(), scheduleInboxCount())
+
+	case PomodoroTickMsg:
+		cmds = append(cmds, m.handlePomodoroTick(msg))
+
+	case PomodoroCompleteMsg:
+		cmds = append(cmds, m.handlePomodoroComplete(msg))
+
+	case eventReminderMsg:
+		m.remind(msg.Event)
+
+	case countdownTickMsg:
+		m.advanceNextEvent(time.Now())
+		cmds = append(cmds, scheduleCountdown())
+
+	case InboxCountMsg:
+		// Keep the last known count on transient errors
+		if msg.Err == nil {
+			m.inboxCount = msg.Count
+		}
+
+	case ErrorMsg:
+		m.status = fmt.Sprintf("Error: %v", msg.Err)
+		m.statusWarning = false
+		m.logStatus(logpane.LevelError, m.status)
+
+	case StatusMsg:
+		m.status = msg.Text
+		m.statusWarning = false
+		m.logStatus(logpane.LevelInfo, msg.Text)
+
+	case SplitRatioMsg:
+		m.setSplitRatio(msg.Ratio)
+
+	case ThemeChangedMsg:
+		cmds = append(cmds, m.applyTheme(msg.Theme))
+
+	case clearStatusMsg:
+		if m.status == msg.Text {
+			m.status = ""
+			m.statusWarning = false
+		}
+
+	// Route data messages to appropriate panes
+	case tasks.TasksLoadedMsg, tasks.TaskCompletedMsg, tasks.TaskMovedMsg, tasks.TaskCreatedMsg,
+		tasks.TaskDetailMsg, tasks.ChecklistUpdatedMsg, tasks.TaskUncompletedMsg, tasks.FlashExpiredMsg,
+		tasks.TasksBulkCompletedMsg, tasks.HighlightTaskMsg:
+		switch msg := msg.(type) {
+		case tasks.TasksLoadedMsg:
+			m.lastRefreshed[panes.PaneTasks] = time.Now()
+		case tasks.TaskMovedMsg:
+			if msg.Err == nil {
+				m.status = "Moved to Inbox"
+				cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
+			}
+		case tasks.TaskCreatedMsg:
+			if msg.Err == nil {
+				m.status = "Created: " + msg.Task.Title
+				cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
+			}
+		case tasks.TasksBulkCompletedMsg:
+			m.status = fmt.Sprintf("Completed %d tasks", len(msg.Completed))
+			m.statusWarning = msg.Errors > 0
+			if msg.Errors > 0 {
+				noun := "error"
+				if msg.Errors > 1 {
+					noun = "errors"
+				}
+				m.status = fmt.Sprintf("Completed %d/%d (%d %s)", len(msg.Completed), msg.Total, msg.Errors, noun)
+			}
+			cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
+		}
+		if pane, ok := m.paneInstances[panes.PaneTasks]; ok {
+			updated, cmd := pane.Update(msg)
+			m.paneInstances[panes.PaneTasks] = updated.(panes.Pane)
+			// Update in active panes too
+			for i, ap := range m.activePanes {
+				if ap.Type() == panes.PaneTasks {
+					m.activePanes[i] = updated.(panes.Pane)
+				}
+			}
+			cmds = append(cmds, cmd)
+		}
+
+	case projects.ProjectsLoadedMsg:
+		m.lastRefreshed[panes.PaneProjects] = time.Now()
+		if pane, ok := m.paneInstances[panes.PaneProjects]; ok {
+			updated, cmd := pane.Update(msg)
+			m.paneInstances[panes.PaneProjects] = updated.(panes.Pane)
+			// Update in active panes too
+			for i, ap := range m.activePanes {
+				if ap.Type() == panes.PaneProjects {
+					m.activePanes[i] = updated.(panes.Pane)
+				}
+			}
+			cmds = append(cmds, cmd)
+		}
+
+	case knowledge.PagesLoadedMsg:
+		m.lastRefreshed[panes.PaneKnowledge] = time.Now()
+		if pane, ok := m.paneInstances[panes.PaneKnowledge]; ok {
+			updated, cmd := pane.Update(msg)
+			m.paneInstances[panes.PaneKnowledge] = updated.(panes.Pane)
+			for i, ap := range m.activePanes {
+				if ap.Type() == panes.PaneKnowledge {
+					m.activePanes[i] = updated.(panes.Pane)
+				}
+			}
 			cmds = append(cmds, cmd)
 		}
 
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.ready = true
-		m.redistributeSpace()
+	case knowledge.PageOpenedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Error: %v", msg.Err)
+		} else {
+			m.status = "Opened " + msg.URL
+		}
+		cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
 
-	case MCPInitializedMsg:
-		m.status = "Connected"
-		// Refresh the initial pane
-		if len(m.activePanes) > 0 {
-			cmds = append(cmds, m.activePanes[0].Refresh())
+	case calendar.LinkOpenedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Error: %v", msg.Err)
+		} else {
+			m.status = "Opened " + msg.URL
 		}
+		cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
 
-	case ErrorMsg:
-		m.status = fmt.Sprintf("Error: %v", msg.Err)
+	case macroStepMsg:
+		cmds = append(cmds, m.handleMacroStep(msg))
 
-	case StatusMsg:
-		m.status = msg.Text
+	case panes.OfflineRetryMsg:
+		if pane, ok := m.paneInstances[msg.Pane]; ok {
+			updated, cmd := pane.Update(msg)
+			m.paneInstances[msg.Pane] = updated.(panes.Pane)
+			for i, ap := range m.activePanes {
+				if ap.Type() == msg.Pane {
+					m.activePanes[i] = updated.(panes.Pane)
+				}
+			}
+			cmds = append(cmds, cmd)
+		}
 
-	// Route data messages to appropriate panes
-	case tasks.TasksLoadedMsg, tasks.TaskCompletedMsg:
-		if pane, ok := m.paneInstances[panes.PaneTasks]; ok {
+	case calendar.EventCreatedMsg, calendar.RSVPUpdatedMsg:
+		switch msg := msg.(type) {
+		case calendar.EventCreatedMsg:
+			// Errors stay in the form; only success is announced
+			if msg.Err == nil {
+				m.status = "Created event: " + msg.Event.Title
+				cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
+			}
+		case calendar.RSVPUpdatedMsg:
+			if msg.Err != nil {
+				cmds = append(cmds, m.commandError(fmt.Sprintf("RSVP failed: %v", msg.Err)))
+			} else {
+				m.status = "RSVP: " + msg.Status
+				cmds = append(cmds, clearStatusAfter(m.status, 2*time.Second))
+			}
+		}
+		if pane, ok := m.paneInstances[panes.PaneCalendar]; ok {
 			updated, cmd := pane.Update(msg)
-			m.paneInstances[panes.PaneTasks] = updated.(panes.Pane)
-			// Update in active panes too
+			m.paneInstances[panes.PaneCalendar] = updated.(panes.Pane)
 			for i, ap := range m.activePanes {
-				if ap.Type() == panes.PaneTasks {
+				if ap.Type() == panes.PaneCalendar {
 					m.activePanes[i] = updated.(panes.Pane)
 				}
 			}
@@ -292,6 +1132,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case calendar.EventsLoadedMsg:
+		m.lastRefreshed[panes.PaneCalendar] = time.Now()
+		if msg.Err == nil {
+			m.setUpcomingEvents(msg.Events)
+			cmds = append(cmds, m.scheduleReminders(msg.Events))
+		}
 		if pane, ok := m.paneInstances[panes.PaneCalendar]; ok {
 			updated, cmd := pane.Update(msg)
 			m.paneInstances[panes.PaneCalendar] = updated.(panes.Pane)
@@ -304,7 +1149,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
-	case cospane.StateLoadedMsg, cospane.ActionExecutedMsg:
+	case cospane.StateLoadedMsg, cospane.ActionExecutedMsg, cospane.DuplicatesMergedMsg,
+		cospane.BriefingClosedMsg, cospane.BriefingSavedMsg:
+		if merged, ok := msg.(cospane.DuplicatesMergedMsg); ok && merged.Err == nil {
+			m.status = fmt.Sprintf("Merged %d duplicate actions", merged.Removed)
+		}
+		if _, ok := msg.(cospane.StateLoadedMsg); ok {
+			m.lastRefreshed[panes.PaneCoS] = time.Now()
+		}
 		if pane, ok := m.paneInstances[panes.PaneCoS]; ok {
 			updated, cmd := pane.Update(msg)
 			m.paneInstances[panes.PaneCoS] = updated.(panes.Pane)
@@ -317,8 +1169,28 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case AIStreamChunkMsg:
+		if !m.aiStreaming {
+			// First chunk: swap the spinner for the partial reply
+			m.aiLoading = false
+			m.aiStreaming = true
+			m.aiModalVisible = true
+			m.aiCursorOn = true
+			cmds = append(cmds, scheduleAICursorBlink())
+		}
+		m.aiResponse += msg.Chunk
+		cmds = append(cmds, m.waitForAIChunk())
+
+	case aiCursorTickMsg:
+		if m.aiStreaming {
+			m.aiCursorOn = !m.aiCursorOn
+			cmds = append(cmds, scheduleAICursorBlink())
+		}
+
 	case AIResponseMsg:
 		m.aiLoading = false
+		m.aiStreaming = false
+		m.aiChunks, m.aiDone = nil, nil
 		if msg.Err != nil {
 			m.aiResponse = fmt.Sprintf("Error: %v", msg.Err)
 			m.aiAction = nil
@@ -327,10 +1199,45 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.aiResponse = msg.Text
 			m.aiAction = msg.Action
 			m.aiUsage = msg.Usage
+			if msg.Usage != nil {
+				m.aiCostTotal += msg.Usage.CostUSD
+			}
 		}
+		m.aiBriefing = msg.Briefing
 		m.aiModalVisible = true
+		cmds = append(cmds, m.postAIResponse(msg))
+
+	case WebhookFailedMsg:
+		cmds = append(cmds, m.handleWebhookFailed(msg))
+
+	case SearchResultMsg:
+		cmds = append(cmds, m.jumpToSearchResult(msg))
+
+	case tasks.NeedleMoverRequestMsg:
+		cmds = append(cmds, m.askNeedleMover(msg.Titles))
+
+	case cospane.WeeklyReviewRequestMsg:
+		cmds = append(cmds, m.askWeeklyReview(msg.State))
+
+	case cospane.BriefingRequestMsg:
+		cmds = append(cmds, m.askMorningBriefing(msg))
+
+	default:
+		// Let an open text input receive its own messages (cursor blink)
+		if m.search != nil {
+			_, cmd := m.search.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.focusedCapturingInput() {
+			pane := m.activePanes[m.focusedPane]
+			updated, cmd := pane.Update(msg)
+			m.activePanes[m.focusedPane] = updated.(panes.Pane)
+			cmds = append(cmds, cmd)
+		}
 	}
 
+	// Cross-pane subscribers see every message after the app handled it
+	cmds = append(cmds, m.bus.Dispatch(msg))
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -362,40 +1269,71 @@ func (m *Model) View() string {
 		return m.overlayAIModal(b.String())
 	}
 
-	return b.String()
-}
-
-func (m *Model) renderStatusBar() string {
-	// Left side: Partner title
-	left := m.styles.Title.Render(" Partner ")
+	// Overlay the MCP tools list
+	if m.toolsModal != nil {
+		return m.overlayToolsModal(b.String())
+	}
 
-	// Center: status
-	center := m.styles.Muted.Render(m.status)
+	// Overlay global search
+	if m.search != nil {
+		return m.overlaySearch(b.String())
+	}
 
-	// Right side: time
-	now := time.Now().Format("Mon Jan 2 3:04 PM")
-	right := m.styles.Muted.Render(now + " ")
+	// Overlay the action palette
+	if m.palette != nil {
+		return m.overlayPalette(b.String())
+	}
 
-	// Calculate spacing
-	leftWidth := lipgloss.Width(left)
-	centerWidth := lipgloss.Width(center)
-	rightWidth := lipgloss.Width(right)
-	totalWidth := m.width
+	// Overlay a modal owned by the focused pane
+	if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
+		if modal, ok := m.activePanes[m.focusedPane].(panes.ModalRenderer); ok && modal.ModalVisible() {
+			return m.overlayPaneModal(b.String(), modal)
+		}
+	}
 
-	// Distribute space
-	leftPad := (totalWidth - centerWidth) / 2 - leftWidth
-	rightPad := totalWidth - leftWidth - leftPad - centerWidth - rightWidth
+	return b.String()
+}
 
-	if leftPad < 0 {
-		leftPad = 1
-	}
-	if rightPad < 0 {
-		rightPad = 1
+// renderProviderStatus shows each MCP provider while connecting, and only
+// the failed ones once initialization has finished
+func (m *Model) renderProviderStatus() string {
+	var parts []string
+	for _, name := range providerNames {
+		if name == providerNotion && m.cfg.NotionAPIKey == "" {
+			continue
+		}
+		if name == providerGCal && !m.cfg.UsesCalendar("gcal") {
+			continue
+		}
+		switch {
+		case !m.providersReady:
+			parts = append(parts, m.styles.Muted.Render(name+" …"))
+		case m.providerErrors[name] != nil:
+			parts = append(parts, m.styles.Error.Render(name+" ✗"))
+		}
 	}
+	return strings.Join(parts, " ")
+}
 
-	bar := left + strings.Repeat(" ", leftPad) + center + strings.Repeat(" ", rightPad) + right
+// renderPaneBadges shows a count for each pane reporting a nonzero
+// UnreadCount, e.g. "Tasks (3)"
+func (m *Model) renderPaneBadges() string {
+	// Panes are installed when MCPInitializedMsg arrives
+	if !m.providersReady {
+		return ""
+	}
 
-	return m.styles.StatusBar.Width(m.width).Render(bar)
+	var parts []string
+	for pt := panes.PaneTasks; pt <= panes.PaneCoS; pt++ {
+		counter, ok := m.paneInstances[pt].(panes.UnreadCounter)
+		if !ok {
+			continue
+		}
+		if n := counter.UnreadCount(); n > 0 {
+			parts = append(parts, m.styles.Warning.Render(fmt.Sprintf("%s (%d)", paneTitle(pt), n)))
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 func (m *Model) renderPanes(height int) string {
@@ -433,7 +1371,7 @@ func (m *Model) renderSinglePane(height int) string {
 	}
 
 	// Title above the pane content
-	title := m.styles.PaneTitle.Render(" " + pane.Title() + " ")
+	title := m.renderPaneTitle(pane)
 	content := pane.View()
 
 	// Combine title and content
@@ -448,10 +1386,15 @@ func (m *Model) renderSplitH(height int) string {
 		return m.renderSinglePane(height)
 	}
 
-	halfWidth := (m.width - 2) / 2
+	leftWidth, rightWidth := m.splitSizes(m.width - 2)
 
-	left := m.renderPaneBox(m.activePanes[0], halfWidth, height, m.focusedPane == 0)
-	right := m.renderPaneBox(m.activePanes[1], halfWidth, height, m.focusedPane == 1)
+	left := m.renderPaneBox(m.activePanes[0], leftWidth, height, m.focusedPane == 0)
+	right := m.renderPaneBox(m.activePanes[1], rightWidth, height, m.focusedPane == 1)
+	if m.focusedPane == 0 {
+		left = m.markDividerH(left, true)
+	} else {
+		right = m.markDividerH(right, false)
+	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 }
@@ -461,10 +1404,15 @@ func (m *Model) renderSplitV(height int) string {
 		return m.renderSinglePane(height)
 	}
 
-	halfHeight := height / 2
+	topHeight, bottomHeight := m.splitSizes(height)
 
-	top := m.renderPaneBox(m.activePanes[0], m.width-2, halfHeight, m.focusedPane == 0)
-	bottom := m.renderPaneBox(m.activePanes[1], m.width-2, halfHeight, m.focusedPane == 1)
+	top := m.renderPaneBox(m.activePanes[0], m.width-2, topHeight, m.focusedPane == 0)
+	bottom := m.renderPaneBox(m.activePanes[1], m.width-2, bottomHeight, m.focusedPane == 1)
+	if m.focusedPane == 0 {
+		top = m.markDividerV(top, true)
+	} else {
+		bottom = m.markDividerV(bottom, false)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, top, bottom)
 }
@@ -504,15 +1452,26 @@ func (m *Model) renderPaneBox(p panes.Pane, width, height int, focused bool) str
 	}
 
 	// Title + content like single pane mode
-	title := m.styles.PaneTitle.Render(" " + p.Title() + " ")
+	title := m.renderPaneTitle(p)
 	content := p.View()
 	fullContent := title + "\n" + content
 
 	return style.Width(width).Height(height).Render(fullContent)
 }
 
+// renderPaneTitle is the pane's title line, followed by its muted badge
+// for panes that implement panes.Badger (" Tasks (7) ")
+func (m *Model) renderPaneTitle(p panes.Pane) string {
+	if b, ok := p.(panes.Badger); ok {
+		if badge := b.Badge(); badge != "" {
+			return m.styles.PaneTitle.Render(" "+p.Title()+" ") + m.styles.Muted.Render(badge+" ")
+		}
+	}
+	return m.styles.PaneTitle.Render(" " + p.Title() + " ")
+}
+
 func (m *Model) renderHelpLine() string {
-	help := "q:quit  tab:focus  \\:split  0:cos  1-6:panes  ^wo:maximize  a:ai"
+	help := "q:quit  tab/^hjkl:focus  \\:split  0:cos  1-7:panes  ^wo:maximize  ^t:theme  /:search  ^p:actions  ?:tools  ::cmd  a:ai  p:pomodoro"
 	return m.styles.Muted.Render("  " + help)
 }
 
@@ -523,7 +1482,7 @@ func (m *Model) overlayAIModal(background string) string {
 	modalHeight := min(m.height-6, 20)
 
 	// Modal styles - use theme's primary color for accent
-	accentColor := theme.Current.Primary
+	accentColor := m.styles.Palette.Primary
 
 	modalBorder := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -547,9 +1506,15 @@ func (m *Model) overlayAIModal(background string) string {
 		content.WriteString(titleStyle.Render("🤖 Claude Says"))
 		content.WriteString("\n\n")
 
-		// Word-wrap the response
-		wrapped := wordWrap(m.aiResponse, modalWidth-6)
-		content.WriteString(wrapped)
+		// Style the response's Markdown, wrapped to the modal
+		content.WriteString(m.renderMarkdown(m.aiResponse, modalWidth-6))
+		if m.aiStreaming {
+			if m.aiCursorOn {
+				content.WriteString("▌")
+			} else {
+				content.WriteString(" ")
+			}
+		}
 
 		// Show action hint if there's a suggested action
 		if m.aiAction != nil {
@@ -559,24 +1524,132 @@ func (m *Model) overlayAIModal(background string) string {
 			content.WriteString(accentStyle.Render(actionHint))
 		}
 
-		// Show usage stats if available
+		// Show usage stats if available, and the model when one is configured
+		var usageText string
 		if m.aiUsage != nil {
-			content.WriteString("\n\n")
-			usageText := fmt.Sprintf("tokens: %d in / %d out  cost: $%.4f  time: %dms",
+			usageText = fmt.Sprintf("tokens: %d in / %d out  cost: $%.4f  time: %dms",
 				m.aiUsage.InputTokens, m.aiUsage.OutputTokens,
 				m.aiUsage.CostUSD, m.aiUsage.DurationMs)
+			if len(m.aiContextPanes) > 0 {
+				usageText += "  context from: " + contextPaneNames(m.aiContextPanes)
+			}
+		}
+		if model := m.claudeClient.Model; model != "" {
+			if usageText != "" {
+				usageText += "  "
+			}
+			usageText += "model: " + model
+		}
+		if usageText != "" {
+			content.WriteString("\n\n")
 			content.WriteString(m.styles.Muted.Render(usageText))
 		}
 
 		// Help line
 		content.WriteString("\n\n")
 		helpText := "c:continue  enter:execute  esc:close"
+		if m.aiAction != nil && m.aiAction.Type == claude.ActionSaveReview {
+			helpText = "s:save  c:continue  esc:close"
+		}
+		if m.aiStreaming {
+			helpText = "streaming...  a:hide"
+		}
 		content.WriteString(m.styles.Muted.Render(helpText))
 	}
 
 	modal := modalBorder.Render(content.String())
 
-	// Center the modal
+	return m.overlayModal(background, modal, modalWidth)
+}
+
+// overlayToolsModal lists the focused pane's MCP tools with their
+// descriptions wrapped, in the same frame as the AI modal
+func (m *Model) overlayToolsModal(background string) string {
+	modalWidth := min(m.width-10, 70)
+	modalHeight := min(m.height-6, 24)
+	textWidth := modalWidth - 6
+
+	modalBorder := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Palette.Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(modalHeight)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.styles.Palette.Primary)
+
+	tm := m.toolsModal
+	var lines []string
+	switch {
+	case tm.loading:
+		lines = append(lines, m.styles.Muted.Render("Loading..."))
+	case tm.err != nil:
+		lines = append(lines, m.styles.Error.Render(wordWrap(fmt.Sprintf("Error: %v", tm.err), textWidth)))
+	case len(tm.tools) == 0:
+		lines = append(lines, m.styles.Muted.Render("No tools"))
+	default:
+		for i, t := range tm.tools {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, m.styles.Title.Render(t.Name))
+			if t.Description != "" {
+				for _, l := range strings.Split(wordWrap(t.Description, textWidth-2), "\n") {
+					lines = append(lines, "  "+m.styles.Muted.Render(l))
+				}
+			}
+		}
+	}
+
+	// Title, blank line, blank line and help take four of the inner rows
+	visible := max(1, modalHeight-2-4)
+	tm.scroll = min(tm.scroll, max(0, len(lines)-visible))
+	end := min(tm.scroll+visible, len(lines))
+
+	var content strings.Builder
+	title := fmt.Sprintf("%s tools", tm.server)
+	if n := len(tm.tools); n > 0 {
+		title += fmt.Sprintf(" (%d)", n)
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n\n")
+	content.WriteString(strings.Join(lines[tm.scroll:end], "\n"))
+	content.WriteString("\n\n")
+	content.WriteString(m.styles.Muted.Render("j/k:scroll  esc:close"))
+
+	modal := modalBorder.Render(content.String())
+
+	return m.overlayModal(background, modal, modalWidth)
+}
+
+// paneModalFrame is the outer size of a pane modal
+func (m *Model) paneModalFrame() (width, height int) {
+	return min(m.width-10, 70), min(m.height-6, 24)
+}
+
+// overlayPaneModal renders a pane-provided modal (e.g. task details)
+// over the existing content using the same frame as the AI modal
+func (m *Model) overlayPaneModal(background string, pane panes.ModalRenderer) string {
+	modalWidth, modalHeight := m.paneModalFrame()
+
+	modalBorder := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Palette.Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(modalHeight)
+
+	// Inner size excludes padding
+	content := pane.ModalView(modalWidth-4, modalHeight-2)
+	modal := modalBorder.Render(content)
+
+	return m.overlayModal(background, modal, modalWidth)
+}
+
+// overlayModal centers a rendered modal over the background
+func (m *Model) overlayModal(background, modal string, modalWidth int) string {
 	modalLines := strings.Split(modal, "\n")
 	bgLines := strings.Split(background, "\n")
 
@@ -604,7 +1677,13 @@ func (m *Model) overlayAIModal(background string) string {
 	return strings.Join(result, "\n")
 }
 
-// wordWrap wraps text at word boundaries
+// renderMarkdown styles Markdown in AI responses with the current theme
+func (m *Model) renderMarkdown(text string, width int) string {
+	return render.Markdown(text, width, m.styles)
+}
+
+// wordWrap wraps text at word boundaries. Widths are measured in visible
+// columns, so styled words wrap where they appear to.
 func wordWrap(text string, width int) string {
 	if width <= 0 {
 		return text
@@ -621,13 +1700,17 @@ func wordWrap(text string, width int) string {
 		}
 
 		currentLine := words[0]
+		lineWidth := ansi.StringWidth(currentLine)
 		for _, word := range words[1:] {
-			if len(currentLine)+1+len(word) > width {
+			wordWidth := ansi.StringWidth(word)
+			if lineWidth+1+wordWidth > width {
 				result.WriteString(currentLine)
 				result.WriteString("\n")
 				currentLine = word
+				lineWidth = wordWidth
 			} else {
 				currentLine += " " + word
+				lineWidth += 1 + wordWidth
 			}
 		}
 		result.WriteString(currentLine)
@@ -637,46 +1720,52 @@ func wordWrap(text string, width int) string {
 	return strings.TrimSuffix(result.String(), "\n")
 }
 
-// padRight pads a string to a given width
+// padRight pads a string to a given visible width
 func padRight(s string, width int) string {
-	if len(s) >= width {
+	w := ansi.StringWidth(s)
+	if w >= width {
 		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return s + strings.Repeat(" ", width-w)
 }
 
-// Navigation helpers
-func (m *Model) focusNext() {
-	if len(m.activePanes) == 0 {
-		return
+// canSwitchTo reports whether a pane shortcut should switch panes
+func (m *Model) canSwitchTo(target panes.PaneType) bool {
+	if _, ok := m.paneInstances[target]; !ok {
+		return false
 	}
-
-	// Blur current
-	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Blur().(panes.Pane)
-
-	// Move to next
-	m.focusedPane = (m.focusedPane + 1) % len(m.activePanes)
-
-	// Focus new
-	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Focus().(panes.Pane)
+	if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
+		return m.activePanes[m.focusedPane].Type() != target
+	}
+	return true
 }
 
-func (m *Model) focusPrev() {
-	if len(m.activePanes) == 0 {
-		return
+// focusedCapturingInput reports whether the focused pane wants raw key input
+func (m *Model) focusedCapturingInput() bool {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return false
 	}
+	capturer, ok := m.activePanes[m.focusedPane].(panes.InputCapturer)
+	return ok && capturer.CapturingInput()
+}
 
-	// Blur current
-	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Blur().(panes.Pane)
-
-	// Move to prev
-	m.focusedPane--
-	if m.focusedPane < 0 {
-		m.focusedPane = len(m.activePanes) - 1
+// focusedClaims reports whether the focused pane takes key over its
+// global binding
+func (m *Model) focusedClaims(key string) bool {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return false
 	}
+	claimer, ok := m.activePanes[m.focusedPane].(panes.KeyClaimer)
+	return ok && claimer.ClaimsKey(key)
+}
+
+// Navigation helpers
+func (m *Model) focusNext() {
+	m.focusDirection(DirNext)
+}
 
-	// Focus new
-	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Focus().(panes.Pane)
+func (m *Model) focusPrev() {
+	m.focusDirection(DirPrev)
 }
 
 func (m *Model) switchToPane(target panes.PaneType) tea.Cmd {
@@ -743,7 +1832,7 @@ func (m *Model) toggleSplit() tea.Cmd {
 		calendarPane := m.paneInstances[panes.PaneCalendar]
 
 		// For now, duplicate Tasks and Calendar for grid demo
-		// (Email and Knowledge panes not implemented yet)
+		// (Email pane not implemented yet)
 		m.activePanes = []panes.Pane{
 			tasksPane.Focus().(panes.Pane),
 			calendarPane.Blur().(panes.Pane),
@@ -767,6 +1856,40 @@ func (m *Model) toggleSplit() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// setLayout arranges panes for the given layout mode
+func (m *Model) setLayout(mode LayoutMode) tea.Cmd {
+	if mode == LayoutSingle {
+		m.layout = LayoutSingle
+		if len(m.activePanes) > 0 {
+			focused := m.activePanes[m.focusedPane]
+			m.activePanes = []panes.Pane{focused.Focus().(panes.Pane)}
+			m.focusedPane = 0
+		}
+		m.redistributeSpace()
+		return nil
+	}
+
+	tasksPane, hasT := m.paneInstances[panes.PaneTasks]
+	calendarPane, hasC := m.paneInstances[panes.PaneCalendar]
+	if !hasT || !hasC {
+		return nil
+	}
+
+	m.layout = mode
+	m.activePanes = []panes.Pane{
+		tasksPane.Focus().(panes.Pane),
+		calendarPane.Blur().(panes.Pane),
+	}
+	if mode == LayoutGrid {
+		// Email pane not implemented yet - duplicate for grid
+		m.activePanes = append(m.activePanes, tasksPane, calendarPane)
+	}
+	m.focusedPane = 0
+	m.redistributeSpace()
+
+	return tea.Batch(tasksPane.Refresh(), calendarPane.Refresh())
+}
+
 func (m *Model) maximizePane() tea.Cmd {
 	if m.layout == LayoutSingle {
 		// Already maximized - restore previous layout
@@ -790,25 +1913,84 @@ func (m *Model) maximizePane() tea.Cmd {
 	return nil
 }
 
+// cycleTheme switches to the next theme in themeRegistry
+func (m *Model) cycleTheme() tea.Cmd {
+	next := 0
+	for i, t := range themeRegistry {
+		if t.Name == m.styles.Palette.Name {
+			next = (i + 1) % len(themeRegistry)
+			break
+		}
+	}
+
+	return m.applyTheme(themeRegistry[next])
+}
+
+// applyTheme regenerates the shared styles and hands them to every pane
+func (m *Model) applyTheme(t theme.Theme) tea.Cmd {
+	m.styles.SetTheme(t)
+
+	// Propagate to every pane (active panes share these instances)
+	for pt, p := range m.paneInstances {
+		m.paneInstances[pt] = p.SetStyles(m.styles)
+	}
+	for i, p := range m.activePanes {
+		m.activePanes[i] = p.SetStyles(m.styles)
+	}
+
+	m.status = "Theme: " + t.Name
+	m.statusWarning = false
+	return clearStatusAfter(m.status, 2*time.Second)
+}
+
+// Split ratio bounds and step for the ( and ) and ctrl+arrow keys
+const (
+	minSplitRatio  = 0.2
+	maxSplitRatio  = 0.8
+	splitRatioStep = 0.05
+)
+
+// splitSizes divides total between the first and second split pane
+func (m *Model) splitSizes(total int) (int, int) {
+	first := int(float64(total) * m.splitRatio)
+	return first, total - first
+}
+
+// setSplitRatio clamps and applies a new split ratio
+func (m *Model) setSplitRatio(ratio float64) {
+	m.splitRatio = max(minSplitRatio, min(maxSplitRatio, ratio))
+	m.redistributeSpace()
+}
+
 func (m *Model) redistributeSpace() {
 	if len(m.activePanes) == 0 {
 		return
 	}
 
+	// Modal content is the frame less its padding (see overlayPaneModal)
+	modalWidth, modalHeight := m.paneModalFrame()
+	for _, pane := range m.activePanes {
+		if sizer, ok := pane.(panes.ModalSizer); ok {
+			sizer.SetModalSize(modalWidth-4, modalHeight-2)
+		}
+	}
+
 	contentHeight := m.height - 3
 
 	switch m.layout {
 	case LayoutSingle:
 		m.activePanes[0] = m.activePanes[0].SetSize(m.width-4, contentHeight-2).(panes.Pane)
 	case LayoutSplitH:
-		halfWidth := (m.width - 2) / 2
+		leftWidth, rightWidth := m.splitSizes(m.width - 2)
+		widths := []int{leftWidth, rightWidth}
 		for i := range m.activePanes {
-			m.activePanes[i] = m.activePanes[i].SetSize(halfWidth-4, contentHeight-2).(panes.Pane)
+			m.activePanes[i] = m.activePanes[i].SetSize(widths[min(i, 1)]-4, contentHeight-2).(panes.Pane)
 		}
 	case LayoutSplitV:
-		halfHeight := contentHeight / 2
+		topHeight, bottomHeight := m.splitSizes(contentHeight)
+		heights := []int{topHeight, bottomHeight}
 		for i := range m.activePanes {
-			m.activePanes[i] = m.activePanes[i].SetSize(m.width-4, halfHeight-2).(panes.Pane)
+			m.activePanes[i] = m.activePanes[i].SetSize(m.width-4, heights[min(i, 1)]-2).(panes.Pane)
 		}
 	case LayoutGrid:
 		halfWidth := (m.width - 2) / 2
@@ -821,85 +2003,100 @@ func (m *Model) redistributeSpace() {
 
 // triggerAIAssist asks Claude for help based on the current pane context
 func (m *Model) triggerAIAssist() tea.Cmd {
+	// One request at a time; chunks from a second stream would interleave
+	if m.aiLoading || m.aiStreaming {
+		return nil
+	}
 	m.aiLoading = true
+	m.aiAction = nil
+	m.aiUsage = nil
 	m.status = "Asking Claude..."
 
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		// Build context based on current pane
-		var paneContext string
-		var prompt string
-
-		// Always include CoS context for enhanced intelligence
-		cosContext := m.buildCoSContext()
-
-		if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
-			currentPane := m.activePanes[m.focusedPane]
-
-			switch currentPane.Type() {
-			case panes.PaneTasks:
-				// Get task titles for context
-				if m.thingsProvider != nil {
-					tasks, err := m.thingsProvider.GetToday(ctx)
-					if err == nil {
-						var taskList []string
-						for _, t := range tasks {
-							taskList = append(taskList, t.Title)
-						}
-						paneContext = "Today's tasks:\n- " + strings.Join(taskList, "\n- ")
-					}
-				}
-				prompt = "Based on my tasks and CoS context, what's the single highest-leverage needle-mover I should focus on? Be brief (2-3 sentences). Prioritize job search actions if outreach is cold."
-
-			case panes.PaneCalendar:
-				// Get calendar events for context
-				if m.calendarProvider != nil {
-					events, err := m.calendarProvider.GetTodayEvents(ctx)
-					if err == nil {
-						var eventList []string
-						for _, e := range events {
-							eventList = append(eventList, fmt.Sprintf("%s at %s", e.Title, e.StartTime.Format("3:04 PM")))
-						}
-						paneContext = "Today's schedule:\n- " + strings.Join(eventList, "\n- ")
-					}
-				}
-				prompt = "Looking at my schedule and CoS context, what should I be aware of? Any conflicts, prep needed, or avoidance patterns? Be brief."
+	// Assemble context up front: pane data is only safe to read here
+	var paneContext, prompt string
+	var contextPanes []panes.PaneType
+	if m.layout == LayoutSplitH || m.layout == LayoutSplitV {
+		// Both panes are on screen, so ask about them together
+		paneContext, contextPanes = m.buildVisibleContext()
+		prompt = multiPanePrompt(contextPanes)
+	} else if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
+		// The summaries of every pane are cached across calls
+		paneContext = m.buildSystemContext()
+		// The prompt follows the time of day; on the calendar, a meeting
+		// about to start turns it into a prep request
+		now := time.Now()
+		pt := m.activePanes[m.focusedPane].Type()
+		var meeting string
+		if pt == panes.PaneCalendar {
+			meeting = m.meetingPrepContext(now)
+		}
+		prompt = claude.BuildTimeAwarePrompt(pt, now.Hour(), meeting)
+	}
+	m.aiContextPanes = contextPanes
 
-			case panes.PaneCoS:
-				// CoS pane - full coaching mode
-				paneContext = "" // CoS context already included
-				prompt = "You're my Chief of Staff. Based on my current state, what's the ONE thing I should do right now? If avoidance detected, call it out directly. Be brief but firm."
+	chunks := make(chan string)
+	done := make(chan error, 1)
+	m.aiChunks = chunks
+	m.aiDone = done
+	m.aiResponse = ""
 
-			default:
-				prompt = "What's the most important thing I should focus on right now?"
-			}
-		}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.cfg.AITimeoutSeconds)*time.Second)
+		defer cancel()
 
-		// Combine contexts
-		fullContext := cosContext
+		// Always include CoS context for enhanced intelligence
+		fullContext := m.buildCoSContext()
 		if paneContext != "" {
 			fullContext += "\n\n" + paneContext
 		}
 
-		// Ask Claude
-		resp := m.claudeClient.Ask(ctx, claude.Request{
+		// Ask Claude, streaming the reply into chunks
+		done <- m.claudeClient.AskStream(ctx, claude.Request{
 			Prompt:     prompt,
 			Context:    fullContext,
 			AllowTools: false,
-		})
+			Panes:      contextPanes,
+		}, chunks)
+	}()
+
+	return m.waitForAIChunk()
+}
+
+// waitForAIChunk delivers the next streamed chunk, or the final response
+// once the stream closes
+func (m *Model) waitForAIChunk() tea.Cmd {
+	chunks, done := m.aiChunks, m.aiDone
+	client := m.claudeClient
+	return func() tea.Msg {
+		if chunk, ok := <-chunks; ok {
+			return AIStreamChunkMsg{Chunk: chunk}
+		}
 
+		if err := <-done; err != nil {
+			return AIResponseMsg{Err: err}
+		}
+		resp := client.LastResponse()
 		return AIResponseMsg{
 			Text:      resp.Text,
 			Action:    resp.Action,
-			Err:       resp.Error,
 			SessionID: resp.SessionID,
 			Usage:     resp.Usage,
 		}
 	}
 }
 
+// aiCursorBlink is the blink period of the streaming cursor
+const aiCursorBlink = 500 * time.Millisecond
+
+// aiCursorTickMsg toggles the streaming cursor
+type aiCursorTickMsg struct{}
+
+func scheduleAICursorBlink() tea.Cmd {
+	return tea.Tick(aiCursorBlink, func(time.Time) tea.Msg {
+		return aiCursorTickMsg{}
+	})
+}
+
 // buildCoSContext creates context string from CoS state
 func (m *Model) buildCoSContext() string {
 	if m.cosProvider == nil {
@@ -963,6 +2160,14 @@ func (m *Model) executeAIAction() tea.Cmd {
 		m.status = "Create task... (not yet implemented)"
 		// TODO: Create task via Things MCP
 
+	case claude.ActionFocusTask:
+		m.status = ""
+		return m.focusTask(m.aiAction)
+
+	case claude.ActionSaveReview:
+		m.status = ""
+		return m.saveWeeklyReview(m.aiAction)
+
 	default:
 		m.status = "Action acknowledged"
 	}
@@ -971,28 +2176,52 @@ func (m *Model) executeAIAction() tea.Cmd {
 }
 
 // FetchCurrentPaneData fetches data for headless mode
-func (m *Model) FetchCurrentPaneData() (interface{}, error) {
+func (m *Model) FetchCurrentPaneData(ctx context.Context) (interface{}, error) {
+	switch m.initialPane {
+	case panes.PaneLog:
+		return m.diagnosticLog(ctx), nil
+	case panes.PaneCoS:
+		return m.cosData()
+	case panes.PaneCalendar:
+		if !m.forceRefresh {
+			m.openCache()
+		}
+		return m.calendarData(ctx)
+	}
+
 	// Initialize MCP providers synchronously for headless mode
-	// Use the local Python Things MCP server via wrapper script
-thingsTransport, err := transport.NewStdioTransport("/Users/samuelz/partner/scripts/things-mcp.sh", nil)
+	thingsTransport, err := m.newThingsTransport()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Things transport: %w", err)
 	}
 
-	thingsClient := mcp.NewClient(thingsTransport, "things")
-	m.thingsProvider = providers.NewThingsProvider(thingsClient)
+	if !m.forceRefresh {
+		m.openCache()
+	}
+	m.thingsProvider = providers.NewThingsProvider(m.toolClient(thingsTransport, "things", thingsCacheTTL))
 	defer m.thingsProvider.Close()
 
-	ctx := context.Background()
-
 	switch m.initialPane {
 	case panes.PaneTasks:
+		if m.taskList {
+			return m.thingsProvider.GetToday(ctx)
+		}
 		tasks, err := m.thingsProvider.GetTodayDebug(ctx)
 		if err != nil {
 			return nil, err
 		}
 		return tasks, nil
 
+	case panes.PaneProjects:
+		projects, err := m.thingsProvider.GetProjects(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"projects": projects,
+			"count":    len(projects),
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("pane %s not yet implemented for headless mode", m.initialPane)
 	}
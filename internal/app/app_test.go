@@ -0,0 +1,337 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/szoloth/partner/internal/cache"
+	"github.com/szoloth/partner/internal/config"
+	cosstate "github.com/szoloth/partner/internal/cos"
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/panes/calendar"
+	cospane "github.com/szoloth/partner/internal/panes/cos"
+	"github.com/szoloth/partner/internal/panes/knowledge"
+	"github.com/szoloth/partner/internal/panes/projects"
+	"github.com/szoloth/partner/internal/panes/tasks"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConfigThemeFallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme string
+		want  string
+	}{
+		{"known", "nord", theme.Nord.Name},
+		{"alias", "catppuccin", theme.CatppuccinMocha.Name},
+		{"unknown", "solarized", theme.Default.Name},
+		{"empty", "", theme.Default.Name},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Theme = tt.theme
+			m := NewModel(WithConfig(cfg), WithHeadless(true))
+
+			if got := m.styles.Palette.Name; got != tt.want {
+				t.Errorf("theme = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTheme(t *testing.T) {
+	for _, name := range []string{"catppuccin_mocha", "nord", "gruvbox"} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Theme = "dracula" // WithTheme overrides the config
+			th, err := theme.ParseTheme(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := NewModel(WithConfig(cfg), WithTheme(th), WithHeadless(true))
+
+			if got := m.styles.Palette.Name; got != name {
+				t.Errorf("theme = %q, want %q", got, name)
+			}
+		})
+	}
+}
+
+func TestRefreshDropsPaneCache(t *testing.T) {
+	c, err := cache.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	m := NewModel(WithHeadless(true), WithCache(false))
+	m.cache = c
+	for _, key := range []string{"things:get_today:{}", "google-calendar:list-events:{}"} {
+		c.Set(key, json.RawMessage(`{}`), time.Hour)
+	}
+
+	m.dropPaneCache(panes.PaneTasks)
+	if _, ok := c.Get("things:get_today:{}"); ok {
+		t.Error("explicit refresh of the tasks pane kept its cached read")
+	}
+	if _, ok := c.Get("google-calendar:list-events:{}"); !ok {
+		t.Error("refreshing the tasks pane dropped the calendar cache")
+	}
+
+	m.dropPaneCache(panes.PaneCoS) // No server; must not touch the cache
+	if _, ok := c.Get("google-calendar:list-events:{}"); !ok {
+		t.Error("refreshing the CoS pane dropped the calendar cache")
+	}
+}
+
+// cosStateFile is a CoS state with two pending actions. Its streak dates
+// are empty so Load's streak resets leave it alone whatever the date.
+const cosStateFile = `{
+  "version": "1.1",
+  "streaks": {
+    "needle_mover": {"current": 3, "longest": 8},
+    "outreach": {"current_week": 2, "weekly_target": 10}
+  },
+  "patterns": {"avoidance_flags": 1},
+  "action_queue": {
+    "pending": [
+      {"id": 4, "type": "outreach", "company": "Acme", "contact": "Jane"},
+      {"id": 7, "type": "research", "company": "Globex"}
+    ],
+    "completed_today": [],
+    "skipped_today": []
+  },
+  "thresholds": {"outreach_cold_days": 3}
+}`
+
+// headlessCoS returns a headless CoS model reading state from a temp file
+// holding contents, or from a missing file when contents is empty
+func headlessCoS(t *testing.T, contents string) *Model {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cos-state.json")
+	if contents != "" {
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewModel(WithHeadless(true), WithInitialPane("cos"), WithCache(false))
+	m.cosProvider = cosstate.NewProviderWithPath(path)
+	return m
+}
+
+func TestFetchCurrentPaneDataCoS(t *testing.T) {
+	data, err := headlessCoS(t, cosStateFile).FetchCurrentPaneData(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrentPaneData() = %v", err)
+	}
+	got, ok := data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("data is %T, want map[string]interface{}", data)
+	}
+
+	keys := make([]string, 0, len(got))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	if want := []string{"avoidance", "needle_mover", "outreach_cold", "pending_count", "streaks"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %q, want %q", keys, want)
+	}
+
+	if nm, ok := got["needle_mover"].(*cosstate.PendingAction); !ok || nm == nil || nm.ID != 4 || nm.Company != "Acme" {
+		t.Errorf("needle_mover = %#v, want pending action 4 (Acme)", got["needle_mover"])
+	}
+	if got["pending_count"] != 2 {
+		t.Errorf("pending_count = %v, want 2", got["pending_count"])
+	}
+	if got["avoidance"] != true {
+		t.Errorf("avoidance = %v, want true", got["avoidance"])
+	}
+	if got["outreach_cold"] != false {
+		t.Errorf("outreach_cold = %v, want false with 2 outreaches this week", got["outreach_cold"])
+	}
+	streaks, ok := got["streaks"].(cosstate.Streaks)
+	if !ok || streaks.NeedleMover.Current != 3 || streaks.NeedleMover.Longest != 8 || streaks.Outreach.CurrentWeek != 2 {
+		t.Errorf("streaks = %#v", got["streaks"])
+	}
+
+	// The headless output is JSON-encoded
+	if _, err := json.Marshal(data); err != nil {
+		t.Errorf("data does not encode as JSON: %v", err)
+	}
+}
+
+func TestFetchCurrentPaneDataCoSMissingFile(t *testing.T) {
+	data, err := headlessCoS(t, "").FetchCurrentPaneData(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrentPaneData() = %v", err)
+	}
+	got := data.(map[string]interface{})
+	if got["pending_count"] != 0 || got["needle_mover"] != (*cosstate.PendingAction)(nil) {
+		t.Errorf("data = %v, want the empty default state", got)
+	}
+}
+
+func TestFetchCurrentPaneDataCoSBadFile(t *testing.T) {
+	if _, err := headlessCoS(t, "{not json").FetchCurrentPaneData(context.Background()); err == nil {
+		t.Fatal("FetchCurrentPaneData() succeeded on a corrupt state file")
+	}
+}
+
+// focusedTasks returns a headless model with the tasks, calendar, CoS,
+// knowledge, projects and log panes available and the tasks pane focused
+func focusedTasks(t *testing.T) (*Model, *tasks.Model) {
+	t.Helper()
+	m := NewModel(WithHeadless(true), WithCache(false))
+	pane := tasks.New(nil)
+	m.paneInstances[panes.PaneTasks] = pane
+	m.paneInstances[panes.PaneKnowledge] = knowledge.New(nil)
+	m.paneInstances[panes.PaneProjects] = projects.New(nil)
+	m.paneInstances[panes.PaneCalendar] = calendar.New(nil)
+	m.paneInstances[panes.PaneCoS] = cospane.New()
+	m.activePanes = []panes.Pane{pane.Focus()}
+	return m, pane
+}
+
+func TestDigitsSwitchPanes(t *testing.T) {
+	tests := []struct {
+		key  string
+		want panes.PaneType
+	}{
+		{"0", panes.PaneCoS},
+		{"2", panes.PaneCalendar},
+		{"4", panes.PaneKnowledge},
+		{"6", panes.PaneProjects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			m, _ := focusedTasks(t)
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+
+			if got := m.activePanes[m.focusedPane].Type(); got != tt.want {
+				t.Errorf("focused pane = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// And back from the calendar
+	m, _ := focusedTasks(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if got := m.activePanes[m.focusedPane].Type(); got != panes.PaneTasks {
+		t.Errorf("1 from the calendar focused %v, want the tasks pane", got)
+	}
+}
+
+func TestBracketsCycleTasksView(t *testing.T) {
+	m, pane := focusedTasks(t)
+	key := func(k string) { m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	key("]")
+	if got := tasks.ViewMode(pane.CurrentViewMode()); got != tasks.ViewInbox {
+		t.Errorf("] from Today: view = %v, want %v", got, tasks.ViewInbox)
+	}
+	key("[")
+	key("[")
+	if got := tasks.ViewMode(pane.CurrentViewMode()); got != tasks.ViewByTag {
+		t.Errorf("[ from Today: view = %v, want %v", got, tasks.ViewByTag)
+	}
+	if got := m.activePanes[m.focusedPane].Type(); got != panes.PaneTasks {
+		t.Errorf("focused pane = %v, want the tasks pane", got)
+	}
+}
+
+func TestPomodoroKeyFromTasksPane(t *testing.T) {
+	m, _ := focusedTasks(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(m.keys.Pomodoro)})
+
+	if !m.pomodoro.Running() {
+		t.Error("the pomodoro key did not start the timer from the tasks pane")
+	}
+}
+
+func TestPaneDataClearsSystemContext(t *testing.T) {
+	m := NewModel(WithHeadless(true), WithCache(false))
+	m.systemContext = "Tasks:\n- stale"
+	m.systemContextAt = time.Now()
+
+	m.Update(tasks.TasksLoadedMsg{})
+	if m.systemContext != "" {
+		t.Error("loading tasks kept the cached AI context")
+	}
+
+	m.systemContext = "Tasks:\n- current"
+	m.Update(clearStatusMsg{})
+	if m.systemContext == "" {
+		t.Error("a message that changes no pane data dropped the cached AI context")
+	}
+}
+
+func TestMCPInitializedInstallsPanes(t *testing.T) {
+	m := NewModel(WithHeadless(true), WithCache(false), WithInitialPane("cos"))
+	if _, ok := m.paneInstances[panes.PaneTasks]; ok {
+		t.Fatal("the tasks pane exists before initialization")
+	}
+
+	m.Update(MCPInitializedMsg{Panes: map[panes.PaneType]panes.Pane{
+		panes.PaneTasks: tasks.New(nil),
+		panes.PaneCoS:   cospane.New(),
+	}})
+
+	if _, ok := m.paneInstances[panes.PaneTasks]; !ok {
+		t.Error("Update did not install the tasks pane")
+	}
+	if !m.providersReady {
+		t.Error("providersReady not set")
+	}
+	if len(m.activePanes) != 1 || m.activePanes[0].Type() != panes.PaneCoS {
+		t.Errorf("active panes = %v, want the configured CoS pane", m.activePanes)
+	}
+}
+
+func TestLoadSessionValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", `{"version": 1, "layout": "single", "focused_pane": 0}`, false},
+		{"unknown layout", `{"version": 1, "layout": "tabs", "focused_pane": 0}`, true},
+		{"negative focused pane", `{"version": 1, "layout": "single", "focused_pane": -1}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			path := config.ExpandPath(sessionPath)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := loadSession()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("loadSession() = %+v, want an error", s)
+				}
+				return
+			}
+			if err != nil || s == nil {
+				t.Errorf("loadSession() = %+v, %v", s, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	cospane "github.com/szoloth/partner/internal/panes/cos"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askMorningBriefing shows the CoS pane's morning briefing in the AI
+// modal: the cached text when the pane passed one, otherwise a new
+// briefing from today's tasks, events and state
+func (m *Model) askMorningBriefing(msg cospane.BriefingRequestMsg) tea.Cmd {
+	if m.aiLoading || m.aiStreaming || msg.State == nil {
+		return nil
+	}
+	if msg.Cached != "" {
+		text := msg.Cached
+		return func() tea.Msg {
+			return AIResponseMsg{Text: text, Briefing: &cospane.BriefingClosedMsg{Text: text}}
+		}
+	}
+
+	m.aiLoading = true
+	m.aiAction = nil
+	m.aiUsage = nil
+	m.aiContextPanes = nil
+	m.status = "Writing morning briefing..."
+
+	client := m.claudeClient
+	things := m.thingsProvider
+	cal := m.calendarProvider
+	state := msg.State
+	timeout := time.Duration(m.cfg.AITimeoutSeconds) * time.Second
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		// A missing provider leaves its section empty rather than failing
+		var tasks []providers.Task
+		if things != nil {
+			tasks, _ = things.GetToday(ctx)
+		}
+		var events []providers.CalendarEvent
+		if cal != nil {
+			events, _ = cal.GetTodayEvents(ctx)
+		}
+
+		resp := client.MorningBriefing(ctx, tasks, events, state)
+		if resp.Error != nil {
+			return AIResponseMsg{Err: resp.Error}
+		}
+		return AIResponseMsg{
+			Text:      resp.Text,
+			SessionID: resp.SessionID,
+			Usage:     resp.Usage,
+			Briefing:  &cospane.BriefingClosedMsg{Text: resp.Text, Fresh: true},
+		}
+	}
+}
+
+// closeBriefing tells the CoS pane the briefing in the AI modal was read,
+// so it caches the text and records the run
+func (m *Model) closeBriefing() tea.Cmd {
+	msg := m.aiBriefing
+	if msg == nil {
+		return nil
+	}
+	m.aiBriefing = nil
+	return func() tea.Msg { return *msg }
+}
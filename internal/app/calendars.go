@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// mergeCalendars combines the sources in calendar_providers, in config
+// order. gcal is nil when Google Calendar is off or failed to start; the
+// result is nil when no source is left.
+func (m *Model) mergeCalendars(gcal providers.CalendarProviderInterface) providers.CalendarProviderInterface {
+	var calendars []providers.CalendarProviderInterface
+	for _, name := range m.cfg.CalendarProviders {
+		switch {
+		case name == "gcal" && gcal != nil:
+			calendars = append(calendars, gcal)
+		case name == "apple":
+			calendars = append(calendars, providers.NewAppleCalendarProvider(providers.WithTimezone(m.cfg.HomeTimezone)))
+		}
+	}
+
+	switch len(calendars) {
+	case 0:
+		return nil
+	case 1:
+		return calendars[0]
+	default:
+		return providers.NewMultiCalendarProvider(m.mcpDebug, calendars...)
+	}
+}
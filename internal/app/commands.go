@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/panes"
+	logpane "github.com/szoloth/partner/internal/panes/log"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ThemeChangedMsg requests switching to a new theme
+type ThemeChangedMsg struct {
+	Theme theme.Theme
+}
+
+// handleCommandKey captures keystrokes while command mode is active
+func (m *Model) handleCommandKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.commandMode = false
+		m.commandBuf = ""
+		return nil
+	case tea.KeyEnter:
+		line := m.commandBuf
+		m.commandMode = false
+		m.commandBuf = ""
+		return m.runCommand(line)
+	case tea.KeyBackspace:
+		if len(m.commandBuf) > 0 {
+			runes := []rune(m.commandBuf)
+			m.commandBuf = string(runes[:len(runes)-1])
+		}
+		return nil
+	case tea.KeySpace:
+		m.commandBuf += " "
+		return nil
+	case tea.KeyRunes:
+		m.commandBuf += string(msg.Runes)
+		return nil
+	}
+	return nil
+}
+
+// commandErrorDuration is how long command errors stay in the status bar
+const commandErrorDuration = 2 * time.Second
+
+// runCommand dispatches a command line entered after ':'
+func (m *Model) runCommand(line string) tea.Cmd {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch fields[0] {
+	case "q", "quit":
+		return m.quit()
+
+	case "refresh":
+		return m.refreshActivePanes(false)
+
+	case "theme":
+		if len(fields) < 2 {
+			return m.commandError("Usage: :theme <name>")
+		}
+		if t, ok := lookupTheme(fields[1]); ok {
+			return func() tea.Msg { return ThemeChangedMsg{Theme: t} }
+		}
+		return m.commandError(fmt.Sprintf("Unknown theme: %s", fields[1]))
+
+	case "layout":
+		if len(fields) < 2 {
+			return m.commandError("Usage: :layout <single|hsplit|vsplit|grid>")
+		}
+		mode, ok := parseLayout(fields[1])
+		if !ok {
+			return m.commandError(fmt.Sprintf("Unknown layout: %s", fields[1]))
+		}
+		return m.setLayout(mode)
+
+	case "pane":
+		if len(fields) < 2 {
+			return m.commandError("Usage: :pane <name>")
+		}
+		// ParsePaneType falls back to tasks, so check the round trip
+		target := panes.ParsePaneType(fields[1])
+		if target.String() != fields[1] {
+			return m.commandError(fmt.Sprintf("Unknown pane: %s", fields[1]))
+		}
+		if _, ok := m.paneInstances[target]; !ok {
+			return m.commandError(fmt.Sprintf("Pane not available: %s", fields[1]))
+		}
+		return m.switchToPane(target)
+	}
+
+	return m.commandError(fmt.Sprintf("Unknown command: %s", fields[0]))
+}
+
+// commandError shows text in the status bar and clears it after a moment
+func (m *Model) commandError(text string) tea.Cmd {
+	m.status = text
+	m.statusWarning = true
+	m.logStatus(logpane.LevelError, text)
+	return clearStatusAfter(text, commandErrorDuration)
+}
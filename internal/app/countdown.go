@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	countdownWindow = 60 * time.Minute // Show the next event once it's this close
+	countdownUrgent = 5 * time.Minute  // Switch to the error color inside this
+	meetingPrepLead = 30 * time.Minute // AI assist on the calendar preps meetings this close
+)
+
+// countdownTickMsg re-renders the next-event countdown
+type countdownTickMsg struct{}
+
+func scheduleCountdown() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg {
+		return countdownTickMsg{}
+	})
+}
+
+// setUpcomingEvents keeps the timed events from a calendar load, in start
+// order, and picks the next one
+func (m *Model) setUpcomingEvents(events []providers.CalendarEvent) {
+	m.upcomingEvents = m.upcomingEvents[:0]
+	for _, e := range events {
+		if !e.AllDay {
+			m.upcomingEvents = append(m.upcomingEvents, e)
+		}
+	}
+	sort.SliceStable(m.upcomingEvents, func(i, j int) bool {
+		return m.upcomingEvents[i].StartTime.Before(m.upcomingEvents[j].StartTime)
+	})
+	m.advanceNextEvent(time.Now())
+}
+
+// advanceNextEvent points nextEvent at the first event that hasn't started
+func (m *Model) advanceNextEvent(now time.Time) {
+	m.nextEvent = nil
+	for i := range m.upcomingEvents {
+		if m.upcomingEvents[i].StartTime.After(now) {
+			m.nextEvent = &m.upcomingEvents[i]
+			return
+		}
+	}
+}
+
+// renderCountdown shows "⏱ Title in 12m" when the next event is within the hour
+func (m *Model) renderCountdown() string {
+	if m.nextEvent == nil {
+		return ""
+	}
+	until := time.Until(m.nextEvent.StartTime)
+	if until <= 0 || until > countdownWindow {
+		return ""
+	}
+
+	mins := int(until.Round(time.Minute).Minutes())
+	if mins < 1 {
+		mins = 1
+	}
+	text := fmt.Sprintf("⏱ %s in %dm", m.nextEvent.Title, mins)
+	if until <= countdownUrgent {
+		return m.styles.Error.Render(text)
+	}
+	return m.styles.Warning.Render(text)
+}
+
+// meetingPrepContext describes the next event for the AI prompt when it
+// starts within meetingPrepLead, or returns ""
+func (m *Model) meetingPrepContext(now time.Time) string {
+	if m.nextEvent == nil {
+		return ""
+	}
+	until := m.nextEvent.StartTime.Sub(now)
+	if until <= 0 || until > meetingPrepLead {
+		return ""
+	}
+
+	e := m.nextEvent
+	lines := []string{fmt.Sprintf("%s at %s", e.Title, e.StartTime.Format("3:04 PM"))}
+	var attendees []string
+	for _, a := range e.Attendees {
+		if a.Self {
+			continue
+		}
+		name := a.Name
+		if name == "" {
+			name = a.Email
+		}
+		attendees = append(attendees, name)
+	}
+	if len(attendees) > 0 {
+		lines = append(lines, "Attendees: "+strings.Join(attendees, ", "))
+	}
+	if e.Location != "" {
+		lines = append(lines, "Location: "+e.Location)
+	}
+	return strings.Join(lines, "\n")
+}
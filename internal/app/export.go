@@ -0,0 +1,47 @@
+package app
+
+import (
+	"time"
+
+	"github.com/szoloth/partner/internal/export"
+	"github.com/szoloth/partner/internal/panes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ExportedMsg reports where the focused pane was exported to
+type ExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// exportFocused writes the focused pane as Markdown to the Desktop. It
+// returns nil for panes the exporter doesn't cover, letting the key reach
+// the pane.
+func (m *Model) exportFocused() tea.Cmd {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return nil
+	}
+	pane := m.activePanes[m.focusedPane]
+	switch pane.Type() {
+	case panes.PaneTasks, panes.PaneCalendar, panes.PaneCoS:
+	default:
+		return nil
+	}
+
+	// Render now so the file matches what is on screen
+	content, err := export.NewMarkdown().Export(pane)
+	return func() tea.Msg {
+		if err != nil {
+			return ExportedMsg{Err: err}
+		}
+		path, err := export.DefaultPath(pane.Type(), time.Now())
+		if err != nil {
+			return ExportedMsg{Err: err}
+		}
+		if err := export.WriteFile(content, path); err != nil {
+			return ExportedMsg{Err: err}
+		}
+		return ExportedMsg{Path: path}
+	}
+}
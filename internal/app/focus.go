@@ -0,0 +1,105 @@
+package app
+
+import "github.com/szoloth/partner/internal/panes"
+
+// Direction is where focusDirection moves focus: through the pane order
+// (Tab, Shift+Tab) or across the layout (ctrl+h/j/k/l)
+type Direction int
+
+const (
+	DirNext Direction = iota
+	DirPrev
+	DirLeft
+	DirRight
+	DirUp
+	DirDown
+)
+
+// focusDirection moves focus to the pane in dir and reports whether focus
+// moved. Next and Prev wrap around; the spatial directions follow the
+// layout as rendered, so a grid with fewer than four panes behaves like a
+// horizontal split and single layout has no neighbours.
+func (m *Model) focusDirection(dir Direction) bool {
+	n := len(m.activePanes)
+	if n == 0 {
+		return false
+	}
+
+	var target int
+	switch dir {
+	case DirNext:
+		target = (m.focusedPane + 1) % n
+	case DirPrev:
+		target = (m.focusedPane - 1 + n) % n
+	default:
+		target = m.spatialNeighbour(dir)
+	}
+	if target < 0 || target >= n || target == m.focusedPane {
+		return false
+	}
+
+	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Blur().(panes.Pane)
+	m.focusedPane = target
+	m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Focus().(panes.Pane)
+	return true
+}
+
+// spatialNeighbour returns the index of the pane next to the focused one
+// in dir, or -1 when there is none
+func (m *Model) spatialNeighbour(dir Direction) int {
+	n := len(m.activePanes)
+	layout := m.layout
+	if layout == LayoutGrid && n < 4 {
+		layout = LayoutSplitH
+	}
+	if n < 2 {
+		return -1
+	}
+
+	switch layout {
+	case LayoutSplitH:
+		switch dir {
+		case DirLeft:
+			return 0
+		case DirRight:
+			return 1
+		}
+	case LayoutSplitV:
+		switch dir {
+		case DirUp:
+			return 0
+		case DirDown:
+			return 1
+		}
+	case LayoutGrid:
+		// Panes 0 1 on the top row, 2 3 below
+		row, col := m.focusedPane/2, m.focusedPane%2
+		switch dir {
+		case DirLeft:
+			col = 0
+		case DirRight:
+			col = 1
+		case DirUp:
+			row = 0
+		case DirDown:
+			row = 1
+		}
+		return row*2 + col
+	}
+	return -1
+}
+
+// focusKeyDirection maps the directional focus keys to a Direction
+func (m *Model) focusKeyDirection(key string) (Direction, bool) {
+	switch key {
+	case m.keys.FocusLeft:
+		return DirLeft, true
+	case m.keys.FocusRight:
+		return DirRight, true
+	case m.keys.FocusUp:
+		return DirUp, true
+	case m.keys.FocusDown:
+		return DirDown, true
+	}
+	return 0, false
+}
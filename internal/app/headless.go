@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	cospane "github.com/szoloth/partner/internal/panes/cos"
+)
+
+// cosData loads the CoS state file and returns what the CoS pane's
+// GetData reports for it; no MCP server is involved
+func (m *Model) cosData() (interface{}, error) {
+	state, err := m.cosProvider.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CoS state: %w", err)
+	}
+	pane := cospane.New()
+	pane.Update(cospane.StateLoadedMsg{State: state})
+	return pane.GetData(), nil
+}
+
+// calendarData fetches today's events from the configured calendars
+func (m *Model) calendarData(ctx context.Context) (interface{}, error) {
+	var gcal providers.CalendarProviderInterface
+	if m.cfg.UsesCalendar("gcal") {
+		t, err := m.newGCalTransport()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Google Calendar transport: %w", err)
+		}
+		p := providers.NewGCalProvider(m.toolClient(t, "google-calendar", gcalCacheTTL), m.cfg.GCalCalendarIDs,
+			providers.WithTimezone(m.cfg.HomeTimezone))
+		p.SetUserEmail(m.cfg.UserEmail)
+		gcal = p
+	}
+	cal := m.mergeCalendars(gcal)
+	if cal == nil {
+		return nil, fmt.Errorf("calendar_providers is empty")
+	}
+	defer cal.Close()
+
+	events, err := cal.GetTodayEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"view":   "today",
+		"events": events,
+		"count":  len(events),
+	}, nil
+}
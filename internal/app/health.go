@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/mcp/providers"
+	logpane "github.com/szoloth/partner/internal/panes/log"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// healthCheckInterval is how often each MCP server is pinged
+const healthCheckInterval = 30 * time.Second
+
+// thingsHealthTimeout bounds the startup check that Things 3 is reachable
+const thingsHealthTimeout = 10 * time.Second
+
+// ThingsHealthMsg carries the result of the startup Things health check
+type ThingsHealthMsg struct {
+	Err error
+}
+
+// ConnectionStateMsg reports a reconnect event from an MCP client: Err is
+// mcp.ErrReconnecting when a reconnect starts, nil once it succeeds, or the
+// error it gave up with
+type ConnectionStateMsg struct {
+	Server string
+	Err    error
+	events <-chan error
+}
+
+// trackClient remembers a client so its connection can be monitored
+func (m *Model) trackClient(c *mcp.Client) {
+	m.clientsMu.Lock()
+	defer m.clientsMu.Unlock()
+	m.mcpClients[c.ServerID()] = c
+}
+
+// startHealthChecks pings every tracked client in the background
+func (m *Model) startHealthChecks() tea.Cmd {
+	m.clientsMu.Lock()
+	defer m.clientsMu.Unlock()
+
+	servers := make([]string, 0, len(m.mcpClients))
+	for server := range m.mcpClients {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+
+	cmds := make([]tea.Cmd, 0, len(servers))
+	for _, server := range servers {
+		events := m.mcpClients[server].HealthCheck(context.Background(), healthCheckInterval)
+		cmds = append(cmds, waitForConnectionState(server, events))
+	}
+	return tea.Batch(cmds...)
+}
+
+// waitForConnectionState delivers the next event from a health channel
+func waitForConnectionState(server string, events <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-events
+		if !ok {
+			return nil
+		}
+		return ConnectionStateMsg{Server: server, Err: err, events: events}
+	}
+}
+
+// handleConnectionState updates the indicator and keeps listening
+func (m *Model) handleConnectionState(msg ConnectionStateMsg) tea.Cmd {
+	next := waitForConnectionState(msg.Server, msg.events)
+
+	switch {
+	case errors.Is(msg.Err, mcp.ErrReconnecting):
+		m.reconnecting[msg.Server] = true
+		return next
+	case msg.Err == nil:
+		delete(m.reconnecting, msg.Server)
+		m.status = "[connected]"
+		m.statusWarning = false
+	default:
+		delete(m.reconnecting, msg.Server)
+		m.status = fmt.Sprintf("%s: reconnect failed: %v", msg.Server, msg.Err)
+		m.statusWarning = true
+	}
+	return tea.Batch(next, clearStatusAfter(m.status, 2*time.Second))
+}
+
+// renderConnectionState shows "[reconnecting...]" while any server is down
+func (m *Model) renderConnectionState() string {
+	if len(m.reconnecting) == 0 {
+		return ""
+	}
+	return m.styles.Warning.Render("[reconnecting...]")
+}
+
+// checkThingsHealth asks the Things server whether Things 3 itself is
+// reachable, in the background so startup doesn't wait on it
+func (m *Model) checkThingsHealth() tea.Cmd {
+	provider := m.thingsProvider
+	if provider == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), thingsHealthTimeout)
+		defer cancel()
+		return ThingsHealthMsg{Err: provider.HealthCheck(ctx)}
+	}
+}
+
+// handleThingsHealth replaces the status with a friendly message when the
+// server is up but Things 3 is missing, and with the tool's own error
+// when it fails for another reason; failures to reach the server show up
+// on the first load anyway
+func (m *Model) handleThingsHealth(msg ThingsHealthMsg) {
+	switch {
+	case errors.Is(msg.Err, providers.ErrThingsNotInstalled):
+		m.status = "Things 3 not found – install from Mac App Store"
+	case errors.Is(msg.Err, providers.ErrThingsToolError):
+		m.status = msg.Err.Error()
+	default:
+		return
+	}
+	m.statusWarning = true
+	m.logStatus(logpane.LevelError, msg.Err.Error())
+}
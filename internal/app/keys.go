@@ -0,0 +1,73 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/szoloth/partner/internal/panes"
+)
+
+// paneBinding pairs a pane with the key that switches to it
+type paneBinding struct {
+	key  string
+	pane panes.PaneType
+}
+
+// paneBindings lists the pane switch keys in display order
+func (m *Model) paneBindings() []paneBinding {
+	return []paneBinding{
+		{m.keys.SwitchPane0, panes.PaneCoS}, // Chief of Staff - primary pane
+		{m.keys.SwitchPane1, panes.PaneTasks},
+		{m.keys.SwitchPane2, panes.PaneCalendar},
+		{m.keys.SwitchPane3, panes.PaneEmail},
+		{m.keys.SwitchPane4, panes.PaneKnowledge},
+		{m.keys.SwitchPane5, panes.PaneCRM},
+		{m.keys.SwitchPane6, panes.PaneProjects},
+		{m.keys.SwitchPane7, panes.PaneLog},
+	}
+}
+
+// paneShortcut returns the pane bound to key, if any
+func (m *Model) paneShortcut(key string) (panes.PaneType, bool) {
+	for _, b := range m.paneBindings() {
+		if b.key != "" && b.key == key {
+			return b.pane, true
+		}
+	}
+	return 0, false
+}
+
+// keySequence splits a binding like "ctrl+w o" into its prefix and final key.
+// Single-key bindings have an empty prefix.
+func keySequence(binding string) (prefix, last string) {
+	fields := strings.Fields(binding)
+	switch len(fields) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", fields[0]
+	}
+	return fields[0], fields[len(fields)-1]
+}
+
+// matchMaximize tracks the maximize-pane sequence. It reports whether key
+// was consumed and whether the sequence just completed.
+func (m *Model) matchMaximize(key string) (consumed, done bool) {
+	prefix, last := keySequence(m.keys.MaximizePane)
+	if last == "" {
+		return false, false
+	}
+	if prefix == "" {
+		return key == last, key == last
+	}
+	if m.awaitingWindowCmd {
+		m.awaitingWindowCmd = false
+		if key == last {
+			return true, true
+		}
+	}
+	if key == prefix {
+		m.awaitingWindowCmd = true
+		return true, false
+	}
+	return false, false
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	logpane "github.com/szoloth/partner/internal/panes/log"
+)
+
+// logStatus records a status bar message in the log pane
+func (m *Model) logStatus(level logpane.Level, text string) {
+	m.logPane.Append(level, text)
+}
+
+// diagnosticTimeout bounds each server call made by diagnosticLog
+const diagnosticTimeout = 15 * time.Second
+
+// diagnosticLog fills the log pane for "--pane log" in headless mode: the
+// resolved file paths, then a live call to the Things and Google Calendar servers.
+// Failures are logged, not returned, so one run reports every problem.
+func (m *Model) diagnosticLog(ctx context.Context) interface{} {
+	for _, p := range m.cfg.Paths {
+		if _, err := os.Stat(p.Path); err != nil {
+			m.logStatus(logpane.LevelError, fmt.Sprintf("%s: %v (from %s)", p.Key, err, p.Source))
+		} else {
+			m.logStatus(logpane.LevelInfo, fmt.Sprintf("%s: %s (from %s)", p.Key, p.Path, p.Source))
+		}
+	}
+
+	if t, err := m.newThingsTransport(); err != nil {
+		m.logStatus(logpane.LevelError, fmt.Sprintf("Things: failed to create transport: %v", err))
+	} else {
+		things := providers.NewThingsProvider(m.toolClient(t, "things", thingsCacheTTL))
+		callCtx, cancel := context.WithTimeout(ctx, diagnosticTimeout)
+		tasks, err := things.GetToday(callCtx)
+		cancel()
+		if err != nil {
+			m.logStatus(logpane.LevelError, fmt.Sprintf("Things: %v", err))
+		} else {
+			m.logStatus(logpane.LevelInfo, fmt.Sprintf("Things: connected, %d tasks today", len(tasks)))
+		}
+		things.Close()
+	}
+
+	if t, err := m.newGCalTransport(); err != nil {
+		m.logStatus(logpane.LevelError, fmt.Sprintf("Google Calendar: failed to create transport: %v", err))
+	} else {
+		gcal := providers.NewGCalProvider(m.toolClient(t, "google-calendar", gcalCacheTTL), m.cfg.GCalCalendarIDs,
+			providers.WithTimezone(m.cfg.HomeTimezone))
+		callCtx, cancel := context.WithTimeout(ctx, diagnosticTimeout)
+		events, err := gcal.GetTodayEvents(callCtx)
+		cancel()
+		if err != nil {
+			m.logStatus(logpane.LevelError, fmt.Sprintf("Google Calendar: %v", err))
+		} else {
+			m.logStatus(logpane.LevelInfo, fmt.Sprintf("Google Calendar: connected, %d events today", len(events)))
+		}
+		gcal.Close()
+	}
+
+	return m.logPane.GetData()
+}
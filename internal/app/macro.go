@@ -0,0 +1,233 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/szoloth/partner/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// macrosPath is where named macros persist between runs
+const macrosPath = "~/.config/partner/macros.json"
+
+const (
+	maxMacros     = 10                    // Named macros kept in macrosPath
+	macroKeyDelay = 50 * time.Millisecond // Gap between replayed keys
+)
+
+// macroKey is a tea.KeyMsg as stored in macrosPath
+type macroKey struct {
+	Type  tea.KeyType `json:"type"`
+	Runes string      `json:"runes,omitempty"`
+	Alt   bool        `json:"alt,omitempty"`
+}
+
+func toMacroKey(k tea.KeyMsg) macroKey {
+	return macroKey{Type: k.Type, Runes: string(k.Runes), Alt: k.Alt}
+}
+
+func (k macroKey) keyMsg() tea.KeyMsg {
+	return tea.KeyMsg{Type: k.Type, Runes: []rune(k.Runes), Alt: k.Alt}
+}
+
+// macroStepMsg replays key index of the playback started as run id
+type macroStepMsg struct {
+	id    int
+	index int
+}
+
+// Pending macro prefixes, waiting for the register letter
+const (
+	macroPendingRecord = "record"
+	macroPendingPlay   = "play"
+)
+
+// handleMacroKey runs before all other key handling. It consumes the
+// record/play keys and their register letter, stops playback on esc, and
+// appends every other key to the macro being recorded. It reports whether
+// the key was consumed.
+func (m *Model) handleMacroKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Replayed keys go straight to the normal handling
+	if m.replayingMacro {
+		return nil, false
+	}
+	key := msg.String()
+
+	if m.macroPlayback != nil && msg.Type == tea.KeyEsc {
+		m.macroPlayback = nil
+		m.status = "Macro stopped"
+		return clearStatusAfter(m.status, 2*time.Second), true
+	}
+
+	if pending := m.macroPending; pending != "" {
+		m.macroPending = ""
+		switch {
+		case pending == macroPendingPlay && key == m.keys.PlayMacro:
+			if m.lastMacro == "" {
+				return m.commandError("No macro played yet"), true
+			}
+			return m.playMacro(m.lastMacro), true
+		case !isRegister(key):
+			return nil, true
+		case pending == macroPendingRecord:
+			return m.startRecording(key), true
+		default:
+			return m.playMacro(key), true
+		}
+	}
+
+	// The macro keys are ordinary characters while text is being typed
+	typing := m.commandMode || m.search != nil || m.palette != nil || m.focusedCapturingInput()
+	if !typing {
+		switch key {
+		case m.keys.RecordMacro:
+			if m.macroRegister != "" {
+				return m.stopRecording(), true
+			}
+			m.macroPending = macroPendingRecord
+			return nil, true
+		case m.keys.PlayMacro:
+			if m.macroRegister == "" {
+				m.macroPending = macroPendingPlay
+				return nil, true
+			}
+		}
+	}
+
+	if m.macroRegister != "" {
+		m.recordedKeys = append(m.recordedKeys, msg)
+	}
+	return nil, false
+}
+
+// isRegister reports whether key names a macro register (a-z)
+func isRegister(key string) bool {
+	return len(key) == 1 && key[0] >= 'a' && key[0] <= 'z'
+}
+
+// startRecording begins recording into register reg
+func (m *Model) startRecording(reg string) tea.Cmd {
+	if err := m.loadMacros(); err != nil {
+		return m.commandError(err.Error())
+	}
+	if _, exists := m.macros[reg]; !exists && len(m.macros) >= maxMacros {
+		return m.commandError(fmt.Sprintf("Macro limit reached (%d); record over an existing letter", maxMacros))
+	}
+	m.macroRegister = reg
+	m.recordedKeys = nil
+	return nil
+}
+
+// stopRecording saves the recorded keys under the current register
+func (m *Model) stopRecording() tea.Cmd {
+	reg := m.macroRegister
+	m.macroRegister = ""
+	if len(m.recordedKeys) == 0 {
+		m.status = "Empty macro discarded"
+		return clearStatusAfter(m.status, 2*time.Second)
+	}
+
+	keys := make([]macroKey, len(m.recordedKeys))
+	for i, k := range m.recordedKeys {
+		keys[i] = toMacroKey(k)
+	}
+	m.macros[reg] = keys
+	m.recordedKeys = nil
+	if err := m.saveMacros(); err != nil {
+		return m.commandError(err.Error())
+	}
+	m.status = fmt.Sprintf("Saved macro @%s (%d keys)", reg, len(keys))
+	return clearStatusAfter(m.status, 2*time.Second)
+}
+
+// playMacro replays register reg one key every macroKeyDelay
+func (m *Model) playMacro(reg string) tea.Cmd {
+	if err := m.loadMacros(); err != nil {
+		return m.commandError(err.Error())
+	}
+	keys, ok := m.macros[reg]
+	if !ok || len(keys) == 0 {
+		return m.commandError("No macro @" + reg)
+	}
+	m.lastMacro = reg
+	m.macroRun++
+	m.macroPlayback = keys
+	return macroStep(m.macroRun, 0)
+}
+
+func macroStep(id, index int) tea.Cmd {
+	return tea.Tick(macroKeyDelay, func(time.Time) tea.Msg {
+		return macroStepMsg{id: id, index: index}
+	})
+}
+
+// handleMacroStep dispatches one replayed key through Update and schedules
+// the next
+func (m *Model) handleMacroStep(msg macroStepMsg) tea.Cmd {
+	// A stopped or superseded run
+	if msg.id != m.macroRun || m.macroPlayback == nil || msg.index >= len(m.macroPlayback) {
+		return nil
+	}
+
+	m.replayingMacro = true
+	_, cmd := m.Update(m.macroPlayback[msg.index].keyMsg())
+	m.replayingMacro = false
+
+	if msg.index+1 < len(m.macroPlayback) {
+		return tea.Batch(cmd, macroStep(msg.id, msg.index+1))
+	}
+	m.macroPlayback = nil
+	return cmd
+}
+
+// renderMacroState shows "⏺ REC @a" while recording and "▶ @a" during
+// playback
+func (m *Model) renderMacroState() string {
+	switch {
+	case m.macroRegister != "":
+		return m.styles.Error.Render("⏺ REC @" + m.macroRegister)
+	case m.macroPlayback != nil:
+		return m.styles.Warning.Render("▶ @" + m.lastMacro)
+	}
+	return ""
+}
+
+// loadMacros reads macrosPath once; a missing file means no macros
+func (m *Model) loadMacros() error {
+	if m.macros != nil {
+		return nil
+	}
+	m.macros = make(map[string][]macroKey)
+	data, err := os.ReadFile(config.ExpandPath(macrosPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read macros: %w", err)
+	}
+	if err := json.Unmarshal(data, &m.macros); err != nil {
+		return fmt.Errorf("failed to parse macros: %w", err)
+	}
+	return nil
+}
+
+// saveMacros writes every named macro to macrosPath
+func (m *Model) saveMacros() error {
+	data, err := json.MarshalIndent(m.macros, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode macros: %w", err)
+	}
+	path := config.ExpandPath(macrosPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write macros: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"github.com/szoloth/partner/internal/panes"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -14,6 +16,11 @@ type ChangeLayoutMsg struct {
 	Layout LayoutMode
 }
 
+// SplitRatioMsg sets the split layout ratio (clamped to 0.2-0.8)
+type SplitRatioMsg struct {
+	Ratio float64
+}
+
 type FocusNextMsg struct{}
 type FocusPrevMsg struct{}
 
@@ -24,10 +31,14 @@ type DataLoadedMsg struct {
 	Err  error
 }
 
+// RefreshMsg refreshes a pane, or every stale active pane when Pane is allActive
 type RefreshMsg struct {
 	Pane panes.PaneType
 }
 
+// allActive targets every active pane in a RefreshMsg
+const allActive panes.PaneType = -1
+
 // Error messages
 type ErrorMsg struct {
 	Err error
@@ -38,6 +49,11 @@ type StatusMsg struct {
 	Text string
 }
 
+// clearStatusMsg clears the status bar if it still shows Text
+type clearStatusMsg struct {
+	Text string
+}
+
 // Command helpers
 func switchPane(target panes.PaneType) tea.Cmd {
 	return func() tea.Msg {
@@ -50,3 +66,10 @@ func setStatus(text string) tea.Cmd {
 		return StatusMsg{Text: text}
 	}
 }
+
+// clearStatusAfter clears a flashed status message after d
+func clearStatusAfter(text string, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return clearStatusMsg{Text: text}
+	})
+}
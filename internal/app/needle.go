@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/claude"
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/panes/tasks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askNeedleMover has Claude pick the highest-leverage task among titles
+// and shows the answer in the AI modal, offering to jump to that task
+func (m *Model) askNeedleMover(titles []string) tea.Cmd {
+	if m.aiLoading || m.aiStreaming || len(titles) == 0 {
+		return nil
+	}
+	m.aiLoading = true
+	m.aiAction = nil
+	m.aiUsage = nil
+	m.aiContextPanes = nil
+	m.status = "Asking Claude for a needle mover..."
+
+	client := m.claudeClient
+	goals := m.cfg.Goals
+	timeout := time.Duration(m.cfg.AITimeoutSeconds) * time.Second
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		resp := client.NeedleMover(ctx, titles, goals)
+		if resp.Error != nil {
+			return AIResponseMsg{Err: resp.Error}
+		}
+		action := resp.Action
+		if title, ok := pickTitle(resp.Text, titles); ok {
+			action = &claude.Action{
+				Type:        claude.ActionFocusTask,
+				Description: "Focus on " + title + " (enter)",
+				Data:        map[string]interface{}{"title": title},
+			}
+		}
+		return AIResponseMsg{
+			Text:      resp.Text,
+			Action:    action,
+			SessionID: resp.SessionID,
+			Usage:     resp.Usage,
+		}
+	}
+}
+
+// pickTitle finds which of titles the response names. The longest match
+// wins so "Write post" doesn't shadow "Write post outline".
+func pickTitle(text string, titles []string) (string, bool) {
+	lower := strings.ToLower(text)
+	best := ""
+	for _, t := range titles {
+		if t != "" && len(t) > len(best) && strings.Contains(lower, strings.ToLower(t)) {
+			best = t
+		}
+	}
+	return best, best != ""
+}
+
+// focusTask jumps to the task named by an ActionFocusTask action
+func (m *Model) focusTask(action *claude.Action) tea.Cmd {
+	title, _ := action.Data["title"].(string)
+	if title == "" {
+		return nil
+	}
+	var cmd tea.Cmd
+	if !m.focusedIs(panes.PaneTasks) {
+		cmd = m.switchToPane(panes.PaneTasks)
+	}
+	return tea.Batch(cmd, func() tea.Msg {
+		return tasks.HighlightTaskMsg{Title: title}
+	})
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/notifications"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// eventReminderMsg fires notifyBefore ahead of an event's start
+type eventReminderMsg struct {
+	Event providers.CalendarEvent
+}
+
+// WithNotifier replaces the desktop notifier (e.g. with a fake in tests)
+func WithNotifier(n notifications.Notifier) Option {
+	return func(m *Model) {
+		m.notifier = n
+	}
+}
+
+// notifyBefore is the configured reminder lead time
+func (m *Model) notifyBefore() time.Duration {
+	return time.Duration(m.cfg.NotifyBeforeMinutes) * time.Minute
+}
+
+// reminderKey identifies an event occurrence; a moved event gets a new key
+func reminderKey(e providers.CalendarEvent) string {
+	return e.ID + "@" + e.StartTime.Format(time.RFC3339)
+}
+
+// scheduleReminders starts a timer for each loaded event that hasn't been
+// scheduled yet, so refreshes don't stack duplicate timers
+func (m *Model) scheduleReminders(events []providers.CalendarEvent) tea.Cmd {
+	if m.notifier == nil {
+		return nil
+	}
+	now := time.Now()
+	var cmds []tea.Cmd
+	for _, e := range events {
+		key := reminderKey(e)
+		if e.AllDay || !e.StartTime.After(now) || m.scheduledReminders[key] {
+			continue
+		}
+		m.scheduledReminders[key] = true
+
+		event := e
+		delay := e.StartTime.Sub(now) - m.notifyBefore()
+		if delay < 0 {
+			delay = 0
+		}
+		cmds = append(cmds, tea.Tick(delay, func(time.Time) tea.Msg {
+			return eventReminderMsg{Event: event}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// remind shows the notification for an event once, skipping events that
+// were moved or cancelled since the timer started
+func (m *Model) remind(e providers.CalendarEvent) {
+	key := reminderKey(e)
+	if m.notifiedEvents[key] || !m.isUpcoming(key) {
+		return
+	}
+	m.notifiedEvents[key] = true
+
+	notifier := m.notifier
+	go func() {
+		// Notifications are best effort; there's nowhere useful to report failure
+		_ = notifier.Notify(e.Title, e.StartTime.Format("3:04 PM"))
+	}()
+}
+
+// isUpcoming reports whether the last calendar load still has the event
+func (m *Model) isUpcoming(key string) bool {
+	for _, e := range m.upcomingEvents {
+		if reminderKey(e) == key {
+			return true
+		}
+	}
+	return false
+}
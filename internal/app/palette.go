@@ -0,0 +1,297 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/szoloth/partner/internal/fuzzy"
+	"github.com/szoloth/partner/internal/panes"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPaletteResults caps how many actions the palette lists at once
+const maxPaletteResults = 12
+
+// PaletteAction is an entry in the quick-action palette
+type PaletteAction struct {
+	Label string
+	Keys  []string // Bindings shown as a hint; may be empty
+	Cmd   func(*Model) tea.Cmd
+}
+
+// palette is the open quick-action overlay
+type palette struct {
+	input   textinput.Model
+	matches []int // Indices into Model.paletteActions, best first
+	cursor  int
+}
+
+// defaultPaletteActions lists every action the palette offers
+func (m *Model) defaultPaletteActions() []PaletteAction {
+	var actions []PaletteAction
+
+	for _, b := range m.paneBindings() {
+		target := b.pane
+		actions = append(actions, PaletteAction{
+			Label: "Go to " + paneTitle(target),
+			Keys:  []string{b.key},
+			Cmd: func(m *Model) tea.Cmd {
+				if _, ok := m.paneInstances[target]; !ok {
+					return m.commandError(fmt.Sprintf("Pane not available: %s", target))
+				}
+				return m.switchToPane(target)
+			},
+		})
+	}
+
+	for _, layout := range []LayoutMode{LayoutSingle, LayoutSplitH, LayoutSplitV, LayoutGrid} {
+		mode := layout
+		actions = append(actions, PaletteAction{
+			Label: "Layout: " + mode.String(),
+			Keys:  []string{":layout " + mode.String()},
+			Cmd:   func(m *Model) tea.Cmd { return m.setLayout(mode) },
+		})
+	}
+
+	for _, t := range themeRegistry {
+		t := t
+		actions = append(actions, PaletteAction{
+			Label: "Theme: " + t.Name,
+			Keys:  []string{":theme " + t.Name},
+			Cmd:   func(m *Model) tea.Cmd { return m.applyTheme(t) },
+		})
+	}
+
+	actions = append(actions,
+		PaletteAction{
+			Label: "Cycle theme",
+			Keys:  []string{m.keys.CycleTheme},
+			Cmd:   func(m *Model) tea.Cmd { return m.cycleTheme() },
+		},
+		PaletteAction{
+			Label: "Toggle split",
+			Keys:  []string{m.keys.ToggleSplit},
+			Cmd:   func(m *Model) tea.Cmd { return m.toggleSplit() },
+		},
+		PaletteAction{
+			Label: "Maximize pane",
+			Keys:  []string{m.keys.MaximizePane},
+			Cmd:   func(m *Model) tea.Cmd { return m.maximizePane() },
+		},
+		PaletteAction{
+			Label: "AI assist",
+			Keys:  []string{m.keys.AIAssist},
+			Cmd:   func(m *Model) tea.Cmd { return m.triggerAIAssist() },
+		},
+		PaletteAction{
+			Label: "Refresh all panes",
+			Keys:  []string{":refresh"},
+			Cmd:   func(m *Model) tea.Cmd { return m.refreshActivePanes(false) },
+		},
+		PaletteAction{
+			Label: "Search",
+			Keys:  []string{m.keys.Search},
+			Cmd:   func(m *Model) tea.Cmd { return m.openSearch() },
+		},
+		PaletteAction{
+			Label: "Complete focused task",
+			Keys:  []string{"d"},
+			Cmd: func(m *Model) tea.Cmd {
+				if !m.focusedIs(panes.PaneTasks) {
+					return m.commandError("Focus the tasks pane to complete a task")
+				}
+				return m.sendKeyToFocused("d")
+			},
+		},
+		PaletteAction{
+			Label: "Create task",
+			Keys:  []string{"n"},
+			Cmd:   func(m *Model) tea.Cmd { return m.sendKeyToPane(panes.PaneTasks, "n") },
+		},
+		PaletteAction{
+			Label: "Create calendar event",
+			Keys:  []string{"n"},
+			Cmd:   func(m *Model) tea.Cmd { return m.sendKeyToPane(panes.PaneCalendar, "n") },
+		},
+		PaletteAction{
+			Label: "Start/pause pomodoro",
+			Keys:  []string{m.keys.Pomodoro},
+			Cmd:   func(m *Model) tea.Cmd { return m.togglePomodoro() },
+		},
+		PaletteAction{
+			Label: "Quit",
+			Keys:  []string{m.keys.Quit},
+			Cmd:   func(m *Model) tea.Cmd { return m.quit() },
+		},
+	)
+
+	return actions
+}
+
+// paneTitle capitalizes a pane name for display
+func paneTitle(p panes.PaneType) string {
+	if p == panes.PaneCoS {
+		return "Chief of Staff"
+	}
+	name := p.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// focusedIs reports whether the focused pane is of type pt
+func (m *Model) focusedIs(pt panes.PaneType) bool {
+	return len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) &&
+		m.activePanes[m.focusedPane].Type() == pt
+}
+
+// sendKeyToFocused delivers a synthetic key press to the focused pane
+func (m *Model) sendKeyToFocused(key string) tea.Cmd {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return nil
+	}
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	updated, cmd := m.activePanes[m.focusedPane].Update(msg)
+	pane := updated.(panes.Pane)
+	m.activePanes[m.focusedPane] = pane
+	m.paneInstances[pane.Type()] = pane
+	return cmd
+}
+
+// sendKeyToPane switches to pt if needed, then delivers key to it
+func (m *Model) sendKeyToPane(pt panes.PaneType, key string) tea.Cmd {
+	if _, ok := m.paneInstances[pt]; !ok {
+		return m.commandError(fmt.Sprintf("Pane not available: %s", pt))
+	}
+	var cmds []tea.Cmd
+	if !m.focusedIs(pt) {
+		cmds = append(cmds, m.switchToPane(pt))
+	}
+	cmds = append(cmds, m.sendKeyToFocused(key))
+	return tea.Batch(cmds...)
+}
+
+// openPalette shows the palette with every action listed
+func (m *Model) openPalette() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "type an action"
+	input.CharLimit = 60
+
+	m.palette = &palette{input: input}
+	m.filterPalette()
+	return m.palette.input.Focus()
+}
+
+// handlePaletteKey handles keys while the palette is open. Enter runs the
+// highlighted action; closing the palette any other way runs nothing.
+func (m *Model) handlePaletteKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.palette
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.palette = nil
+		return nil
+	case tea.KeyEnter:
+		m.palette = nil
+		if p.cursor >= len(p.matches) {
+			return nil
+		}
+		return m.paletteActions[p.matches[p.cursor]].Cmd(m)
+	case tea.KeyUp, tea.KeyCtrlK:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil
+	case tea.KeyDown, tea.KeyCtrlJ:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	query := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		m.filterPalette()
+	}
+	return cmd
+}
+
+// filterPalette ranks actions against the query; an empty query lists
+// them all in registration order
+func (m *Model) filterPalette() {
+	p := m.palette
+	p.cursor = 0
+	p.matches = p.matches[:0]
+
+	query := strings.ToLower(strings.TrimSpace(p.input.Value()))
+	scores := make(map[int]int)
+	for i, a := range m.paletteActions {
+		score, ok := fuzzy.Score(a.Label, query)
+		if !ok {
+			continue
+		}
+		scores[i] = score
+		p.matches = append(p.matches, i)
+	}
+	if query == "" {
+		return
+	}
+	// Stable insertion sort keeps registration order among equal scores
+	for i := 1; i < len(p.matches); i++ {
+		for j := i; j > 0 && scores[p.matches[j]] > scores[p.matches[j-1]]; j-- {
+			p.matches[j], p.matches[j-1] = p.matches[j-1], p.matches[j]
+		}
+	}
+}
+
+// overlayPalette draws the palette over the background
+func (m *Model) overlayPalette(background string) string {
+	modalWidth := min(m.width-10, 60)
+	p := m.palette
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Actions"))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	if len(p.matches) == 0 {
+		b.WriteString(m.styles.Muted.Render("No matching actions"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor inside the visible window
+	start := 0
+	if p.cursor >= maxPaletteResults {
+		start = p.cursor - maxPaletteResults + 1
+	}
+	end := min(len(p.matches), start+maxPaletteResults)
+
+	inner := modalWidth - 4
+	for i := start; i < end; i++ {
+		a := m.paletteActions[p.matches[i]]
+		hint := strings.Join(a.Keys, " ")
+		gap := max(1, inner-2-lipgloss.Width(a.Label)-lipgloss.Width(hint))
+		if i == p.cursor {
+			b.WriteString(m.styles.ListItemSelected.Render("> " + a.Label))
+		} else {
+			b.WriteString(m.styles.ListItem.Render("  " + a.Label))
+		}
+		b.WriteString(strings.Repeat(" ", gap) + m.styles.Muted.Render(hint))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Render("↑/↓:select  enter:run  esc:close"))
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Palette.Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(b.String())
+	return m.overlayModal(background, modal, modalWidth)
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"time"
+
+	"github.com/szoloth/partner/internal/pomodoro"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PomodoroTickMsg advances the pomodoro countdown. Gen ties a tick to the
+// run that scheduled it so pausing and resuming can't double the tick rate.
+type PomodoroTickMsg struct {
+	Gen int
+}
+
+// PomodoroCompleteMsg is sent when a work session or break runs out
+type PomodoroCompleteMsg struct {
+	Phase pomodoro.Phase
+	Next  pomodoro.Phase
+}
+
+func (m *Model) schedulePomodoroTick() tea.Cmd {
+	gen := m.pomodoroGen
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return PomodoroTickMsg{Gen: gen}
+	})
+}
+
+// togglePomodoro starts or pauses the timer
+func (m *Model) togglePomodoro() tea.Cmd {
+	m.pomodoro.Toggle(time.Now())
+	if !m.pomodoro.Running() {
+		return nil
+	}
+	m.pomodoroGen++
+	return m.schedulePomodoroTick()
+}
+
+// resetPomodoro stops the timer and hides it from the status bar
+func (m *Model) resetPomodoro() {
+	m.pomodoro.Reset()
+	m.pomodoroGen++
+}
+
+// handlePomodoroTick counts down, reporting completion when a phase ends
+func (m *Model) handlePomodoroTick(msg PomodoroTickMsg) tea.Cmd {
+	if msg.Gen != m.pomodoroGen {
+		return nil
+	}
+	if finished, done := m.pomodoro.Tick(time.Now()); done {
+		next := m.pomodoro.Phase()
+		return func() tea.Msg {
+			return PomodoroCompleteMsg{Phase: finished, Next: next}
+		}
+	}
+	if m.pomodoro.Running() {
+		return m.schedulePomodoroTick()
+	}
+	return nil
+}
+
+// handlePomodoroComplete announces the end of a phase
+func (m *Model) handlePomodoroComplete(msg PomodoroCompleteMsg) tea.Cmd {
+	text := "Pomodoro done – press p to start your " + msg.Next.String()
+	if msg.Phase != pomodoro.PhaseWork {
+		text = "Break over – press p to start working"
+	}
+	m.status = text
+	if m.notifier != nil {
+		notifier := m.notifier
+		go func() {
+			_ = notifier.Notify("Pomodoro", text)
+		}()
+	}
+	return clearStatusAfter(text, 5*time.Second)
+}
+
+// renderPomodoro shows the countdown once the timer has been started
+func (m *Model) renderPomodoro() string {
+	if !m.pomodoro.Started() {
+		return ""
+	}
+	return m.styles.StatusKey.Render(m.pomodoro.Label())
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sgr matches one complete SGR escape sequence
+var sgr = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// bold and pink style s the way lipgloss would with colors enabled
+func bold(s string) string { return "\x1b[1m" + s + "\x1b[0m" }
+func pink(s string) string { return "\x1b[1;38;5;205m" + s + "\x1b[0m" }
+
+// checkEscapes fails if line holds a partial escape sequence
+func checkEscapes(t *testing.T, line string) {
+	t.Helper()
+	if rest := sgr.ReplaceAllString(line, ""); strings.ContainsRune(rest, '\x1b') {
+		t.Errorf("line %q has a split escape sequence", line)
+	}
+}
+
+func TestWordWrapStyled(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string // Lines with styling stripped
+	}{
+		{
+			"plain",
+			"the quick brown fox jumps",
+			10,
+			[]string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			"bold words",
+			bold("the") + " " + bold("quick") + " " + bold("brown") + " fox " + bold("jumps"),
+			10,
+			[]string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			"styled run across a break",
+			pink("alpha beta gamma delta"),
+			11,
+			[]string{"alpha beta", "gamma delta"},
+		},
+		{
+			"exact fit",
+			bold("abcde") + " " + pink("fghij"),
+			11,
+			[]string{"abcde fghij"},
+		},
+		{
+			"wide runes",
+			bold("日本語") + " " + bold("テスト") + " ok",
+			9,
+			[]string{"日本語", "テスト ok"},
+		},
+		{
+			"long word stays whole",
+			bold("supercalifragilistic") + " x",
+			8,
+			[]string{"supercalifragilistic", "x"},
+		},
+		{
+			"blank lines kept",
+			bold("one") + "\n\n" + bold("two"),
+			10,
+			[]string{"one", "", "two"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(wordWrap(tt.text, tt.width), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines %q, want %q", len(lines), lines, tt.want)
+			}
+			for i, line := range lines {
+				checkEscapes(t, line)
+				if got := ansi.Strip(line); got != tt.want[i] {
+					t.Errorf("line %d = %q, want %q", i, got, tt.want[i])
+				}
+				// Only a single word too long for the width may overflow
+				if w := ansi.StringWidth(line); w > tt.width && strings.Contains(ansi.Strip(line), " ") {
+					t.Errorf("line %d is %d columns wide, want at most %d", i, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestWordWrapKeepsEverySequence(t *testing.T) {
+	text := bold("one") + " " + pink("two") + " " + bold("three") + " " + pink("four")
+	wrapped := wordWrap(text, 7)
+
+	if got, want := len(sgr.FindAllString(wrapped, -1)), len(sgr.FindAllString(text, -1)); got != want {
+		t.Errorf("wrapped text has %d escape sequences, want %d", got, want)
+	}
+	if got := strings.ReplaceAll(wrapped, "\n", " "); got != text {
+		t.Errorf("rejoined lines = %q, want %q", got, text)
+	}
+}
+
+func TestWordWrapNoWidth(t *testing.T) {
+	text := bold("left") + " as is"
+	if got := wordWrap(text, 0); got != text {
+		t.Errorf("wordWrap(width 0) = %q, want the text unchanged", got)
+	}
+}
+
+func TestPadRightStyled(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  int // Visible width of the result
+	}{
+		{"plain", "abc", 6, 6},
+		{"bold", bold("abc"), 6, 6},
+		{"multiple sequences", bold("ab") + pink("cd"), 10, 10},
+		{"wide runes", bold("日本"), 6, 6},
+		{"already wide enough", pink("abcdef"), 4, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := padRight(tt.s, tt.width)
+			checkEscapes(t, got)
+			if w := ansi.StringWidth(got); w != tt.want {
+				t.Errorf("width = %d, want %d (%q)", w, tt.want, got)
+			}
+			if !strings.HasPrefix(got, tt.s) {
+				t.Errorf("padRight(%q) = %q, want the input kept as a prefix", tt.s, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/claude"
+	cosstate "github.com/szoloth/partner/internal/cos"
+	"github.com/szoloth/partner/internal/export"
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askWeeklyReview has Claude write a weekly review from state and the
+// tasks completed in the last seven days, shown in the AI modal with an
+// offer to save it
+func (m *Model) askWeeklyReview(state *cosstate.State) tea.Cmd {
+	if m.aiLoading || m.aiStreaming || state == nil {
+		return nil
+	}
+	m.aiLoading = true
+	m.aiAction = nil
+	m.aiUsage = nil
+	m.aiContextPanes = nil
+	m.status = "Writing weekly review..."
+
+	client := m.claudeClient
+	things := m.thingsProvider
+	timeout := time.Duration(m.cfg.AITimeoutSeconds) * time.Second
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		// Without Things the review still covers the CoS state
+		var completed []providers.Task
+		if things != nil {
+			completed, _ = things.GetLogbook(ctx, "7d")
+		}
+
+		resp := client.WeeklyReview(ctx, completed, state)
+		if resp.Error != nil {
+			return AIResponseMsg{Err: resp.Error}
+		}
+		path, err := export.WeeklyReviewPath(time.Now())
+		if err != nil {
+			return AIResponseMsg{Err: err}
+		}
+		return AIResponseMsg{
+			Text: resp.Text,
+			Action: &claude.Action{
+				Type:        claude.ActionSaveReview,
+				Description: "Save to " + path + " (s)",
+				Data:        map[string]interface{}{"path": path},
+			},
+			SessionID: resp.SessionID,
+			Usage:     resp.Usage,
+		}
+	}
+}
+
+// saveWeeklyReview writes the review in the AI modal to the path named by
+// an ActionSaveReview action
+func (m *Model) saveWeeklyReview(action *claude.Action) tea.Cmd {
+	path, _ := action.Data["path"].(string)
+	if path == "" {
+		return nil
+	}
+	content := strings.TrimSpace(m.aiResponse) + "\n"
+	return func() tea.Msg {
+		if err := export.WriteFile(content, path); err != nil {
+			return ExportedMsg{Err: err}
+		}
+		return ExportedMsg{Path: path}
+	}
+}
@@ -0,0 +1,238 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/theme"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchResults caps how many matches the overlay lists
+const maxSearchResults = 10
+
+// SearchResultMsg is sent when a search result is chosen
+type SearchResultMsg struct {
+	Pane panes.PaneType
+	ID   string
+}
+
+// searchResult is a matched item with its owning pane
+type searchResult struct {
+	pane  panes.PaneType
+	item  panes.SearchItem
+	score int
+}
+
+// SearchPane is the global search overlay opened with '/'
+type SearchPane struct {
+	input   textinput.Model
+	items   []searchResult // Everything searchable, captured on open
+	results []searchResult
+	cursor  int
+	styles  *theme.Styles
+}
+
+// NewSearchPane snapshots the searchable data of the given panes
+func NewSearchPane(sources []panes.Pane, styles *theme.Styles) *SearchPane {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search tasks, events, actions"
+	input.CharLimit = 100
+	input.Focus()
+
+	s := &SearchPane{input: input, styles: styles}
+	for _, src := range sources {
+		searchable, ok := src.(panes.Searchable)
+		if !ok {
+			continue
+		}
+		for _, item := range searchable.SearchItems() {
+			s.items = append(s.items, searchResult{pane: src.Type(), item: item})
+		}
+	}
+	return s
+}
+
+// Init starts the cursor blinking
+func (s *SearchPane) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles keys while the overlay is open. The returned bool is
+// false once the overlay should close.
+func (s *SearchPane) Update(msg tea.Msg) (bool, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEsc:
+			return false, nil
+		case tea.KeyEnter:
+			if s.cursor >= len(s.results) {
+				return false, nil
+			}
+			r := s.results[s.cursor]
+			return false, func() tea.Msg {
+				return SearchResultMsg{Pane: r.pane, ID: r.item.ID}
+			}
+		case tea.KeyUp, tea.KeyCtrlP:
+			if s.cursor > 0 {
+				s.cursor--
+			}
+			return true, nil
+		case tea.KeyDown, tea.KeyCtrlN:
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+			}
+			return true, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	query := s.input.Value()
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.filter()
+	}
+	return true, cmd
+}
+
+// filter recomputes results for the current query
+func (s *SearchPane) filter() {
+	s.results = s.results[:0]
+	s.cursor = 0
+
+	query := strings.ToLower(strings.TrimSpace(s.input.Value()))
+	if query == "" {
+		return
+	}
+
+	for _, it := range s.items {
+		if score, ok := matchScore(it.item.Title, query); ok {
+			it.score = score
+			s.results = append(s.results, it)
+		}
+	}
+
+	sort.SliceStable(s.results, func(i, j int) bool {
+		return s.results[i].score > s.results[j].score
+	})
+	if len(s.results) > maxSearchResults {
+		s.results = s.results[:maxSearchResults]
+	}
+}
+
+// matchScore reports whether title contains query (case-insensitive) and
+// ranks the match: prefix beats word start beats mid-word, earlier and
+// tighter matches rank higher
+func matchScore(title, query string) (int, bool) {
+	lower := strings.ToLower(title)
+	idx := strings.Index(lower, query)
+	if idx < 0 {
+		return 0, false
+	}
+
+	score := 100 - idx
+	switch {
+	case idx == 0:
+		score += 50
+	case lower[idx-1] == ' ' || lower[idx-1] == '-':
+		score += 25
+	}
+	// Prefer titles that are mostly the query
+	score -= (len(lower) - len(query)) / 4
+	return score, true
+}
+
+// View renders the overlay contents
+func (s *SearchPane) View(width int) string {
+	var b strings.Builder
+
+	b.WriteString(s.styles.Title.Render("Search"))
+	b.WriteString("\n\n")
+
+	switch {
+	case strings.TrimSpace(s.input.Value()) == "":
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf("%d items loaded", len(s.items))))
+		b.WriteString("\n")
+	case len(s.results) == 0:
+		b.WriteString(s.styles.Muted.Render("No matches"))
+		b.WriteString("\n")
+	}
+
+	for i, r := range s.results {
+		line := fmt.Sprintf("[%s] %s", r.pane, r.item.Title)
+		if lipgloss.Width(line) > width-2 && width > 5 {
+			line = string([]rune(line)[:width-5]) + "..."
+		}
+		if i == s.cursor {
+			b.WriteString(s.styles.ListItemSelected.Render("> " + line))
+		} else {
+			b.WriteString(s.styles.ListItem.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.input.View())
+	b.WriteString("\n")
+	b.WriteString(s.styles.Muted.Render("↑/↓:select  enter:jump  esc:close"))
+
+	return b.String()
+}
+
+// openSearch opens the search overlay over every loaded pane
+func (m *Model) openSearch() tea.Cmd {
+	sources := make([]panes.Pane, 0, len(m.paneInstances))
+	for _, pt := range []panes.PaneType{panes.PaneTasks, panes.PaneCalendar, panes.PaneCoS, panes.PaneKnowledge} {
+		if pane, ok := m.paneInstances[pt]; ok {
+			sources = append(sources, pane)
+		}
+	}
+	m.search = NewSearchPane(sources, m.styles)
+	return m.search.Init()
+}
+
+// jumpToSearchResult switches to the owning pane and selects the item
+func (m *Model) jumpToSearchResult(msg SearchResultMsg) tea.Cmd {
+	pane, ok := m.paneInstances[msg.Pane]
+	if !ok {
+		return nil
+	}
+	searchable, ok := pane.(panes.Searchable)
+	if !ok {
+		return nil
+	}
+
+	selected := searchable.SelectItem(msg.ID)
+	m.paneInstances[msg.Pane] = selected
+
+	// Focus it in place if already visible, otherwise swap it in
+	for i, ap := range m.activePanes {
+		if ap.Type() == msg.Pane {
+			m.activePanes[m.focusedPane] = m.activePanes[m.focusedPane].Blur().(panes.Pane)
+			m.focusedPane = i
+			m.activePanes[i] = selected.Focus().(panes.Pane)
+			return nil
+		}
+	}
+	return m.switchToPane(msg.Pane)
+}
+
+// overlaySearch draws the search overlay over the background
+func (m *Model) overlaySearch(background string) string {
+	modalWidth := min(m.width-10, 70)
+
+	modalBorder := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Palette.Primary).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	modal := modalBorder.Render(m.search.View(modalWidth - 4))
+	return m.overlayModal(background, modal, modalWidth)
+}
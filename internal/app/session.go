@@ -0,0 +1,180 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/szoloth/partner/internal/config"
+	"github.com/szoloth/partner/internal/panes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionVersion is bumped when the session format changes incompatibly
+const sessionVersion = 1
+
+// sessionPath is where layout and pane state persist between runs
+const sessionPath = "~/.config/partner/session.json"
+
+// Session is the layout and pane state saved on a clean exit
+type Session struct {
+	Version     int            `json:"version"`
+	Layout      string         `json:"layout"`
+	Panes       []string       `json:"panes"`
+	FocusedPane int            `json:"focused_pane"`
+	SplitRatio  float64        `json:"split_ratio"`
+	ViewModes   map[string]int `json:"view_modes,omitempty"` // Keyed by pane name
+}
+
+// WithSessionRestore enables restoring the previous session on startup
+func WithSessionRestore(restore bool) Option {
+	return func(m *Model) {
+		m.restoreSession = restore
+	}
+}
+
+// loadSession reads the saved session. A missing file returns nil; a
+// corrupt or incompatible one is reported as an error.
+func loadSession() (*Session, error) {
+	data, err := os.ReadFile(config.ExpandPath(sessionPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	if s.Version != sessionVersion {
+		return nil, fmt.Errorf("unsupported session version %d", s.Version)
+	}
+	if _, ok := parseLayout(s.Layout); !ok {
+		return nil, fmt.Errorf("unknown layout %q in session", s.Layout)
+	}
+	if s.FocusedPane < 0 {
+		return nil, fmt.Errorf("invalid focused pane %d in session", s.FocusedPane)
+	}
+	return &s, nil
+}
+
+// saveSession writes the current layout and pane state
+func (m *Model) saveSession() error {
+	s := Session{
+		Version:     sessionVersion,
+		Layout:      m.layout.String(),
+		FocusedPane: m.focusedPane,
+		SplitRatio:  m.splitRatio,
+		ViewModes:   make(map[string]int),
+	}
+	for _, pane := range m.activePanes {
+		s.Panes = append(s.Panes, pane.Type().String())
+	}
+	for pt, pane := range m.paneInstances {
+		if vm, ok := pane.(panes.ViewModeSaver); ok {
+			s.ViewModes[pt.String()] = vm.CurrentViewMode()
+		}
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	path := config.ExpandPath(sessionPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// restoreSessionState applies the loaded session's layout settings ahead of Init
+func (m *Model) restoreSessionState() {
+	s, err := loadSession()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring saved session: %v\n", err)
+		return
+	}
+	if s == nil {
+		return
+	}
+
+	m.session = s
+	m.startLayout, _ = parseLayout(s.Layout)
+	if s.SplitRatio > 0 {
+		m.setSplitRatio(s.SplitRatio)
+	}
+	if len(s.Panes) > 0 {
+		m.initialPane = panes.ParsePaneType(s.Panes[0])
+	}
+}
+
+// applyPendingSession arranges the panes recorded in the session once they
+// exist. It returns false if there is no session or none of its panes are
+// available.
+func (m *Model) applyPendingSession() (tea.Cmd, bool) {
+	s := m.session
+	if s == nil {
+		return nil, false
+	}
+	m.session = nil
+
+	for name, mode := range s.ViewModes {
+		pt := panes.ParsePaneType(name)
+		if pane, ok := m.paneInstances[pt]; ok && pt.String() == name {
+			if vm, ok := pane.(panes.ViewModeSaver); ok {
+				m.paneInstances[pt] = vm.RestoreViewMode(mode)
+			}
+		}
+	}
+
+	var active []panes.Pane
+	for _, name := range s.Panes {
+		pt := panes.ParsePaneType(name)
+		if pane, ok := m.paneInstances[pt]; ok && pt.String() == name {
+			active = append(active, pane.Blur().(panes.Pane))
+		}
+	}
+	if len(active) == 0 {
+		return nil, false
+	}
+
+	m.layout, _ = parseLayout(s.Layout)
+	if m.layout == LayoutSingle {
+		active = active[:1]
+	}
+	m.focusedPane = 0
+	if s.FocusedPane < len(active) {
+		m.focusedPane = s.FocusedPane
+	}
+	active[m.focusedPane] = active[m.focusedPane].Focus().(panes.Pane)
+	m.activePanes = active
+	m.redistributeSpace()
+
+	// Refresh each distinct pane once
+	var cmds []tea.Cmd
+	seen := make(map[panes.PaneType]bool)
+	for _, pane := range active {
+		if !seen[pane.Type()] {
+			seen[pane.Type()] = true
+			cmds = append(cmds, pane.Refresh())
+		}
+	}
+	return tea.Batch(cmds...), true
+}
+
+// quit saves the session and exits
+func (m *Model) quit() tea.Cmd {
+	if m.restoreSession && !m.headless {
+		// Best effort: a failed save shouldn't block quitting
+		_ = m.saveSession()
+	}
+	return tea.Quit
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resizeSplit moves the split boundary for ctrl+arrow keys: left/right in
+// a horizontal split, up/down in a vertical one. It reports whether key
+// was used.
+func (m *Model) resizeSplit(key string) bool {
+	var delta float64
+	switch {
+	case m.layout == LayoutSplitH && key == "ctrl+left":
+		delta = -splitRatioStep
+	case m.layout == LayoutSplitH && key == "ctrl+right":
+		delta = splitRatioStep
+	case m.layout == LayoutSplitV && key == "ctrl+up":
+		delta = -splitRatioStep
+	case m.layout == LayoutSplitV && key == "ctrl+down":
+		delta = splitRatioStep
+	default:
+		return false
+	}
+	m.setSplitRatio(m.splitRatio + delta)
+	return true
+}
+
+// markDividerH puts a ┤ (left pane) or ├ (right pane) on the focused
+// pane's border next to the divider, halfway down, to show it can move
+func (m *Model) markDividerH(box string, leftPane bool) string {
+	lines := strings.Split(box, "\n")
+	mid := len(lines) / 2
+	if mid == 0 {
+		return box
+	}
+
+	border := lipgloss.RoundedBorder()
+	line := lines[mid]
+	edge, mark := border.Right, "┤"
+	idx := strings.LastIndex(line, edge)
+	if !leftPane {
+		edge, mark = border.Left, "├"
+		idx = strings.Index(line, edge)
+	}
+	if idx < 0 {
+		return box
+	}
+	lines[mid] = line[:idx] + m.dividerStyle().Render(mark) + line[idx+len(edge):]
+	return strings.Join(lines, "\n")
+}
+
+// markDividerV puts a ┬ (top pane) or ┴ (bottom pane) in the middle of the
+// focused pane's border along the divider
+func (m *Model) markDividerV(box string, topPane bool) string {
+	lines := strings.Split(box, "\n")
+	row, mark := len(lines)-1, "┬"
+	if !topPane {
+		row, mark = 0, "┴"
+	}
+
+	line := lines[row]
+	edge := lipgloss.RoundedBorder().Bottom
+	count := strings.Count(line, edge)
+	if count == 0 {
+		return box
+	}
+
+	// Byte offset of the middle border rune
+	idx := 0
+	for i := 0; i < count/2; i++ {
+		idx += strings.Index(line[idx:], edge) + len(edge)
+	}
+	idx += strings.Index(line[idx:], edge)
+	lines[row] = line[:idx] + m.dividerStyle().Render(mark) + line[idx+len(edge):]
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) dividerStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(m.styles.Palette.Primary)
+}
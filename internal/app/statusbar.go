@@ -0,0 +1,198 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WidgetFn renders one status bar widget; an empty string hides it
+type WidgetFn func(*Model) string
+
+// widgetRegistry maps the names accepted in status_bar.widgets (see
+// config.StatusBarWidgets) to their renderers
+var widgetRegistry = map[string]WidgetFn{
+	"title":      (*Model).titleWidget,
+	"status":     (*Model).statusWidget,
+	"clock":      (*Model).clockWidget,
+	"next_event": (*Model).nextEventWidget,
+	"pomodoro":   (*Model).pomodoroWidget,
+	"ai_cost":    (*Model).aiCostWidget,
+	"theme":      (*Model).themeWidget,
+	"indicators": (*Model).indicatorsWidget,
+}
+
+// renderStatusBar lays out the configured widgets: those before "status"
+// on the left, "status" centered and the rest on the right
+func (m *Model) renderStatusBar() string {
+	cfg := m.cfg.StatusBar
+	var left, center, right []string
+	zone := &left
+	for _, name := range cfg.Widgets {
+		if name == "status" {
+			center = append(center, m.statusWidget())
+			zone = &right
+			continue
+		}
+		fn, ok := widgetRegistry[name]
+		if !ok {
+			continue
+		}
+		if w := fn(m); w != "" {
+			*zone = append(*zone, w)
+		}
+	}
+
+	leftText := strings.Join(left, cfg.Separator)
+	centerText := strings.Join(center, cfg.Separator)
+	rightText := strings.Join(right, cfg.Separator)
+
+	// Center the status, keeping at least a space between zones
+	leftWidth := lipgloss.Width(leftText)
+	centerWidth := lipgloss.Width(centerText)
+	rightWidth := lipgloss.Width(rightText)
+	leftPad := (m.width-centerWidth)/2 - leftWidth
+	if leftPad < 0 {
+		leftPad = 1
+	}
+	rightPad := m.width - leftWidth - leftPad - centerWidth - rightWidth
+	if rightPad < 0 {
+		rightPad = 1
+	}
+
+	bar := leftText + strings.Repeat(" ", leftPad) + centerText + strings.Repeat(" ", rightPad) + rightText
+	return m.styles.StatusBar.Width(m.width).Render(bar)
+}
+
+// hasWidget reports whether the status bar shows the named widget
+func (m *Model) hasWidget(name string) bool {
+	for _, w := range m.cfg.StatusBar.Widgets {
+		if w == name {
+			return true
+		}
+	}
+	return false
+}
+
+// titleWidget is the app name and any macro being recorded or replayed
+func (m *Model) titleWidget() string {
+	title := m.styles.Title.Render(" Partner ")
+	if macro := m.renderMacroState(); macro != "" {
+		title += " " + macro
+	}
+	return title
+}
+
+// statusWidget is the status message, or the command prompt while typing.
+// It leads with the pomodoro timer unless that is a widget of its own.
+func (m *Model) statusWidget() string {
+	if m.commandMode {
+		return m.styles.StatusKey.Render(":" + m.commandBuf + "_")
+	}
+
+	status := m.styles.Muted.Render(m.status)
+	if m.statusWarning {
+		status = m.styles.Warning.Render(m.status)
+	}
+	if m.hasWidget("pomodoro") {
+		return status
+	}
+	if pomo := m.renderPomodoro(); pomo != "" {
+		if m.status == "" {
+			return pomo
+		}
+		return pomo + "  " + status
+	}
+	return status
+}
+
+// clockWidget is the current date and time
+func (m *Model) clockWidget() string {
+	return m.styles.Muted.Render(time.Now().Format("Mon Jan 2 3:04 PM") + " ")
+}
+
+// nextEventWidget is the next timed event among those the calendar pane
+// has loaded
+func (m *Model) nextEventWidget() string {
+	if !m.providersReady {
+		return ""
+	}
+	p, ok := m.paneInstances[panes.PaneCalendar]
+	if !ok {
+		return ""
+	}
+	events, _ := p.GetData().([]providers.CalendarEvent)
+
+	now := time.Now()
+	var next *providers.CalendarEvent
+	for i, e := range events {
+		if e.AllDay || !e.StartTime.After(now) {
+			continue
+		}
+		if next == nil || e.StartTime.Before(next.StartTime) {
+			next = &events[i]
+		}
+	}
+	if next == nil {
+		return ""
+	}
+
+	title := next.Title
+	if r := []rune(title); len(r) > 24 {
+		title = string(r[:23]) + "…"
+	}
+	start := next.StartTime.In(m.cfg.HomeLocation())
+	layout := "3:04 PM"
+	if start.Format("2006-01-02") != now.In(start.Location()).Format("2006-01-02") {
+		layout = "Mon 3:04 PM"
+	}
+	return m.styles.Muted.Render("Next: "+title+" ") + m.styles.StatusKey.Render(start.Format(layout))
+}
+
+// pomodoroWidget is the pomodoro timer, hidden while typing a command
+func (m *Model) pomodoroWidget() string {
+	if m.commandMode {
+		return ""
+	}
+	return m.renderPomodoro()
+}
+
+// aiCostWidget is what this session's AI calls have cost so far
+func (m *Model) aiCostWidget() string {
+	if m.aiCostTotal == 0 {
+		return ""
+	}
+	return m.styles.Muted.Render(fmt.Sprintf("AI $%.4f", m.aiCostTotal))
+}
+
+// themeWidget is the name of the active theme
+func (m *Model) themeWidget() string {
+	return m.styles.Muted.Render(m.styles.Palette.Name)
+}
+
+// indicatorsWidget gathers pane badges, the inbox count, the countdown,
+// server connection state and provider status
+func (m *Model) indicatorsWidget() string {
+	var parts []string
+	if badges := m.renderPaneBadges(); badges != "" {
+		parts = append(parts, badges)
+	}
+	if m.inboxCount > 0 {
+		parts = append(parts, m.styles.Warning.Render(fmt.Sprintf("📥 %d", m.inboxCount)))
+	}
+	if countdown := m.renderCountdown(); countdown != "" {
+		parts = append(parts, countdown)
+	}
+	if conn := m.renderConnectionState(); conn != "" {
+		parts = append(parts, conn)
+	}
+	if status := m.renderProviderStatus(); status != "" {
+		parts = append(parts, status)
+	}
+	return strings.Join(parts, "  ")
+}
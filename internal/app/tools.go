@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/mcp/transport"
+	"github.com/szoloth/partner/internal/panes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toolsModal is the state of the "?" modal listing the focused pane's
+// MCP tools
+type toolsModal struct {
+	server  string
+	tools   []mcp.Tool
+	loading bool
+	err     error
+	scroll  int // First wrapped line shown
+}
+
+// ToolsLoadedMsg carries the tool list requested by the tools modal
+type ToolsLoadedMsg struct {
+	Server string
+	Tools  []mcp.Tool
+	Err    error
+}
+
+// serverForPane returns the MCP server ID (as passed to toolClient) that
+// backs a pane; CoS and the log pane have none
+func serverForPane(pt panes.PaneType) (string, bool) {
+	switch pt {
+	case panes.PaneTasks, panes.PaneProjects:
+		return "things", true
+	case panes.PaneCalendar:
+		return "google-calendar", true
+	case panes.PaneKnowledge:
+		return "notion", true
+	}
+	return "", false
+}
+
+// openToolsModal opens the modal and fetches the tools of the focused
+// pane's server from its running client
+func (m *Model) openToolsModal() tea.Cmd {
+	if len(m.activePanes) == 0 || m.focusedPane >= len(m.activePanes) {
+		return nil
+	}
+	pt := m.activePanes[m.focusedPane].Type()
+	server, ok := serverForPane(pt)
+	if !ok {
+		return m.commandError(fmt.Sprintf("The %s pane has no MCP server", paneTitle(pt)))
+	}
+
+	m.clientsMu.Lock()
+	client := m.mcpClients[server]
+	m.clientsMu.Unlock()
+	if client == nil {
+		return m.commandError(server + " is not connected")
+	}
+
+	m.toolsModal = &toolsModal{server: server, loading: true}
+	return func() tea.Msg {
+		tools, err := client.ListTools(context.Background())
+		return ToolsLoadedMsg{Server: server, Tools: tools, Err: err}
+	}
+}
+
+// handleToolsLoaded fills the modal unless it was closed or reopened for
+// another server in the meantime
+func (m *Model) handleToolsLoaded(msg ToolsLoadedMsg) {
+	if m.toolsModal == nil || m.toolsModal.server != msg.Server {
+		return
+	}
+	m.toolsModal.loading = false
+	m.toolsModal.tools = msg.Tools
+	m.toolsModal.err = msg.Err
+}
+
+// handleToolsKey handles keys while the tools modal is open
+func (m *Model) handleToolsKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", m.keys.ListTools, m.keys.Quit:
+		m.toolsModal = nil
+	case "j", "down":
+		m.toolsModal.scroll++
+	case "k", "up":
+		if m.toolsModal.scroll > 0 {
+			m.toolsModal.scroll--
+		}
+	case "g":
+		m.toolsModal.scroll = 0
+	}
+	return nil
+}
+
+// ListServerTools starts the MCP server behind the initial pane and
+// returns its tools, for --list-tools
+func (m *Model) ListServerTools(ctx context.Context) ([]mcp.Tool, error) {
+	server, ok := serverForPane(m.initialPane)
+	if !ok {
+		return nil, fmt.Errorf("pane %s has no MCP server", m.initialPane)
+	}
+	return m.ProbeServer(ctx, server)
+}
+
+// ProbeServer starts one MCP server ("things", "google-calendar" or
+// "notion") with the configured transport and lists its tools, for
+// --list-tools and "partner init"
+func (m *Model) ProbeServer(ctx context.Context, server string) ([]mcp.Tool, error) {
+	var t mcp.Transport
+	var err error
+	switch server {
+	case "things":
+		t, err = m.newThingsTransport()
+	case "google-calendar":
+		t, err = m.newGCalTransport()
+	case "notion":
+		if m.cfg.NotionAPIKey == "" {
+			return nil, fmt.Errorf("notion_api_key is not set")
+		}
+		t, err = m.newNotionTransport()
+	default:
+		return nil, fmt.Errorf("unknown MCP server %q", server)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s transport: %w", server, err)
+	}
+
+	// Stdio servers are spawned here so a bad script is reported as a
+	// start failure rather than a failed call. Start doesn't take a
+	// context, so a server stuck in its handshake is killed at ctx's deadline.
+	if s, ok := t.(interface{ Start() error }); ok {
+		started := make(chan error, 1)
+		go func() { started <- s.Start() }()
+		select {
+		case err = <-started:
+		case <-ctx.Done():
+			err = fmt.Errorf("%s did not start: %w", server, ctx.Err())
+		}
+		if err != nil {
+			t.Close()
+			return nil, err
+		}
+	}
+	if m.mcpDebug != nil {
+		t = transport.NewDebugTransport(t, server, m.mcpDebug)
+	}
+
+	client := mcp.NewClient(t, server)
+	defer client.Close()
+	return client.ListTools(ctx)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/system"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SystemWokeMsg reports that the machine woke from sleep
+type SystemWokeMsg struct{}
+
+// ProvidersReinitializedMsg reports the result of reinitMCPProviders;
+// Errors is keyed by server ID
+type ProvidersReinitializedMsg struct {
+	Errors map[string]error
+}
+
+// startSleepWakeDetector begins watching for wakes in interactive mode
+func (m *Model) startSleepWakeDetector() tea.Cmd {
+	if m.headless || m.sleepWake != nil {
+		return nil
+	}
+	m.sleepWake = system.NewSleepWakeDetector(system.DefaultPollInterval)
+	return waitForWake(m.sleepWake.Woke())
+}
+
+// waitForWake delivers the next wake as a SystemWokeMsg
+func waitForWake(woke <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-woke
+		return SystemWokeMsg{}
+	}
+}
+
+// handleSystemWoke reconnects the MCP servers and keeps listening
+func (m *Model) handleSystemWoke() tea.Cmd {
+	next := waitForWake(m.sleepWake.Woke())
+	if !m.providersReady {
+		// initMCPProviders is still running and will start fresh servers
+		return next
+	}
+	return tea.Batch(next, m.reinitMCPProviders())
+}
+
+// reinitMCPProviders restarts every tracked MCP server. After a sleep the
+// stdio servers are usually dead while their transports still hold the old
+// pipes; Restart closes them and spawns and initializes a new process.
+func (m *Model) reinitMCPProviders() tea.Cmd {
+	m.clientsMu.Lock()
+	clients := make([]*mcp.Client, 0, len(m.mcpClients))
+	for _, c := range m.mcpClients {
+		clients = append(clients, c)
+	}
+	m.clientsMu.Unlock()
+
+	m.status = "Reconnecting..."
+	m.statusWarning = true
+
+	return func() tea.Msg {
+		errs := make(map[string]error)
+		for _, c := range clients {
+			if err := c.Restart(); err != nil {
+				errs[c.ServerID()] = err
+			}
+		}
+		return ProvidersReinitializedMsg{Errors: errs}
+	}
+}
+
+// handleProvidersReinitialized reports the outcome and reloads the active
+// panes from the new servers
+func (m *Model) handleProvidersReinitialized(msg ProvidersReinitializedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, pane := range m.activePanes {
+		cmds = append(cmds, pane.ForceRefresh())
+	}
+
+	if len(msg.Errors) == 0 {
+		m.status = "Reconnected"
+		m.statusWarning = false
+		return tea.Batch(append(cmds, clearStatusAfter(m.status, 2*time.Second))...)
+	}
+
+	servers := make([]string, 0, len(msg.Errors))
+	for server := range msg.Errors {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	parts := make([]string, len(servers))
+	for i, server := range servers {
+		parts[i] = fmt.Sprintf("%s: %v", server, msg.Errors[server])
+	}
+	return tea.Batch(append(cmds, m.commandError("Reconnect failed: "+strings.Join(parts, "; ")))...)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/szoloth/partner/internal/webhook"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WebhookFailedMsg reports an AI response the webhook didn't accept
+type WebhookFailedMsg struct {
+	Err error
+}
+
+// newWebhook builds the dispatcher for webhook_url, or nil when unset
+func (m *Model) newWebhook() *webhook.Dispatcher {
+	if m.cfg.WebhookURL == "" {
+		return nil
+	}
+	opts := []webhook.Option{webhook.WithTimeout(m.cfg.WebhookTimeout)}
+	if m.cfg.WebhookSecret != "" {
+		opts = append(opts, webhook.WithSecret(m.cfg.WebhookSecret))
+	}
+	if m.mcpDebug != nil {
+		opts = append(opts, webhook.WithLogger(m.mcpDebug))
+	}
+	return webhook.New(m.cfg.WebhookURL, opts...)
+}
+
+// postAIResponse sends the response just shown in the AI modal to the
+// webhook. Delivery failures only flash a warning.
+func (m *Model) postAIResponse(msg AIResponseMsg) tea.Cmd {
+	if m.webhook == nil {
+		return nil
+	}
+	p := webhook.Payload{
+		Response:  m.aiResponse,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if len(m.activePanes) > 0 && m.focusedPane < len(m.activePanes) {
+		p.Pane = m.activePanes[m.focusedPane].Type().String()
+	}
+	if msg.Action != nil {
+		p.Action = &webhook.Action{
+			Type:        msg.Action.Type.String(),
+			Description: msg.Action.Description,
+			Data:        msg.Action.Data,
+		}
+	}
+
+	d := m.webhook
+	return func() tea.Msg {
+		if err := d.Send(context.Background(), p); err != nil {
+			return WebhookFailedMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// handleWebhookFailed flashes the delivery error in the status bar
+func (m *Model) handleWebhookFailed(msg WebhookFailedMsg) tea.Cmd {
+	return m.commandError(fmt.Sprintf("Webhook failed: %v", msg.Err))
+}
@@ -0,0 +1,54 @@
+// Package bus lets panes react to each other's messages without the app
+// routing each message type by hand.
+package bus
+
+import (
+	"reflect"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Handler reacts to a dispatched message. Handlers run synchronously inside
+// the app's Update, so they must not block: any I/O belongs in the returned
+// command, which Bubble Tea runs in the background.
+type Handler func(tea.Msg) tea.Cmd
+
+// EventBus maps message types to their subscribers. Dispatch calls handlers
+// on the caller's goroutine, in subscription order.
+type EventBus struct {
+	mu       sync.Mutex
+	handlers map[reflect.Type][]Handler
+}
+
+// New creates an empty bus
+func New() *EventBus {
+	return &EventBus{handlers: make(map[reflect.Type][]Handler)}
+}
+
+// Subscribe registers h for messages whose dynamic type is msgType, e.g.
+// reflect.TypeOf(tasks.TaskCompletedMsg{})
+func (b *EventBus) Subscribe(msgType reflect.Type, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[msgType] = append(b.handlers[msgType], h)
+}
+
+// Dispatch passes msg to every handler subscribed to its type and batches
+// the commands they return
+func (b *EventBus) Dispatch(msg tea.Msg) tea.Cmd {
+	if msg == nil {
+		return nil
+	}
+
+	// Copy so handlers may subscribe without deadlocking
+	b.mu.Lock()
+	handlers := append([]Handler(nil), b.handlers[reflect.TypeOf(msg)]...)
+	b.mu.Unlock()
+
+	var cmds []tea.Cmd
+	for _, h := range handlers {
+		cmds = append(cmds, h(msg))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,132 @@
+// Package cache stores MCP tool results in SQLite so repeated reads within a
+// short window don't hit the live servers.
+package cache
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure Go driver, registered as "sqlite"
+)
+
+// DefaultPath is the standard location for the cache database
+const DefaultPath = "~/.cache/partner/cache.db"
+
+// SnapshotMaxAge is how long an offline snapshot is kept after it was
+// fetched. Keys include the call's arguments (e.g. a calendar's date
+// range), so without a limit the table grows by a few rows every day.
+const SnapshotMaxAge = 7 * 24 * time.Hour
+
+// Snapshots keep the last good answer for each key past its expiry, so the
+// app can show something while a server is unreachable
+const schema = `CREATE TABLE IF NOT EXISTS cache (
+	key TEXT PRIMARY KEY,
+	value BLOB,
+	expires_at INTEGER
+);
+CREATE TABLE IF NOT EXISTS snapshots (
+	key TEXT PRIMARY KEY,
+	value BLOB,
+	fetched_at INTEGER
+)`
+
+// Cache is a key/value store with per-entry expiry
+type Cache struct {
+	db  *sql.DB
+	now func() time.Time // Injectable clock for expiry
+}
+
+// Open opens (creating if needed) the cache database at path and evicts
+// expired entries and old snapshots
+func Open(path string) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+	// SQLite allows one writer; serialize rather than fail with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create cache tables: %w", err)
+	}
+
+	c := &Cache{db: db, now: time.Now}
+	if err := c.Evict(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Get returns the value stored under key if it hasn't expired
+func (c *Cache) Get(key string) (json.RawMessage, bool) {
+	var value []byte
+	err := c.db.QueryRow(
+		`SELECT value FROM cache WHERE key = ? AND expires_at > ?`,
+		key, c.now().UnixNano(),
+	).Scan(&value)
+	if err != nil {
+		return nil, false
+	}
+	return json.RawMessage(value), true
+}
+
+// Set stores value under key for ttl
+func (c *Cache) Set(key string, value json.RawMessage, ttl time.Duration) {
+	// A failed write only costs a future cache miss
+	_, _ = c.db.Exec(
+		`INSERT OR REPLACE INTO cache (key, value, expires_at) VALUES (?, ?, ?)`,
+		key, []byte(value), c.now().Add(ttl).UnixNano(),
+	)
+}
+
+// SetSnapshot records value as the last good answer for key
+func (c *Cache) SetSnapshot(key string, value json.RawMessage) {
+	_, _ = c.db.Exec(
+		`INSERT OR REPLACE INTO snapshots (key, value, fetched_at) VALUES (?, ?, ?)`,
+		key, []byte(value), c.now().UnixNano(),
+	)
+}
+
+// Snapshot returns the last good answer for key and when it was fetched,
+// however old it is
+func (c *Cache) Snapshot(key string) (json.RawMessage, time.Time, bool) {
+	var value []byte
+	var fetchedAt int64
+	err := c.db.QueryRow(`SELECT value, fetched_at FROM snapshots WHERE key = ?`, key).Scan(&value, &fetchedAt)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return json.RawMessage(value), time.Unix(0, fetchedAt), true
+}
+
+// InvalidatePrefix removes every entry whose key starts with prefix
+func (c *Cache) InvalidatePrefix(prefix string) {
+	_, _ = c.db.Exec(`DELETE FROM cache WHERE substr(key, 1, ?) = ?`, len(prefix), prefix)
+}
+
+// Evict removes expired entries and snapshots older than SnapshotMaxAge
+func (c *Cache) Evict() error {
+	now := c.now()
+	if _, err := c.db.Exec(`DELETE FROM cache WHERE expires_at <= ?`, now.UnixNano()); err != nil {
+		return fmt.Errorf("failed to evict cache entries: %w", err)
+	}
+	if _, err := c.db.Exec(`DELETE FROM snapshots WHERE fetched_at <= ?`, now.Add(-SnapshotMaxAge).UnixNano()); err != nil {
+		return fmt.Errorf("failed to evict snapshots: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openTest opens a cache in a temp dir whose clock reads *now
+func openTest(t *testing.T, now *time.Time) *Cache {
+	t.Helper()
+	c, err := Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestEvictSnapshots(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	c := openTest(t, &now)
+
+	c.SetSnapshot("things:get_today:{}", json.RawMessage(`"old"`))
+	now = now.Add(SnapshotMaxAge - time.Hour)
+	c.SetSnapshot("things:get_inbox:{}", json.RawMessage(`"recent"`))
+
+	now = now.Add(2 * time.Hour)
+	if err := c.Evict(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok := c.Snapshot("things:get_today:{}"); ok {
+		t.Error("snapshot older than SnapshotMaxAge survived Evict")
+	}
+	if raw, _, ok := c.Snapshot("things:get_inbox:{}"); !ok || string(raw) != `"recent"` {
+		t.Errorf("recent snapshot = %s, %v; want it kept", raw, ok)
+	}
+}
+
+func TestEvictExpired(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	c := openTest(t, &now)
+
+	c.Set("short", json.RawMessage(`1`), time.Minute)
+	c.Set("long", json.RawMessage(`2`), time.Hour)
+	now = now.Add(2 * time.Minute)
+	if err := c.Evict(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("expired entry survived Evict")
+	}
+	if _, ok := c.Get("long"); !ok {
+		t.Error("live entry was evicted")
+	}
+}
+
+func TestInvalidatePrefixKeepsSnapshots(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	c := openTest(t, &now)
+
+	for _, key := range []string{"things:get_today:{}", "google-calendar:list-events:{}"} {
+		c.Set(key, json.RawMessage(`1`), time.Hour)
+		c.SetSnapshot(key, json.RawMessage(`1`))
+	}
+	c.InvalidatePrefix("things:")
+
+	if _, ok := c.Get("things:get_today:{}"); ok {
+		t.Error("things entry survived InvalidatePrefix")
+	}
+	if _, ok := c.Get("google-calendar:list-events:{}"); !ok {
+		t.Error("other server's entry was invalidated")
+	}
+	if _, _, ok := c.Snapshot("things:get_today:{}"); !ok {
+		t.Error("InvalidatePrefix dropped the offline snapshot")
+	}
+}
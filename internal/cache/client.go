@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+)
+
+// CachedClient serves read-only tool calls from the cache and writes
+// through on a miss. Any other call invalidates the server's entries so
+// changes made in the app show up on the next read. When a read fails it
+// falls back to the last good answer and marks the context offline (see
+// mcp.WithOffline). Reads made with mcp.WithoutCache skip both.
+type CachedClient struct {
+	client *mcp.Client
+	cache  *Cache
+	ttl    time.Duration
+}
+
+// NewCachedClient wraps client, keeping results for ttl
+func NewCachedClient(client *mcp.Client, cache *Cache, ttl time.Duration) *CachedClient {
+	return &CachedClient{client: client, cache: cache, ttl: ttl}
+}
+
+// CallTool implements mcp.ToolCaller
+func (c *CachedClient) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*mcp.ToolResult, error) {
+	if !readOnlyTool(toolName) {
+		c.cache.InvalidatePrefix(c.client.ServerID() + ":")
+		return c.client.CallTool(ctx, toolName, args)
+	}
+
+	// encoding/json sorts map keys, so equal args give equal keys
+	jsonArgs, err := json.Marshal(args)
+	if err != nil {
+		return c.client.CallTool(ctx, toolName, args)
+	}
+	key := c.client.ServerID() + ":" + toolName + ":" + string(jsonArgs)
+
+	bypass := mcp.CacheBypassed(ctx)
+	if raw, ok := c.cache.Get(key); ok && !bypass {
+		var result mcp.ToolResult
+		if err := json.Unmarshal(raw, &result); err == nil {
+			return &result, nil
+		}
+	}
+
+	result, err := c.client.CallTool(ctx, toolName, args)
+	if err != nil {
+		if bypass {
+			return nil, err
+		}
+		return c.offlineResult(ctx, key, err)
+	}
+	if !result.IsError {
+		if raw, err := json.Marshal(result); err == nil {
+			c.cache.Set(key, raw, c.ttl)
+			c.cache.SetSnapshot(key, raw)
+		}
+	}
+	return result, nil
+}
+
+// offlineResult answers a failed read from its snapshot, returning callErr
+// when there is none
+func (c *CachedClient) offlineResult(ctx context.Context, key string, callErr error) (*mcp.ToolResult, error) {
+	raw, fetchedAt, ok := c.cache.Snapshot(key)
+	if !ok {
+		return nil, callErr
+	}
+	var result mcp.ToolResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, callErr
+	}
+	mcp.MarkOffline(ctx, fetchedAt)
+	return &result, nil
+}
+
+// Close closes the wrapped client; the cache is shared and left open
+func (c *CachedClient) Close() error {
+	return c.client.Close()
+}
+
+// readOnlyTool reports whether a tool only reads data, going by the naming
+// used by the Things and Google Calendar servers
+func readOnlyTool(name string) bool {
+	for _, prefix := range []string{"get_", "get-", "list_", "list-", "search_", "search-"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ mcp.ToolCaller = (*CachedClient)(nil)
@@ -0,0 +1,86 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cosstate "github.com/szoloth/partner/internal/cos"
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// briefingTaskLimit is how many of today's tasks the briefing prompt lists
+const briefingTaskLimit = 3
+
+// MorningBriefing asks for a short numbered briefing on the day ahead:
+// today's schedule, the top tasks in Things order, the outreach streak,
+// and whether a needle mover is queued
+func (c *Client) MorningBriefing(ctx context.Context, tasks []providers.Task, events []providers.CalendarEvent, state *cosstate.State) Response {
+	var b strings.Builder
+
+	b.WriteString("Today's schedule:\n")
+	if len(events) == 0 {
+		b.WriteString("- nothing scheduled\n")
+	}
+	for _, e := range events {
+		when := "all day"
+		if !e.AllDay {
+			when = e.StartTime.Format("15:04") + "-" + e.EndTime.Format("15:04")
+		}
+		line := "- " + when + " " + e.Title
+		if e.Location != "" {
+			line += " (" + e.Location + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\nTop tasks:\n")
+	if len(tasks) == 0 {
+		b.WriteString("- none in Today\n")
+	}
+	for i, t := range tasks {
+		if i == briefingTaskLimit {
+			break
+		}
+		line := "- " + t.Title
+		if t.ProjectTitle != "" {
+			line += " (" + t.ProjectTitle + ")"
+		}
+		if t.Deadline != nil {
+			line += ", due " + t.Deadline.Format("Mon Jan 2")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if state != nil {
+		o := state.Streaks.Outreach
+		fmt.Fprintf(&b, "\nOutreach: %d sent this week (target %d), %d weeks hitting target",
+			o.CurrentWeek, o.WeeklyTarget, o.WeeksHittingTarget)
+		if o.LastOutreachDate != "" {
+			b.WriteString(", last sent " + o.LastOutreachDate)
+		}
+		b.WriteString("\n")
+
+		// The queue is kept in priority order, needle mover first
+		if pending := state.ActionQueue.Pending; len(pending) > 0 {
+			a := pending[0]
+			line := a.Type
+			if a.Company != "" {
+				line += " " + a.Company
+			}
+			if a.Description != "" {
+				line += ": " + a.Description
+			}
+			b.WriteString("Needle mover: " + line + "\n")
+		} else {
+			b.WriteString("Needle mover: not set\n")
+		}
+	}
+
+	prompt := `Write my morning briefing from the data below as a numbered list of at most 5 points, under 200 words in total.
+Lead with the one thing that matters most today, fit the top tasks around the schedule, and flag a cold outreach streak or a missing needle mover.
+
+` + b.String()
+
+	return c.Ask(ctx, Request{Prompt: prompt})
+}
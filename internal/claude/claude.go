@@ -1,17 +1,34 @@
 package claude
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/szoloth/partner/internal/panes"
+)
+
+// Model names accepted by the CLI's --model flag
+const (
+	ModelOpus   = "claude-opus-4-5"
+	ModelSonnet = "claude-sonnet-4-5"
+	ModelHaiku  = "claude-haiku-4-5"
 )
 
+// Models lists the known model names, e.g. for shell completion
+var Models = []string{ModelOpus, ModelSonnet, ModelHaiku}
+
 // Client wraps the Claude CLI for AI assistance with session persistence
 type Client struct {
-	sessionID string // Persists context across calls
+	// Model is passed to the CLI as --model; empty uses the CLI's default
+	Model string
+
+	sessionID    string   // Persists context across calls
+	lastResponse Response // Final result of the last AskStream
 }
 
 // NewClient creates a new Claude CLI client
@@ -19,6 +36,11 @@ func NewClient() *Client {
 	return &Client{}
 }
 
+// NewClientWithModel creates a Claude CLI client that asks model
+func NewClientWithModel(model string) *Client {
+	return &Client{Model: model}
+}
+
 // Request represents a request to Claude
 type Request struct {
 	Prompt     string
@@ -26,6 +48,9 @@ type Request struct {
 	MaxTokens  int
 	AllowTools bool
 	NewSession bool // Force a new session (ignore existing session_id)
+
+	// Panes whose data is in Context, for display alongside usage stats
+	Panes []panes.PaneType
 }
 
 // Response represents Claude's response
@@ -62,8 +87,31 @@ const (
 	ActionCreateTask
 	ActionScheduleEvent
 	ActionSummarize
+	ActionFocusTask  // Data["title"] names the task to jump to
+	ActionSaveReview // Data["path"] is where the weekly review is saved
 )
 
+// String names the action type, e.g. for webhook payloads
+func (t ActionType) String() string {
+	switch t {
+	case ActionCompleteTask:
+		return "complete_task"
+	case ActionDraftEmail:
+		return "draft_email"
+	case ActionCreateTask:
+		return "create_task"
+	case ActionScheduleEvent:
+		return "schedule_event"
+	case ActionSummarize:
+		return "summarize"
+	case ActionFocusTask:
+		return "focus_task"
+	case ActionSaveReview:
+		return "save_review"
+	}
+	return "none"
+}
+
 // CLIResponse represents the JSON output from claude CLI
 type CLIResponse struct {
 	Type         string  `json:"type"`
@@ -100,6 +148,9 @@ func (c *Client) Ask(ctx context.Context, req Request) Response {
 
 	// Build args with JSON output for structured parsing
 	args := []string{"-p", fullPrompt, "--output-format", "json"}
+	if c.Model != "" {
+		args = append(args, "--model", c.Model)
+	}
 
 	// Use existing session for context persistence (unless new session requested)
 	if c.sessionID != "" && !req.NewSession {
@@ -155,6 +206,141 @@ func (c *Client) Ask(ctx context.Context, req Request) Response {
 	}
 }
 
+// streamLine is one line of `--output-format stream-json` output. Only the
+// fields needed for text deltas and the final result are decoded.
+type streamLine struct {
+	Type  string `json:"type"`
+	Event struct {
+		Type  string `json:"type"`
+		Delta struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"delta"`
+	} `json:"event"`
+	Message struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"message"`
+}
+
+// AskStream sends a prompt like Ask but streams the reply, sending each
+// text chunk to out as it arrives. out is closed when the stream ends.
+// The assembled response is available from LastResponse afterwards.
+func (c *Client) AskStream(ctx context.Context, req Request, out chan<- string) error {
+	defer close(out)
+	c.lastResponse = Response{}
+
+	fullPrompt := req.Prompt
+	if req.Context != "" {
+		fullPrompt = fmt.Sprintf("Context:\n%s\n\nRequest:\n%s", req.Context, req.Prompt)
+	}
+
+	// stream-json requires --verbose in print mode; partial messages carry
+	// the incremental text deltas
+	args := []string{"-p", fullPrompt, "--output-format", "stream-json", "--verbose", "--include-partial-messages"}
+	if c.Model != "" {
+		args = append(args, "--model", c.Model)
+	}
+	if c.sessionID != "" && !req.NewSession {
+		args = append(args, "--session-id", c.sessionID)
+	}
+
+	cmd := exec.CommandContext(ctx, "claude", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("claude command failed: %w", err)
+	}
+
+	var text strings.Builder
+	var result *CLIResponse
+	streamed := false
+
+	// Cancelling ctx kills the process, which ends the scan below
+	send := func(chunk string) {
+		text.WriteString(chunk)
+		select {
+		case out <- chunk:
+		case <-ctx.Done():
+		}
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		var ev streamLine
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+
+		switch ev.Type {
+		case "stream_event":
+			if ev.Event.Type == "content_block_delta" && ev.Event.Delta.Text != "" {
+				streamed = true
+				send(ev.Event.Delta.Text)
+			}
+		case "assistant":
+			// CLIs without partial messages only emit whole messages
+			if !streamed {
+				for _, block := range ev.Message.Content {
+					if block.Type == "text" && block.Text != "" {
+						send(block.Text)
+					}
+				}
+			}
+		case "result":
+			var r CLIResponse
+			if err := json.Unmarshal(line, &r); err == nil {
+				result = &r
+			}
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("claude command failed: %w (stderr: %s)", err, stderr.String())
+	}
+	if result == nil {
+		return fmt.Errorf("claude stream ended without a result")
+	}
+	if result.IsError {
+		return fmt.Errorf("claude API error: %s", result.Result)
+	}
+
+	if result.SessionID != "" {
+		c.sessionID = result.SessionID
+	}
+
+	full := text.String()
+	if full == "" {
+		full = result.Result
+	}
+	c.lastResponse = Response{
+		Text:      full,
+		SessionID: result.SessionID,
+		Action:    c.parseAction(full),
+		Usage: &Usage{
+			InputTokens:  result.Usage.InputTokens,
+			OutputTokens: result.Usage.OutputTokens,
+			CostUSD:      result.TotalCostUSD,
+			DurationMs:   result.DurationMs,
+		},
+	}
+	return nil
+}
+
+// LastResponse returns the assembled result of the last AskStream
+func (c *Client) LastResponse() Response {
+	return c.lastResponse
+}
+
 // Continue sends a follow-up message in the existing session
 func (c *Client) Continue(ctx context.Context, prompt string) Response {
 	if c.sessionID == "" {
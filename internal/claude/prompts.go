@@ -0,0 +1,155 @@
+package claude
+
+import (
+	"math/rand/v2"
+
+	"github.com/szoloth/partner/internal/panes"
+)
+
+// timeSlot is the part of the day a prompt is tuned for
+type timeSlot int
+
+const (
+	slotDay     timeSlot = iota // Any hour without a slot of its own
+	slotMorning                 // Before 9 AM
+	slotMidday                  // 12-1 PM
+	slotEvening                 // 5 PM onwards
+	timeSlotCount
+)
+
+// slotForHour maps an hour (0-23) to its time slot
+func slotForHour(hour int) timeSlot {
+	switch {
+	case hour < 9:
+		return slotMorning
+	case hour == 12:
+		return slotMidday
+	case hour >= 17:
+		return slotEvening
+	}
+	return slotDay
+}
+
+// timePrompts holds the prompt variants for each pane and time slot; one
+// is picked at random so repeated asks don't read identically. Panes
+// without an entry use defaultPrompts.
+var timePrompts = map[panes.PaneType][timeSlotCount][]string{
+	panes.PaneTasks: {
+		slotDay: {
+			"Based on my tasks and CoS context, what's the single highest-leverage needle-mover I should focus on? Be brief (2-3 sentences). Prioritize job search actions if outreach is cold.",
+			"Looking at my open tasks, which one moves things forward the most if I do it in the next hour? Be brief (2-3 sentences).",
+			"Which of my tasks am I most likely avoiding, and is it the one that matters most? Be brief and direct.",
+		},
+		slotMorning: {
+			"It's early. Based on my tasks and CoS context, what are my top 1-3 priorities for this morning? Be brief. Put job search actions first if outreach is cold.",
+			"Before the day fills up: which single task should I finish this morning to make today a win? Be brief (2-3 sentences).",
+			"Plan my morning from these tasks: what do I start with, and what can wait until after lunch? Be brief.",
+		},
+		slotMidday: {
+			"It's midday. Based on what's still open, what's my plan for the afternoon? Be brief (2-3 sentences).",
+			"Half the day is gone. Which tasks still belong to today, and which should I move? Be brief.",
+			"What's the one task I should finish this afternoon so today isn't wasted? Be brief and direct.",
+		},
+		slotEvening: {
+			"It's the end of the day. What should I wrap up before stopping, and what gets moved to tomorrow? Be brief.",
+			"Looking at what's still open, what's one small thing I can close out tonight so tomorrow starts clean? Be brief (2-3 sentences).",
+			"Wrap-up time: which loose ends from today need a quick reply or a note before I stop? Be brief.",
+		},
+	},
+	panes.PaneCalendar: {
+		slotDay: {
+			"Looking at my schedule and CoS context, what should I be aware of? Any conflicts, prep needed, or avoidance patterns? Be brief.",
+			"Where are the open blocks in my schedule, and what deep work should go in them? Be brief.",
+			"Is there anything on my calendar I should decline, shorten or prepare for? Be brief (2-3 sentences).",
+		},
+		slotMorning: {
+			"Here's my day. Which meetings need prep this morning, and where's my best focus block? Be brief.",
+			"Before the first meeting: what should I know about today's schedule, and is anything double-booked? Be brief.",
+			"Plan my morning around today's calendar: what do I prepare, and what can I get done before the first event? Be brief.",
+		},
+		slotMidday: {
+			"It's midday. What does my afternoon look like, and do I need to prepare for anything? Be brief.",
+			"Looking at the rest of today's schedule, where can I fit focused work this afternoon? Be brief (2-3 sentences).",
+			"Any afternoon meetings I'm underprepared for, or should push? Be brief and direct.",
+		},
+		slotEvening: {
+			"The day is ending. Do any of today's meetings need a follow-up, and what should I prepare for tomorrow? Be brief.",
+			"Looking at tomorrow's schedule, what should I get ready tonight? Be brief (2-3 sentences).",
+			"Wrap up the day: which meetings produced action items I haven't captured yet? Be brief.",
+		},
+	},
+	panes.PaneCoS: {
+		slotDay: {
+			"You're my Chief of Staff. Based on my current state, what's the ONE thing I should do right now? If avoidance detected, call it out directly. Be brief but firm.",
+			"You're my Chief of Staff. Which queued action have I been putting off longest, and why should I do it now? Be brief but firm.",
+			"You're my Chief of Staff. Am I working on what matters, or on what's comfortable? Call it out and name one next action. Be brief.",
+		},
+		slotMorning: {
+			"You're my Chief of Staff. It's morning: what ONE action sets up today, given my streaks and queue? Be brief but firm.",
+			"You're my Chief of Staff. Before I open email, what's the first action I should take this morning? Be brief and direct.",
+			"You're my Chief of Staff. Pick my morning priorities from the action queue and call out anything I'm avoiding. Be brief.",
+		},
+		slotMidday: {
+			"You're my Chief of Staff. Half the day is done: am I on track, and what's the afternoon's ONE action? Be brief but firm.",
+			"You're my Chief of Staff. Review my morning against the queue and tell me what to do after lunch. Be brief.",
+			"You're my Chief of Staff. What's slipping today, and what do I do about it this afternoon? Be brief and direct.",
+		},
+		slotEvening: {
+			"You're my Chief of Staff. It's evening: what should I wrap up before stopping, and what goes first tomorrow? Be brief but firm.",
+			"You're my Chief of Staff. Did I keep my streaks alive today? If not, what's the smallest action that still counts? Be brief.",
+			"You're my Chief of Staff. Close the day: what did I avoid, and what's the first thing tomorrow morning? Be brief and direct.",
+		},
+	},
+}
+
+var defaultPrompts = [timeSlotCount][]string{
+	slotDay: {
+		"What's the most important thing I should focus on right now?",
+		"Given everything on screen, what's the next action that matters most? Be brief.",
+		"What am I overlooking right now? Be brief (2-3 sentences).",
+	},
+	slotMorning: {
+		"It's morning. What should my priorities be today? Be brief.",
+		"What's the first thing I should do this morning? Be brief and direct.",
+		"How should I structure this morning to get the most important work done? Be brief.",
+	},
+	slotMidday: {
+		"It's midday. What should my afternoon plan be? Be brief.",
+		"Am I on track for today? What should I focus on this afternoon? Be brief.",
+		"What should I get done before the end of the afternoon? Be brief (2-3 sentences).",
+	},
+	slotEvening: {
+		"It's the end of the day. What should I wrap up before stopping? Be brief.",
+		"What should I close out tonight, and what's first tomorrow? Be brief.",
+		"What's left unfinished today that I should note before I stop? Be brief.",
+	},
+}
+
+// meetingPrepPrompts are used on the calendar pane when a meeting starts
+// within the next half hour; the meeting details follow the prompt
+var meetingPrepPrompts = []string{
+	"I have a meeting starting soon. Help me prepare: what should I know, what should I ask, and what outcome should I aim for? Be brief.",
+	"Give me a quick prep brief for my next meeting: goals, likely topics, and one question to ask each attendee who matters. Be brief.",
+	"My next meeting is about to start. What's the one thing I need to walk out of it with, and how do I get it? Be brief (2-3 sentences).",
+}
+
+// BuildTimeAwarePrompt picks a prompt for paneType suited to the hour
+// (0-23): morning priorities before 9 AM, the afternoon plan between 12
+// and 1 PM, and wrapping up after 5 PM. context describes a meeting
+// starting within 30 minutes (title and attendees); when it is set on the
+// calendar pane the prompt is about preparing for that meeting instead.
+func BuildTimeAwarePrompt(paneType panes.PaneType, hour int, context string) string {
+	if paneType == panes.PaneCalendar && context != "" {
+		return pick(meetingPrepPrompts) + "\n\nMeeting:\n" + context
+	}
+
+	variants, ok := timePrompts[paneType]
+	if !ok {
+		variants = defaultPrompts
+	}
+	return pick(variants[slotForHour(hour)])
+}
+
+func pick(variants []string) string {
+	return variants[rand.IntN(len(variants))]
+}
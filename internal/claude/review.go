@@ -0,0 +1,91 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cosstate "github.com/szoloth/partner/internal/cos"
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// reviewPendingLimit caps how many queued actions the review prompt lists
+const reviewPendingLimit = 5
+
+// WeeklyReview asks for a Markdown review of the past seven days: the tasks
+// completed in that time, outreach and needle-mover streaks, and the actions
+// at the top of the CoS queue
+func (c *Client) WeeklyReview(ctx context.Context, tasks []providers.Task, state *cosstate.State) Response {
+	var b strings.Builder
+	weekAgo := time.Now().AddDate(0, 0, -7)
+
+	b.WriteString("Tasks completed in the last 7 days:\n")
+	completed := 0
+	for _, t := range tasks {
+		if t.CompletedAt == nil || t.CompletedAt.Before(weekAgo) {
+			continue
+		}
+		line := "- " + t.Title
+		if t.ProjectTitle != "" {
+			line += " (" + t.ProjectTitle + ")"
+		}
+		b.WriteString(line + " - " + t.CompletedAt.Format("Mon Jan 2") + "\n")
+		completed++
+	}
+	if completed == 0 {
+		b.WriteString("- none recorded\n")
+	}
+
+	if state != nil {
+		o := state.Streaks.Outreach
+		fmt.Fprintf(&b, "\nOutreach: %d sent this week (target %d), %d weeks hitting target",
+			o.CurrentWeek, o.WeeklyTarget, o.WeeksHittingTarget)
+		if o.LastOutreachDate != "" {
+			b.WriteString(", last sent " + o.LastOutreachDate)
+		}
+		b.WriteString("\n")
+
+		n := state.Streaks.NeedleMover
+		fmt.Fprintf(&b, "Needle-mover streak: %d days (longest %d)", n.Current, n.Longest)
+		if n.LastCompleted != "" {
+			b.WriteString(", last completed " + n.LastCompleted)
+		}
+		b.WriteString("\n")
+
+		// The queue is kept in priority order, needle mover first
+		pending := state.ActionQueue.Pending
+		if len(pending) > 0 {
+			b.WriteString("\nTop pending actions:\n")
+			for i, a := range pending {
+				if i == reviewPendingLimit {
+					fmt.Fprintf(&b, "- ...and %d more\n", len(pending)-i)
+					break
+				}
+				line := "- " + a.Type
+				if a.Company != "" {
+					line += " " + a.Company
+				}
+				if a.Contact != "" {
+					line += " (" + a.Contact + ")"
+				}
+				if a.Description != "" {
+					line += ": " + a.Description
+				}
+				fmt.Fprintf(&b, "%s, queued %s\n", line, a.CreatedAt.Format("Jan 2"))
+			}
+		}
+	}
+
+	prompt := `Write my weekly review in Markdown from the data below. Use exactly these sections:
+
+## Wins
+## Misses
+## Priorities for next week
+
+Be specific and brief: name tasks and companies, call out streaks that slipped, and list at most 3 priorities.
+
+` + b.String()
+
+	return c.Ask(ctx, Request{Prompt: prompt})
+}
@@ -0,0 +1,153 @@
+// Package completion generates shell completion scripts for the partner CLI
+package completion
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/claude"
+	"github.com/szoloth/partner/internal/theme"
+)
+
+// PaneNames are the values panes.ParsePaneType accepts
+var PaneNames = []string{"tasks", "calendar", "email", "knowledge", "crm", "projects", "cos", "log"}
+
+// Shells lists the shells Generate supports
+var Shells = []string{"bash", "zsh", "fish"}
+
+// flagInfo is a command-line flag as completion scripts need it
+type flagInfo struct {
+	name   string
+	usage  string
+	values []string // Fixed values, if any
+	arg    bool     // Takes a value
+}
+
+// Generate returns a completion script for shell covering the flags
+// registered on flag.CommandLine
+func Generate(shell string) (string, error) {
+	flags := commandLineFlags()
+	switch shell {
+	case "bash":
+		return bash(flags), nil
+	case "zsh":
+		return zsh(flags), nil
+	case "fish":
+		return fish(flags), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (want %s)", shell, strings.Join(Shells, ", "))
+	}
+}
+
+func commandLineFlags() []flagInfo {
+	var flags []flagInfo
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		info := flagInfo{name: f.Name, usage: f.Usage, arg: !isBool(f)}
+		switch f.Name {
+		case "pane":
+			info.values = PaneNames
+		case "theme":
+			info.values = theme.Names()
+		case "completion":
+			info.values = Shells
+		case "claude-model":
+			info.values = claude.Models
+		}
+		flags = append(flags, info)
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+	return flags
+}
+
+// isBool reports whether f is a boolean flag that takes no argument
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func bash(flags []flagInfo) string {
+	var b strings.Builder
+	var names []string
+	for _, f := range flags {
+		names = append(names, "--"+f.name)
+	}
+
+	b.WriteString("# bash completion for partner\n")
+	b.WriteString("_partner() {\n")
+	b.WriteString("    local cur prev\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+	b.WriteString("    case \"$prev\" in\n")
+	for _, f := range flags {
+		if len(f.values) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "        --%s|-%s)\n", f.name, f.name)
+		fmt.Fprintf(&b, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(f.values, " "))
+		b.WriteString("            return\n")
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("        --config|-config)\n")
+	b.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	b.WriteString("            return\n")
+	b.WriteString("            ;;\n")
+	b.WriteString("    esac\n\n")
+	fmt.Fprintf(&b, "    COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(names, " "))
+	b.WriteString("}\n")
+	b.WriteString("complete -F _partner partner\n")
+	return b.String()
+}
+
+func zsh(flags []flagInfo) string {
+	var b strings.Builder
+	b.WriteString("#compdef partner\n\n")
+	b.WriteString("_partner() {\n")
+	b.WriteString("    _arguments \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("--%s[%s]", f.name, zshEscape(f.usage))
+		switch {
+		case len(f.values) > 0:
+			spec += fmt.Sprintf(":%s:(%s)", f.name, strings.Join(f.values, " "))
+		case f.name == "config":
+			spec += ":file:_files"
+		case f.arg:
+			spec += ":" + f.name + ": "
+		}
+		fmt.Fprintf(&b, "        '%s' \\\n", spec)
+	}
+	b.WriteString("        && return 0\n")
+	b.WriteString("}\n\n")
+	b.WriteString("compdef _partner partner\n")
+	return b.String()
+}
+
+// zshEscape makes a flag description safe inside a single-quoted
+// _arguments spec
+func zshEscape(s string) string {
+	r := strings.NewReplacer("'", "'\\''", "[", "\\[", "]", "\\]", ":", "\\:")
+	return r.Replace(s)
+}
+
+func fish(flags []flagInfo) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for partner\n")
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c partner -l %s -d %s", f.name, fishQuote(f.usage))
+		switch {
+		case len(f.values) > 0:
+			line += fmt.Sprintf(" -x -a %s", fishQuote(strings.Join(f.values, " ")))
+		case f.name == "config":
+			line += " -r -F"
+		case f.arg:
+			line += " -x"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
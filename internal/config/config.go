@@ -0,0 +1,440 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultPath is the standard location for the config file
+const DefaultPath = "~/.config/partner/config.toml"
+
+// Config holds user settings loaded from config.toml
+type Config struct {
+	ThingsMCPScript     string
+	GCalCredentialsPath string
+	InitialPane         string
+	InitialLayout       string // single, hsplit, vsplit, grid
+	Theme               string
+	AITimeoutSeconds    int
+	NotifyBeforeMinutes int    // Desktop notification lead time for events
+	MinRefreshSeconds   int    // Refreshes closer together than this are skipped
+	Goals               string // Fed to the AI when picking a needle mover
+	ClaudeModel         string // Passed to the Claude CLI as --model; empty uses its default
+
+	// MCP servers running as HTTP daemons; when a URL is set it is used
+	// instead of spawning the stdio server
+	ThingsMCPURL   string
+	ThingsMCPToken string
+	GCalMCPURL     string
+	GCalMCPToken   string
+
+	// GCalCalendarIDs lists the Google calendars to show; empty means the
+	// primary calendar only
+	GCalCalendarIDs []string
+
+	// CalendarProviders lists the calendar sources merged in the Calendar
+	// pane: "gcal" (Google Calendar) and/or "apple" (Apple Calendar)
+	CalendarProviders []string
+
+	// UserEmail identifies you among event attendees for RSVPs
+	UserEmail string
+
+	// HomeTimezone is the IANA zone event times are shown in, e.g.
+	// "America/Los_Angeles"; empty means the system zone
+	HomeTimezone string
+
+	// NotionAPIKey enables the Knowledge pane via the Notion MCP server
+	NotionAPIKey string
+
+	// ObsidianVaultPath is a local Obsidian vault the Knowledge pane lists
+	// when NotionAPIKey is unset; ~ is expanded
+	ObsidianVaultPath string
+
+	// WebhookURL receives every AI response as a JSON POST; WebhookSecret
+	// signs the body and WebhookTimeout bounds each request
+	WebhookURL     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+
+	// Keybindings holds the global key for each app action
+	Keybindings Keybindings
+
+	// StatusBar picks the status bar widgets, from the [status_bar] table
+	StatusBar StatusBarConfig
+
+	// Paths records where each file setting came from (see resolvePaths)
+	Paths []ResolvedPath
+}
+
+// Keybindings maps global actions to key strings as reported by
+// tea.KeyMsg.String(). MaximizePane may be a space-separated sequence.
+type Keybindings struct {
+	Quit          string
+	FocusNext     string
+	FocusPrev     string
+	FocusLeft     string // Directional focus in split and grid layouts
+	FocusRight    string
+	FocusUp       string
+	FocusDown     string
+	SwitchPane0   string
+	SwitchPane1   string
+	SwitchPane2   string
+	SwitchPane3   string
+	SwitchPane4   string
+	SwitchPane5   string
+	SwitchPane6   string
+	SwitchPane7   string
+	ToggleSplit   string
+	ShrinkSplit   string
+	GrowSplit     string
+	MaximizePane  string
+	CycleTheme    string
+	Search        string
+	Palette       string
+	Export        string // Write the focused pane to ~/Desktop as Markdown
+	CommandMode   string
+	AIAssist      string
+	Refresh       string
+	Pomodoro      string // Start/pause the pomodoro timer
+	PomodoroReset string
+	RecordMacro   string // Q<letter> starts recording, Q again stops
+	PlayMacro     string // @<letter> replays, @@ repeats the last
+	ListTools     string // Modal of the focused pane's MCP tools
+}
+
+// DefaultKeybindings returns the built-in key map
+func DefaultKeybindings() Keybindings {
+	return Keybindings{
+		Quit:          "q",
+		FocusNext:     "tab",
+		FocusPrev:     "shift+tab",
+		FocusLeft:     "ctrl+h",
+		FocusRight:    "ctrl+l",
+		FocusUp:       "ctrl+k",
+		FocusDown:     "ctrl+j",
+		SwitchPane0:   "0",
+		SwitchPane1:   "1",
+		SwitchPane2:   "2",
+		SwitchPane3:   "3",
+		SwitchPane4:   "4",
+		SwitchPane5:   "5",
+		SwitchPane6:   "6",
+		SwitchPane7:   "7",
+		ToggleSplit:   "\\",
+		ShrinkSplit:   "(",
+		GrowSplit:     ")",
+		MaximizePane:  "ctrl+w o",
+		CycleTheme:    "ctrl+t",
+		Search:        "/",
+		Palette:       "ctrl+p",
+		Export:        "E",
+		CommandMode:   ":",
+		AIAssist:      "a",
+		Refresh:       "r",
+		Pomodoro:      "p",
+		PomodoroReset: "P",
+		RecordMacro:   "Q",
+		PlayMacro:     "@",
+		ListTools:     "?",
+	}
+}
+
+// set assigns key to the field named by action. Names match the field
+// name case-insensitively, ignoring underscores, so both "focus_next" and
+// "FocusNext" work.
+func (k *Keybindings) set(action, key string) error {
+	want := strings.ReplaceAll(strings.ToLower(action), "_", "")
+	v := reflect.ValueOf(k).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if strings.ToLower(v.Type().Field(i).Name) == want {
+			v.Field(i).SetString(key)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %q", action)
+}
+
+// StatusBarWidgets lists the widget names accepted in status_bar.widgets
+var StatusBarWidgets = []string{
+	"title", "clock", "status", "next_event", "pomodoro", "ai_cost", "theme", "indicators",
+}
+
+// StatusBarConfig is the status bar layout. Widgets before "status" are
+// left-aligned, "status" is centered and the rest are right-aligned; the
+// widgets in each group are joined with Separator.
+type StatusBarConfig struct {
+	Widgets   []string
+	Separator string
+}
+
+// DefaultStatusBar is the built-in layout: title, the status line (with
+// the pomodoro timer), then pane badges and connection state, and the clock
+func DefaultStatusBar() StatusBarConfig {
+	return StatusBarConfig{
+		Widgets:   []string{"title", "status", "indicators", "clock"},
+		Separator: "  ",
+	}
+}
+
+// Default returns the built-in settings used when no config file exists
+func Default() *Config {
+	return &Config{
+		ThingsMCPScript:     ExpandPath(DefaultThingsMCPScript),
+		GCalCredentialsPath: ExpandPath(DefaultGCalCredentialsPath),
+		InitialPane:         "tasks",
+		InitialLayout:       "single",
+		Theme:               "teenage_engineering",
+		AITimeoutSeconds:    30,
+		NotifyBeforeMinutes: 5,
+		MinRefreshSeconds:   10,
+		WebhookTimeout:      5 * time.Second,
+		Keybindings:         DefaultKeybindings(),
+		StatusBar:           DefaultStatusBar(),
+		CalendarProviders:   []string{"gcal"},
+	}
+}
+
+// Load reads the config file at path (DefaultPath if empty). A missing file
+// yields the defaults; keys absent from the file keep their default values.
+// PARTNER_THINGS_MCP_SCRIPT and PARTNER_GCAL_CREDENTIALS take precedence
+// over the file.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	path = ExpandPath(path)
+
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			cfg.resolvePaths(nil)
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	doc, err := decodeTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if err := cfg.apply(doc); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	cfg.resolvePaths(doc)
+
+	return cfg, nil
+}
+
+// UsesCalendar reports whether the named calendar provider is enabled
+func (c *Config) UsesCalendar(name string) bool {
+	return slices.Contains(c.CalendarProviders, name)
+}
+
+// HomeLocation is the zone named by HomeTimezone, or the system zone
+func (c *Config) HomeLocation() *time.Location {
+	if loc, err := time.LoadLocation(c.HomeTimezone); c.HomeTimezone != "" && err == nil {
+		return loc
+	}
+	return time.Local
+}
+
+// apply overlays values present in the decoded document
+func (c *Config) apply(doc map[string]interface{}) error {
+	var err error
+	setString := func(key string, dst *string) {
+		if err != nil {
+			return
+		}
+		err = getString(doc, key, dst)
+	}
+
+	setString("things_mcp_script", &c.ThingsMCPScript)
+	setString("gcal_credentials_path", &c.GCalCredentialsPath)
+	setString("initial_pane", &c.InitialPane)
+	setString("initial_layout", &c.InitialLayout)
+	setString("theme", &c.Theme)
+	setString("goals", &c.Goals)
+	setString("user_email", &c.UserEmail)
+	setString("claude_model", &c.ClaudeModel)
+	setString("home_timezone", &c.HomeTimezone)
+	setString("notion_api_key", &c.NotionAPIKey)
+	setString("obsidian_vault_path", &c.ObsidianVaultPath)
+	setString("webhook_url", &c.WebhookURL)
+	setString("webhook_secret", &c.WebhookSecret)
+	setString("things_mcp_url", &c.ThingsMCPURL)
+	setString("things_mcp_token", &c.ThingsMCPToken)
+	setString("gcal_mcp_url", &c.GCalMCPURL)
+	setString("gcal_mcp_token", &c.GCalMCPToken)
+	if err != nil {
+		return err
+	}
+
+	if c.ObsidianVaultPath != "" {
+		c.ObsidianVaultPath = ExpandPath(c.ObsidianVaultPath)
+	}
+	if c.HomeTimezone != "" {
+		if _, err := time.LoadLocation(c.HomeTimezone); err != nil {
+			return fmt.Errorf("home_timezone: %w", err)
+		}
+	}
+
+	if err := getInt(doc, "ai_timeout_seconds", &c.AITimeoutSeconds); err != nil {
+		return err
+	}
+	if err := getInt(doc, "notify_before_minutes", &c.NotifyBeforeMinutes); err != nil {
+		return err
+	}
+	if err := getInt(doc, "min_refresh_seconds", &c.MinRefreshSeconds); err != nil {
+		return err
+	}
+	if c.AITimeoutSeconds <= 0 {
+		return fmt.Errorf("ai_timeout_seconds must be positive, got %d", c.AITimeoutSeconds)
+	}
+	if c.NotifyBeforeMinutes <= 0 {
+		return fmt.Errorf("notify_before_minutes must be positive, got %d", c.NotifyBeforeMinutes)
+	}
+
+	if err := getDuration(doc, "webhook_timeout", &c.WebhookTimeout); err != nil {
+		return err
+	}
+
+	if err := getStringSlice(doc, "gcal_calendar_ids", &c.GCalCalendarIDs); err != nil {
+		return err
+	}
+	if err := getStringSlice(doc, "calendar_providers", &c.CalendarProviders); err != nil {
+		return err
+	}
+	for _, name := range c.CalendarProviders {
+		if name != "gcal" && name != "apple" {
+			return fmt.Errorf("calendar_providers: unknown provider %q (want gcal or apple)", name)
+		}
+	}
+
+	if raw, ok := doc["keybindings"]; ok {
+		table, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("keybindings must be a table")
+		}
+		for action := range table {
+			var key string
+			if err := getString(table, action, &key); err != nil {
+				return fmt.Errorf("keybindings: %w", err)
+			}
+			if key == "" {
+				continue
+			}
+			if err := c.Keybindings.set(action, key); err != nil {
+				return fmt.Errorf("keybindings: %w", err)
+			}
+		}
+	}
+
+	if raw, ok := doc["status_bar"]; ok {
+		table, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("status_bar must be a table")
+		}
+		if err := getStringSlice(table, "widgets", &c.StatusBar.Widgets); err != nil {
+			return fmt.Errorf("status_bar: %w", err)
+		}
+		if err := getString(table, "separator", &c.StatusBar.Separator); err != nil {
+			return fmt.Errorf("status_bar: %w", err)
+		}
+		for _, w := range c.StatusBar.Widgets {
+			if !slices.Contains(StatusBarWidgets, w) {
+				return fmt.Errorf("status_bar: unknown widget %q", w)
+			}
+		}
+	}
+
+	return nil
+}
+
+func getString(doc map[string]interface{}, key string, dst *string) error {
+	raw, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string", key)
+	}
+	*dst = s
+	return nil
+}
+
+func getStringSlice(doc map[string]interface{}, key string, dst *[]string) error {
+	raw, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an array of strings", key)
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("%s must be an array of strings", key)
+		}
+		out = append(out, s)
+	}
+	*dst = out
+	return nil
+}
+
+func getInt(doc map[string]interface{}, key string, dst *int) error {
+	raw, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	n, ok := raw.(int64)
+	if !ok {
+		return fmt.Errorf("%s must be an integer", key)
+	}
+	*dst = int(n)
+	return nil
+}
+
+// getDuration accepts a Go duration string ("5s") or a number of seconds
+func getDuration(doc map[string]interface{}, key string, dst *time.Duration) error {
+	raw, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	switch v := raw.(type) {
+	case int64:
+		*dst = time.Duration(v) * time.Second
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		*dst = d
+	default:
+		return fmt.Errorf("%s must be a duration such as \"5s\"", key)
+	}
+	return nil
+}
+
+// ExpandPath expands ~ to the home directory
+func ExpandPath(path string) string {
+	if len(path) > 0 && path[0] == '~' {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		return filepath.Join(home, path[1:])
+	}
+	return path
+}
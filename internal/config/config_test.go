@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadString writes contents to a temp config file and loads it
+func loadString(t *testing.T, contents string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(path)
+}
+
+func TestLoadRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"defaults", "", ""},
+		{"positive", "ai_timeout_seconds = 60\nnotify_before_minutes = 10", ""},
+		{"zero ai timeout", "ai_timeout_seconds = 0", "ai_timeout_seconds must be positive"},
+		{"negative ai timeout", "ai_timeout_seconds = -5", "ai_timeout_seconds must be positive"},
+		{"zero notify", "notify_before_minutes = 0", "notify_before_minutes must be positive"},
+		{"negative notify", "notify_before_minutes = -1", "notify_before_minutes must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadString(t, tt.data)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Load() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import "os"
+
+// Environment variables that override the file settings in config.toml
+const (
+	EnvThingsMCPScript = "PARTNER_THINGS_MCP_SCRIPT"
+	EnvGCalCredentials = "PARTNER_GCAL_CREDENTIALS"
+)
+
+// Fallbacks used when neither the environment nor the config file sets a path
+const (
+	DefaultThingsMCPScript     = "~/.config/partner/things-mcp.sh"
+	DefaultGCalCredentialsPath = "~/.config/partner/credentials.json"
+)
+
+// ResolvedPath is a file setting after the environment, config file and
+// default lookup, as reported by --validate-config
+type ResolvedPath struct {
+	Key    string // Config file key, e.g. things_mcp_script
+	Path   string
+	Source string // "env PARTNER_...", "config" or "default"
+}
+
+// pathSetting ties a file setting to its config key and environment variable
+type pathSetting struct {
+	key string
+	env string
+	dst func(*Config) *string
+}
+
+var pathSettings = []pathSetting{
+	{"things_mcp_script", EnvThingsMCPScript, func(c *Config) *string { return &c.ThingsMCPScript }},
+	{"gcal_credentials_path", EnvGCalCredentials, func(c *Config) *string { return &c.GCalCredentialsPath }},
+}
+
+// resolvePaths applies the environment overrides on top of the config file
+// values in doc (nil when there is no file) and expands ~ in every path
+func (c *Config) resolvePaths(doc map[string]interface{}) {
+	c.Paths = c.Paths[:0]
+	for _, s := range pathSettings {
+		dst := s.dst(c)
+		source := "default"
+		if _, ok := doc[s.key]; ok {
+			source = "config"
+		}
+		if v := os.Getenv(s.env); v != "" {
+			*dst = v
+			source = "env " + s.env
+		}
+		*dst = ExpandPath(*dst)
+		c.Paths = append(c.Paths, ResolvedPath{Key: s.key, Path: *dst, Source: source})
+	}
+}
@@ -0,0 +1,280 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeTOML parses the subset of TOML used by partner's config file:
+// [table] headers (dotted names allowed), key = value pairs with string,
+// integer, float, boolean and array values, and # comments. Tables are
+// returned as nested map[string]interface{} values.
+func decodeTOML(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+
+		// Table header
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: unsupported table header %q", lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			table, err := lookupTable(root, name)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			current = table
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key, err := parseKey(strings.TrimSpace(line[:eq]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		raw := strings.TrimSpace(line[eq+1:])
+
+		// Arrays may span several lines
+		for !bracketsBalanced(raw) && i+1 < len(lines) {
+			i++
+			raw += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+
+		p := &valueParser{s: raw}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		p.skipSpace()
+		if p.pos != len(p.s) {
+			return nil, fmt.Errorf("line %d: unexpected %q after value", lineNo, p.s[p.pos:])
+		}
+
+		if _, exists := current[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+		current[key] = value
+	}
+
+	return root, nil
+}
+
+// lookupTable returns (creating as needed) the table for a dotted name
+func lookupTable(root map[string]interface{}, name string) (map[string]interface{}, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty table name")
+	}
+
+	table := root
+	for _, part := range strings.Split(name, ".") {
+		part, err := parseKey(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		next, ok := table[part]
+		if !ok {
+			created := make(map[string]interface{})
+			table[part] = created
+			table = created
+			continue
+		}
+		sub, ok := next.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%q is not a table", part)
+		}
+		table = sub
+	}
+	return table, nil
+}
+
+// parseKey accepts bare keys and quoted keys
+func parseKey(key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("empty key")
+	}
+	if strings.HasPrefix(key, `"`) || strings.HasPrefix(key, "'") {
+		p := &valueParser{s: key}
+		v, err := p.parseString()
+		if err != nil {
+			return "", err
+		}
+		return v, nil
+	}
+	for _, r := range key {
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "", fmt.Errorf("invalid key %q", key)
+		}
+	}
+	return key, nil
+}
+
+// stripComment removes a trailing # comment that isn't inside a string
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// bracketsBalanced reports whether every '[' outside strings has been closed
+func bracketsBalanced(s string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// valueParser reads a single TOML value from a string
+type valueParser struct {
+	s   string
+	pos int
+}
+
+func (p *valueParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *valueParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '"' || c == '\'':
+		return p.parseString()
+	case c == '[':
+		return p.parseArray()
+	default:
+		return p.parseScalar()
+	}
+}
+
+func (p *valueParser) parseString() (string, error) {
+	quote := p.s[p.pos]
+	start := p.pos
+	p.pos++
+
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == '\\' && quote == '"' {
+			p.pos += 2
+			continue
+		}
+		if c == quote {
+			p.pos++
+			raw := p.s[start:p.pos]
+			if quote == '\'' {
+				return raw[1 : len(raw)-1], nil
+			}
+			v, err := strconv.Unquote(raw)
+			if err != nil {
+				return "", fmt.Errorf("invalid string %s", raw)
+			}
+			return v, nil
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *valueParser) parseArray() ([]interface{}, error) {
+	p.pos++ // '['
+	items := []interface{}{}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		if p.s[p.pos] == ']' {
+			p.pos++
+			return items, nil
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected , or ] in array, found %q", p.s[p.pos:])
+		}
+	}
+}
+
+func (p *valueParser) parseScalar() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(" \t,]", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	token := p.s[start:p.pos]
+
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+
+	clean := strings.ReplaceAll(token, "_", "")
+	if n, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid value %q", token)
+}
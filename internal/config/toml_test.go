@@ -0,0 +1,109 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]interface{}
+	}{
+		{"empty", "", map[string]interface{}{}},
+		{"basic string", `theme = "nord"`, map[string]interface{}{"theme": "nord"}},
+		{"literal string", `path = 'C:\notes'`, map[string]interface{}{"path": `C:\notes`}},
+		{
+			"escapes",
+			`s = "tab\there \"quoted\" back\\slash \u00e9"`,
+			map[string]interface{}{"s": "tab\there \"quoted\" back\\slash é"},
+		},
+		{"hash in string", `url = "http://x/#frag" # comment`, map[string]interface{}{"url": "http://x/#frag"}},
+		{"quoted key", `"my key" = 1`, map[string]interface{}{"my key": int64(1)}},
+		{
+			"scalars",
+			"n = 1_000\nf = 1.5\nneg = -3\nyes = true\nno = false",
+			map[string]interface{}{"n": int64(1000), "f": 1.5, "neg": int64(-3), "yes": true, "no": false},
+		},
+		{
+			"comments",
+			"# leading comment\n\nkey = 1 # trailing\n   # indented",
+			map[string]interface{}{"key": int64(1)},
+		},
+		{"array", `ids = ["a", "b"]`, map[string]interface{}{"ids": []interface{}{"a", "b"}}},
+		{"empty array", `ids = []`, map[string]interface{}{"ids": []interface{}{}}},
+		{"trailing comma", `ids = [1, 2,]`, map[string]interface{}{"ids": []interface{}{int64(1), int64(2)}}},
+		{
+			"nested array",
+			`grid = [[1, 2], ["x"]]`,
+			map[string]interface{}{"grid": []interface{}{
+				[]interface{}{int64(1), int64(2)},
+				[]interface{}{"x"},
+			}},
+		},
+		{
+			"multi-line array",
+			"widgets = [\n  \"clock\", # the time\n  \"pomodoro\",\n]\nafter = true",
+			map[string]interface{}{"widgets": []interface{}{"clock", "pomodoro"}, "after": true},
+		},
+		{
+			"tables",
+			"top = 1\n[keybindings]\nquit = \"q\"\n[a.b]\nc = 2",
+			map[string]interface{}{
+				"top":         int64(1),
+				"keybindings": map[string]interface{}{"quit": "q"},
+				"a":           map[string]interface{}{"b": map[string]interface{}{"c": int64(2)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTOML([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("decodeTOML() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeTOML() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"missing value", "key =", "line 1: missing value"},
+		{"no equals", "key", "line 1: expected key = value"},
+		{"invalid key", "bad key = 1", "invalid key"},
+		{"unterminated string", `s = "open`, "unterminated string"},
+		{"bad escape", `s = "\q"`, "invalid string"},
+		{"unterminated array", "ids = [1, 2", "unterminated array"},
+		{"missing comma", "ids = [1 2]", "expected , or ]"},
+		{"missing comma between strings", `ids = ["a" "b"]`, "expected , or ]"},
+		{"double comma", "ids = [1,, 2]", "invalid value"},
+		{"trailing junk", `s = "a" b`, "unexpected"},
+		{"bad scalar", "n = maybe", `invalid value "maybe"`},
+		{"duplicate key", "a = 1\na = 2", `line 2: duplicate key "a"`},
+		{"array of tables", "[[items]]", "unsupported table header"},
+		{"empty table name", "[]", "empty table name"},
+		{"key then table", "a = 1\n[a]", `"a" is not a table`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeTOML([]byte(tt.data))
+			if err == nil {
+				t.Fatalf("decodeTOML(%q) succeeded, want an error containing %q", tt.data, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("decodeTOML(%q) error = %q, want it to contain %q", tt.data, err, tt.want)
+			}
+		})
+	}
+}
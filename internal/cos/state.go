@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // DefaultStatePath is the standard location for CoS state
 const DefaultStatePath = "~/.claude/state/cos-state.json"
 
+// CurrentVersion is the state schema version written by this build
+const CurrentVersion = "1.1"
+
 // State represents the Chief of Staff system state
 type State struct {
 	Version     string    `json:"version"`
@@ -23,6 +28,10 @@ type State struct {
 	ActionQueue       ActionQueue       `json:"action_queue"`
 	PreparedMaterials PreparedMaterials `json:"prepared_materials"`
 	Thresholds        Thresholds        `json:"thresholds"`
+
+	// Streaks broken since the file was last saved, set by
+	// CheckAndResetStreaks for display; not persisted
+	StreakResets []string `json:"-"`
 }
 
 // Briefings tracks when each briefing type was last run
@@ -60,6 +69,7 @@ type OutreachStreak struct {
 	WeekStart          string `json:"week_start"` // YYYY-MM-DD
 	WeeklyTarget       int    `json:"weekly_target"`
 	WeeksHittingTarget int    `json:"weeks_hitting_target"`
+	LastOutreachDate   string `json:"last_outreach_date,omitempty"` // YYYY-MM-DD
 }
 
 // TrainingStreak tracks training activity
@@ -122,9 +132,60 @@ type Thresholds struct {
 	AvoidancePlanningDays int `json:"avoidance_planning_days"`
 }
 
+// dateLayout is the YYYY-MM-DD format used for state dates
+const dateLayout = "2006-01-02"
+
+// ValidationError reports the state field that broke a Validate rule
+type ValidationError struct {
+	Field  string // JSON path of the field, e.g. "thresholds.outreach_cold_days"
+	Reason string // What is wrong with it
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid state: %s %s", e.Field, e.Reason)
+}
+
+// Validate checks the state for values that should never be persisted.
+// The error is a *ValidationError naming the first field that fails.
+func (s *State) Validate() error {
+	if s.Thresholds.OutreachColdDays < 1 {
+		return &ValidationError{"thresholds.outreach_cold_days", fmt.Sprintf("must be >= 1, got %d", s.Thresholds.OutreachColdDays)}
+	}
+
+	if last := s.Streaks.NeedleMover.LastCompleted; last != "" {
+		if _, err := time.Parse(dateLayout, last); err != nil {
+			return &ValidationError{"streaks.needle_mover.last_completed", fmt.Sprintf("%q is not YYYY-MM-DD", last)}
+		}
+	}
+
+	if last := s.Streaks.Outreach.LastOutreachDate; last != "" {
+		if _, err := time.Parse(dateLayout, last); err != nil {
+			return &ValidationError{"streaks.outreach.last_outreach_date", fmt.Sprintf("%q is not YYYY-MM-DD", last)}
+		}
+	}
+
+	if s.Streaks.Outreach.CurrentWeek < 0 {
+		return &ValidationError{"streaks.outreach.current_week", fmt.Sprintf("must be >= 0, got %d", s.Streaks.Outreach.CurrentWeek)}
+	}
+
+	seen := make(map[int]bool)
+	for _, a := range s.ActionQueue.Pending {
+		if a.ID < 0 {
+			return &ValidationError{"action_queue.pending", fmt.Sprintf("has negative id %d", a.ID)}
+		}
+		if seen[a.ID] {
+			return &ValidationError{"action_queue.pending", fmt.Sprintf("has duplicate id %d", a.ID)}
+		}
+		seen[a.ID] = true
+	}
+
+	return nil
+}
+
 // Provider reads and writes CoS state
 type Provider struct {
 	path string
+	now  func() time.Time // Clock for streak checks; nil means time.Now
 }
 
 // NewProvider creates a new CoS state provider
@@ -141,6 +202,11 @@ func NewProviderWithPath(path string) *Provider {
 	}
 }
 
+// Path is the state file the provider reads and writes
+func (p *Provider) Path() string {
+	return p.path
+}
+
 // Load reads the current state from disk
 func (p *Provider) Load() (*State, error) {
 	data, err := os.ReadFile(p.path)
@@ -152,16 +218,28 @@ func (p *Provider) Load() (*State, error) {
 		return nil, fmt.Errorf("failed to read state file: %w", err)
 	}
 
+	data, err = migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate state file: %w", err)
+	}
+
 	var state State
 	if err := json.Unmarshal(data, &state); err != nil {
 		return nil, fmt.Errorf("failed to parse state file: %w", err)
 	}
 
+	p.MergeDuplicateActions(&state)
+	p.CheckAndResetStreaks(&state)
+
 	return &state, nil
 }
 
 // Save writes the state to disk
 func (p *Provider) Save(state *State) error {
+	if err := state.Validate(); err != nil {
+		return err
+	}
+
 	state.LastUpdated = time.Now()
 
 	data, err := json.MarshalIndent(state, "", "  ")
@@ -199,14 +277,15 @@ func (p *Provider) IsOutreachCold(state *State) bool {
 	return true
 }
 
-// DaysSinceLastOutreach calculates days since last outreach
+// DaysSinceLastOutreach counts calendar days since the last outreach, so
+// outreach earlier today is 0 and yesterday is 1. With no recorded
+// outreach it reports one day past the cold threshold.
 func (p *Provider) DaysSinceLastOutreach(state *State) int {
-	// This is a simplified calculation
-	// In practice, you'd parse the date and calculate properly
-	if state.Streaks.Outreach.CurrentWeek > 0 {
-		return 0
+	days, ok := daysSince(state.Streaks.Outreach.LastOutreachDate, p.clock())
+	if !ok {
+		return state.Thresholds.OutreachColdDays + 1
 	}
-	return state.Thresholds.OutreachColdDays + 1
+	return days
 }
 
 // IsAvoidanceDetected checks if avoidance pattern is active
@@ -214,15 +293,20 @@ func (p *Provider) IsAvoidanceDetected(state *State) bool {
 	return state.Patterns.AvoidanceFlags > 0
 }
 
-// MarkActionComplete moves an action from pending to completed
+// MarkActionComplete moves an action from pending to completed. Outreach
+// actions count toward this week's outreach.
 func (p *Provider) MarkActionComplete(state *State, actionID int) {
-	var remaining []PendingAction
+	remaining := make([]PendingAction, 0, len(state.ActionQueue.Pending))
 	for _, a := range state.ActionQueue.Pending {
 		if a.ID == actionID {
 			state.ActionQueue.CompletedToday = append(
 				state.ActionQueue.CompletedToday,
 				fmt.Sprintf("%d:%s", a.ID, a.Type),
 			)
+			if strings.Contains(strings.ToLower(a.Type), "outreach") {
+				state.Streaks.Outreach.LastOutreachDate = p.clock().Format(dateLayout)
+				state.Streaks.Outreach.CurrentWeek++
+			}
 		} else {
 			remaining = append(remaining, a)
 		}
@@ -232,7 +316,7 @@ func (p *Provider) MarkActionComplete(state *State, actionID int) {
 
 // MarkActionSkipped moves an action from pending to skipped
 func (p *Provider) MarkActionSkipped(state *State, actionID int) {
-	var remaining []PendingAction
+	remaining := make([]PendingAction, 0, len(state.ActionQueue.Pending))
 	for _, a := range state.ActionQueue.Pending {
 		if a.ID == actionID {
 			state.ActionQueue.SkippedToday = append(
@@ -246,10 +330,186 @@ func (p *Provider) MarkActionSkipped(state *State, actionID int) {
 	state.ActionQueue.Pending = remaining
 }
 
+// MergeDuplicateActions removes pending actions that share the same
+// (Type, Company, Contact), keeping the one created earliest.
+// Returns the number of actions removed.
+func (p *Provider) MergeDuplicateActions(state *State) int {
+	type actionKey struct {
+		Type    string
+		Company string
+		Contact string
+	}
+
+	seen := make(map[actionKey]int) // key -> index in merged
+	var merged []PendingAction
+	for _, a := range state.ActionQueue.Pending {
+		key := actionKey{Type: a.Type, Company: a.Company, Contact: a.Contact}
+		if idx, ok := seen[key]; ok {
+			if a.CreatedAt.Before(merged[idx].CreatedAt) {
+				merged[idx] = a
+			}
+			continue
+		}
+		seen[key] = len(merged)
+		merged = append(merged, a)
+	}
+
+	removed := len(state.ActionQueue.Pending) - len(merged)
+	if removed > 0 {
+		state.ActionQueue.Pending = merged
+	}
+	return removed
+}
+
+// AddAction appends an action to the pending queue with the next free ID.
+// CreatedAt defaults to now.
+func (p *Provider) AddAction(state *State, action PendingAction) error {
+	if strings.TrimSpace(action.Type) == "" {
+		return fmt.Errorf("action type is required")
+	}
+	action.ID = nextActionID(state)
+	if action.CreatedAt.IsZero() {
+		action.CreatedAt = p.clock()
+	}
+	state.ActionQueue.Pending = append(state.ActionQueue.Pending, action)
+	return nil
+}
+
+// nextActionID is one past the highest ID used today, including actions
+// already completed or skipped ("<id>:<type>" entries)
+func nextActionID(state *State) int {
+	highest := 0
+	for _, a := range state.ActionQueue.Pending {
+		highest = max(highest, a.ID)
+	}
+	for _, list := range [][]string{state.ActionQueue.CompletedToday, state.ActionQueue.SkippedToday} {
+		for _, entry := range list {
+			id, _, _ := strings.Cut(entry, ":")
+			if n, err := strconv.Atoi(id); err == nil {
+				highest = max(highest, n)
+			}
+		}
+	}
+	return highest + 1
+}
+
+// ReorderAction swaps the pending actions at fromIdx and toIdx
+func (p *Provider) ReorderAction(state *State, fromIdx, toIdx int) error {
+	pending := state.ActionQueue.Pending
+	if fromIdx < 0 || fromIdx >= len(pending) || toIdx < 0 || toIdx >= len(pending) {
+		return fmt.Errorf("reorder %d -> %d out of range (queue has %d actions)", fromIdx, toIdx, len(pending))
+	}
+	pending[fromIdx], pending[toIdx] = pending[toIdx], pending[fromIdx]
+	return nil
+}
+
+// EditAction applies the non-empty fields of updates to the pending action
+// with the given ID. ID and CreatedAt are never changed.
+func (p *Provider) EditAction(state *State, id int, updates PendingAction) error {
+	for i := range state.ActionQueue.Pending {
+		a := &state.ActionQueue.Pending[i]
+		if a.ID != id {
+			continue
+		}
+		set := func(dst *string, v string) {
+			if v != "" {
+				*dst = v
+			}
+		}
+		set(&a.Type, updates.Type)
+		set(&a.Company, updates.Company)
+		set(&a.Contact, updates.Contact)
+		set(&a.Role, updates.Role)
+		set(&a.DraftPath, updates.DraftPath)
+		set(&a.Description, updates.Description)
+		return nil
+	}
+	return fmt.Errorf("no pending action with id %d", id)
+}
+
+// ClearCompleted forgets today's completed and skipped actions
+func (p *Provider) ClearCompleted(state *State) {
+	state.ActionQueue.CompletedToday = []string{}
+	state.ActionQueue.SkippedToday = []string{}
+}
+
+// Migrator upgrades a decoded state document by one schema version
+type Migrator func(map[string]interface{}) (map[string]interface{}, error)
+
+// migrations are keyed by the version they upgrade from. Each one must set
+// "version" to the next version so migrate can chain them.
+var migrations = map[string]Migrator{
+	"1.0": migrateV1_0,
+}
+
+// migrate upgrades raw state JSON to CurrentVersion. Files without a
+// version are treated as 1.0.
+func migrate(data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	version, _ := doc["version"].(string)
+	if version == "" {
+		version = "1.0"
+	}
+	if version == CurrentVersion {
+		return data, nil
+	}
+
+	for version != CurrentVersion {
+		m, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("unsupported state version %q", version)
+		}
+		var err error
+		if doc, err = m(doc); err != nil {
+			return nil, fmt.Errorf("migrating from %s: %w", version, err)
+		}
+		next, _ := doc["version"].(string)
+		if next == version {
+			return nil, fmt.Errorf("migration from %s did not advance the version", version)
+		}
+		version = next
+	}
+
+	return json.Marshal(doc)
+}
+
+// migrateV1_0 fills in thresholds and the weekly outreach target, which 1.0
+// files could omit and which otherwise decode as zero
+func migrateV1_0(doc map[string]interface{}) (map[string]interface{}, error) {
+	thresholds := subMap(doc, "thresholds")
+	for _, key := range []string{"outreach_cold_days", "deadline_warning_days", "avoidance_planning_days"} {
+		if _, ok := thresholds[key]; !ok {
+			thresholds[key] = 3
+		}
+	}
+
+	outreach := subMap(subMap(doc, "streaks"), "outreach")
+	if _, ok := outreach["weekly_target"]; !ok {
+		outreach["weekly_target"] = 10
+	}
+
+	doc["version"] = "1.1"
+	return doc, nil
+}
+
+// subMap returns doc[key] as an object, creating it when missing or malformed
+func subMap(doc map[string]interface{}, key string) map[string]interface{} {
+	if m, ok := doc[key].(map[string]interface{}); ok {
+		return m
+	}
+	m := make(map[string]interface{})
+	doc[key] = m
+	return m
+}
+
 // defaultState returns a new default state
 func (p *Provider) defaultState() *State {
 	return &State{
-		Version:     "1.0",
+		Version:     CurrentVersion,
 		LastUpdated: time.Now(),
 		Briefings: Briefings{
 			Morning:    BriefingState{},
@@ -0,0 +1,533 @@
+package cos
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// day is a fixed reference time for building test states
+var day = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+// pending builds a state whose queue holds actions
+func pending(actions ...PendingAction) *State {
+	return &State{ActionQueue: ActionQueue{Pending: actions}}
+}
+
+// pendingIDs lists the IDs left in the queue, in order
+func pendingIDs(state *State) []int {
+	ids := make([]int, len(state.ActionQueue.Pending))
+	for i, a := range state.ActionQueue.Pending {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func TestMergeDuplicateActions(t *testing.T) {
+	state := pending(
+		PendingAction{ID: 1, Type: "outreach", Company: "Acme", Contact: "Jane", CreatedAt: day.Add(2 * time.Hour)},
+		PendingAction{ID: 2, Type: "follow_up", Company: "Globex", CreatedAt: day},
+		PendingAction{ID: 3, Type: "outreach", Company: "Acme", Contact: "Jane", CreatedAt: day.Add(time.Hour)},
+		PendingAction{ID: 4, Type: "outreach", Company: "Acme", Contact: "Bob", CreatedAt: day},
+		PendingAction{ID: 5, Type: "follow_up", Company: "Globex", CreatedAt: day.Add(time.Hour)},
+	)
+
+	p := &Provider{}
+	if removed := p.MergeDuplicateActions(state); removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	// Each group keeps its first position but the earliest-created action
+	if got, want := pendingIDs(state), []int{3, 2, 4}; !slices.Equal(got, want) {
+		t.Errorf("pending IDs = %v, want %v", got, want)
+	}
+
+	if removed := p.MergeDuplicateActions(state); removed != 0 {
+		t.Errorf("second merge removed %d, want 0", removed)
+	}
+}
+
+// queue builds a state with pending actions 1..n, typed by parity
+func queue(n int) *State {
+	state := pending()
+	for i := 1; i <= n; i++ {
+		typ := "follow_up"
+		if i%2 == 0 {
+			typ = "research"
+		}
+		state.ActionQueue.Pending = append(state.ActionQueue.Pending, PendingAction{ID: i, Type: typ})
+	}
+	return state
+}
+
+func TestMarkActionComplete(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     *State
+		id        int
+		wantIDs   []int
+		completed []string
+	}{
+		{"empty queue", queue(0), 1, []int{}, nil},
+		{"first", queue(3), 1, []int{2, 3}, []string{"1:follow_up"}},
+		{"middle", queue(3), 2, []int{1, 3}, []string{"2:research"}},
+		{"last", queue(3), 3, []int{1, 2}, []string{"3:follow_up"}},
+		{"missing id", queue(3), 9, []int{1, 2, 3}, nil},
+	}
+
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p.MarkActionComplete(tt.state, tt.id)
+
+			if got := pendingIDs(tt.state); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("pending IDs = %v, want %v", got, tt.wantIDs)
+			}
+			if tt.state.ActionQueue.Pending == nil {
+				t.Error("pending queue became nil")
+			}
+			if got := tt.state.ActionQueue.CompletedToday; !slices.Equal(got, tt.completed) {
+				t.Errorf("CompletedToday = %q, want %q", got, tt.completed)
+			}
+			if len(tt.state.ActionQueue.SkippedToday) != 0 {
+				t.Errorf("SkippedToday = %q, want none", tt.state.ActionQueue.SkippedToday)
+			}
+		})
+	}
+}
+
+func TestMarkActionSkipped(t *testing.T) {
+	state := queue(3)
+	p := &Provider{}
+
+	p.MarkActionSkipped(state, 2)
+	p.MarkActionSkipped(state, 9)
+
+	if got, want := pendingIDs(state), []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("pending IDs = %v, want %v", got, want)
+	}
+	if got, want := state.ActionQueue.SkippedToday, []string{"2:research"}; !slices.Equal(got, want) {
+		t.Errorf("SkippedToday = %q, want %q", got, want)
+	}
+	if len(state.ActionQueue.CompletedToday) != 0 {
+		t.Errorf("CompletedToday = %q, want none", state.ActionQueue.CompletedToday)
+	}
+}
+
+func BenchmarkMarkActionComplete(b *testing.B) {
+	p := &Provider{}
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		state := queue(100)
+		b.StartTimer()
+		p.MarkActionComplete(state, 50)
+	}
+}
+
+// validState is the smallest state that passes Validate
+func validState() *State {
+	state := queue(3)
+	state.Thresholds.OutreachColdDays = 7
+	state.Streaks.NeedleMover.LastCompleted = "2026-03-09"
+	state.Streaks.Outreach.LastOutreachDate = "2026-03-09"
+	return state
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(*State)
+		wantField string // empty means the state is valid
+	}{
+		{"valid", func(*State) {}, ""},
+		{"empty dates", func(s *State) {
+			s.Streaks.NeedleMover.LastCompleted = ""
+			s.Streaks.Outreach.LastOutreachDate = ""
+		}, ""},
+		{"zero cold days", func(s *State) { s.Thresholds.OutreachColdDays = 0 }, "thresholds.outreach_cold_days"},
+		{"negative cold days", func(s *State) { s.Thresholds.OutreachColdDays = -3 }, "thresholds.outreach_cold_days"},
+		{"needle mover date", func(s *State) { s.Streaks.NeedleMover.LastCompleted = "03/09/2026" }, "streaks.needle_mover.last_completed"},
+		{"outreach date", func(s *State) { s.Streaks.Outreach.LastOutreachDate = "2026-03-09T10:00:00Z" }, "streaks.outreach.last_outreach_date"},
+		{"negative current week", func(s *State) { s.Streaks.Outreach.CurrentWeek = -1 }, "streaks.outreach.current_week"},
+		{"negative id", func(s *State) { s.ActionQueue.Pending[1].ID = -2 }, "action_queue.pending"},
+		{"duplicate id", func(s *State) { s.ActionQueue.Pending[2].ID = 1 }, "action_queue.pending"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := validState()
+			tt.mutate(state)
+			err := state.Validate()
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			if verr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", verr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestSaveRejectsInvalidState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	p := NewProviderWithPath(path)
+
+	state := validState()
+	state.Thresholds.OutreachColdDays = 0
+
+	var verr *ValidationError
+	if err := p.Save(state); !errors.As(err, &verr) {
+		t.Fatalf("Save() = %v, want a *ValidationError", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("state file written despite the invalid state (stat err %v)", err)
+	}
+
+	// Once the rule is satisfied the same state saves
+	state.Thresholds.OutreachColdDays = 7
+	if err := p.Save(state); err != nil {
+		t.Fatalf("Save() after fix = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("state file missing after save: %v", err)
+	}
+}
+
+func TestDaysSinceLastOutreach(t *testing.T) {
+	tests := []struct {
+		name string
+		last string
+		now  time.Time
+		want int
+	}{
+		{"no outreach", "", day, 8},
+		{"unparseable date", "last week", day, 8},
+		{"same day", "2026-03-10", day, 0},
+		{"same day, late", "2026-03-10", time.Date(2026, time.March, 10, 23, 59, 0, 0, time.UTC), 0},
+		{"yesterday, just after midnight", "2026-03-09", time.Date(2026, time.March, 10, 0, 1, 0, 0, time.UTC), 1},
+		{"multi-day", "2026-03-05", day, 5},
+		{"across a month", "2026-02-24", day, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := pending()
+			state.Thresholds.OutreachColdDays = 7
+			state.Streaks.Outreach.LastOutreachDate = tt.last
+			p := &Provider{now: func() time.Time { return tt.now }}
+
+			if got := p.DaysSinceLastOutreach(state); got != tt.want {
+				t.Errorf("DaysSinceLastOutreach() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkActionCompleteRecordsOutreach(t *testing.T) {
+	state := pending(
+		PendingAction{ID: 1, Type: "Outreach"},
+		PendingAction{ID: 2, Type: "research"},
+	)
+	p := &Provider{now: func() time.Time { return day }}
+
+	p.MarkActionComplete(state, 2)
+	if o := state.Streaks.Outreach; o.LastOutreachDate != "" || o.CurrentWeek != 0 {
+		t.Errorf("research counted as outreach: %+v", o)
+	}
+
+	p.MarkActionComplete(state, 1)
+	if o := state.Streaks.Outreach; o.LastOutreachDate != "2026-03-10" || o.CurrentWeek != 1 {
+		t.Errorf("outreach streak = %+v, want dated 2026-03-10 with 1 this week", o)
+	}
+	if got := p.DaysSinceLastOutreach(state); got != 0 {
+		t.Errorf("DaysSinceLastOutreach() = %d right after outreach, want 0", got)
+	}
+}
+
+func TestMigrateV1_0(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "state_v1_0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := migrate(data)
+	if err != nil {
+		t.Fatalf("migrate() = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["version"] != "1.1" {
+		t.Errorf("version = %v, want 1.1", doc["version"])
+	}
+
+	// Missing thresholds get defaults; ones already set are kept
+	thresholds := doc["thresholds"].(map[string]interface{})
+	for key, want := range map[string]float64{
+		"outreach_cold_days":      3,
+		"deadline_warning_days":   5,
+		"avoidance_planning_days": 3,
+	} {
+		if got := thresholds[key]; got != want {
+			t.Errorf("thresholds.%s = %v, want %v", key, got, want)
+		}
+	}
+
+	outreach := doc["streaks"].(map[string]interface{})["outreach"].(map[string]interface{})
+	if got := outreach["weekly_target"]; got != float64(10) {
+		t.Errorf("streaks.outreach.weekly_target = %v, want 10", got)
+	}
+	if got := outreach["current_week"]; got != float64(2) {
+		t.Errorf("streaks.outreach.current_week = %v, want 2", got)
+	}
+
+	var state State
+	if err := json.Unmarshal(migrated, &state); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.Validate(); err != nil {
+		t.Errorf("migrated state is invalid: %v", err)
+	}
+	if nm := state.Streaks.NeedleMover; nm.Current != 4 || nm.Longest != 9 {
+		t.Errorf("needle-mover streak = %+v, want it kept", nm)
+	}
+	if got := pendingIDs(&state); !slices.Equal(got, []int{1}) || state.ActionQueue.Pending[0].Company != "Acme" {
+		t.Errorf("pending = %+v, want the Acme action kept", state.ActionQueue.Pending)
+	}
+}
+
+func TestMigrateVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion string // empty means an error is expected
+	}{
+		{"current", `{"version":"1.1","thresholds":{"outreach_cold_days":9}}`, "1.1"},
+		{"unversioned is 1.0", `{"thresholds":{}}`, "1.1"},
+		{"unknown older", `{"version":"0.9"}`, ""},
+		{"unknown newer", `{"version":"2.0"}`, ""},
+		{"not json", `version: 1.0`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, err := migrate([]byte(tt.data))
+			if tt.wantVersion == "" {
+				if err == nil {
+					t.Fatalf("migrate() = %s, want an error", migrated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("migrate() = %v", err)
+			}
+			var doc struct{ Version string }
+			if err := json.Unmarshal(migrated, &doc); err != nil {
+				t.Fatal(err)
+			}
+			if doc.Version != tt.wantVersion {
+				t.Errorf("version = %q, want %q", doc.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestLoadUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"version":"2.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewProviderWithPath(path).Load(); err == nil {
+		t.Fatal("Load() succeeded on an unknown version, want an error")
+	}
+}
+
+func TestAddAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   *State
+		action  PendingAction
+		wantID  int
+		wantErr bool
+	}{
+		{"empty queue", queue(0), PendingAction{Type: "research"}, 1, false},
+		{"after pending", queue(3), PendingAction{Type: "research"}, 4, false},
+		{"ID supplied is replaced", queue(2), PendingAction{ID: 1, Type: "research"}, 3, false},
+		{"after completed today", func() *State {
+			s := queue(2)
+			s.ActionQueue.CompletedToday = []string{"7:outreach"}
+			s.ActionQueue.SkippedToday = []string{"5:research", "junk"}
+			return s
+		}(), PendingAction{Type: "follow_up"}, 8, false},
+		{"missing type", queue(2), PendingAction{Company: "Acme"}, 0, true},
+		{"blank type", queue(2), PendingAction{Type: "  "}, 0, true},
+	}
+
+	p := &Provider{now: func() time.Time { return day }}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(tt.state.ActionQueue.Pending)
+			err := p.AddAction(tt.state, tt.action)
+			pending := tt.state.ActionQueue.Pending
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("AddAction() succeeded, want an error")
+				}
+				if len(pending) != before {
+					t.Errorf("queue grew to %d despite the error", len(pending))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddAction() = %v", err)
+			}
+			if len(pending) != before+1 {
+				t.Fatalf("queue has %d actions, want %d", len(pending), before+1)
+			}
+			added := pending[len(pending)-1]
+			if added.ID != tt.wantID || added.Type != tt.action.Type {
+				t.Errorf("added %d:%s, want %d:%s", added.ID, added.Type, tt.wantID, tt.action.Type)
+			}
+			if !added.CreatedAt.Equal(day) {
+				t.Errorf("CreatedAt = %v, want the provider clock %v", added.CreatedAt, day)
+			}
+			ids := pendingIDs(tt.state)
+			slices.Sort(ids)
+			if len(slices.Compact(ids)) != len(pending) {
+				t.Errorf("duplicate IDs after AddAction: %v", pendingIDs(tt.state))
+			}
+		})
+	}
+}
+
+func TestAddActionKeepsCreatedAt(t *testing.T) {
+	state := queue(0)
+	created := day.Add(-48 * time.Hour)
+	if err := (&Provider{}).AddAction(state, PendingAction{Type: "research", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+	if got := state.ActionQueue.Pending[0].CreatedAt; !got.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", got, created)
+	}
+}
+
+func TestReorderAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		wantIDs  []int
+		wantErr  bool
+	}{
+		{"down", 0, 1, []int{2, 1, 3}, false},
+		{"up", 2, 1, []int{1, 3, 2}, false},
+		{"ends", 0, 2, []int{3, 2, 1}, false},
+		{"same place", 1, 1, []int{1, 2, 3}, false},
+		{"from negative", -1, 0, []int{1, 2, 3}, true},
+		{"past the end", 2, 3, []int{1, 2, 3}, true},
+	}
+
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := queue(3)
+			err := p.ReorderAction(state, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReorderAction() = %v, want error %v", err, tt.wantErr)
+			}
+			if got := pendingIDs(state); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("pending IDs = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+
+	if err := p.ReorderAction(queue(0), 0, 0); err == nil {
+		t.Error("ReorderAction() on an empty queue succeeded")
+	}
+}
+
+func TestEditAction(t *testing.T) {
+	original := PendingAction{
+		ID: 2, Type: "outreach", Company: "Acme", Contact: "Jane", Role: "CTO",
+		DraftPath: "drafts/acme.md", Description: "Intro", CreatedAt: day,
+	}
+
+	tests := []struct {
+		name    string
+		id      int
+		updates PendingAction
+		want    PendingAction
+		wantErr bool
+	}{
+		{"no changes", 2, PendingAction{}, original, false},
+		{"one field", 2, PendingAction{Contact: "Bob"}, func() PendingAction {
+			a := original
+			a.Contact = "Bob"
+			return a
+		}(), false},
+		{"every field", 2, PendingAction{
+			Type: "follow_up", Company: "Globex", Contact: "Bob", Role: "CEO",
+			DraftPath: "drafts/globex.md", Description: "Second try",
+		}, PendingAction{
+			ID: 2, Type: "follow_up", Company: "Globex", Contact: "Bob", Role: "CEO",
+			DraftPath: "drafts/globex.md", Description: "Second try", CreatedAt: day,
+		}, false},
+		{"ID and CreatedAt are kept", 2, PendingAction{ID: 9, CreatedAt: day.Add(time.Hour)}, original, false},
+		{"missing id", 5, PendingAction{Contact: "Bob"}, original, true},
+	}
+
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := pending(PendingAction{ID: 1, Type: "research"}, original)
+			err := p.EditAction(state, tt.id, tt.updates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EditAction() = %v, want error %v", err, tt.wantErr)
+			}
+			if got := state.ActionQueue.Pending[1]; got != tt.want {
+				t.Errorf("action = %+v, want %+v", got, tt.want)
+			}
+			if got := state.ActionQueue.Pending[0]; got != (PendingAction{ID: 1, Type: "research"}) {
+				t.Errorf("other action changed: %+v", got)
+			}
+		})
+	}
+}
+
+func TestClearCompleted(t *testing.T) {
+	state := queue(2)
+	state.ActionQueue.CompletedToday = []string{"3:outreach"}
+	state.ActionQueue.SkippedToday = []string{"4:research"}
+
+	p := &Provider{}
+	p.ClearCompleted(state)
+
+	if got := pendingIDs(state); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("pending IDs = %v, want them untouched", got)
+	}
+	q := state.ActionQueue
+	if q.CompletedToday == nil || len(q.CompletedToday) != 0 || q.SkippedToday == nil || len(q.SkippedToday) != 0 {
+		t.Errorf("completed = %q, skipped = %q; want both empty and non-nil", q.CompletedToday, q.SkippedToday)
+	}
+
+	// Cleared IDs may be reused
+	if err := p.AddAction(state, PendingAction{Type: "research"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := state.ActionQueue.Pending[2].ID; got != 3 {
+		t.Errorf("next ID after clearing = %d, want 3", got)
+	}
+}
@@ -0,0 +1,63 @@
+package cos
+
+import (
+	"fmt"
+	"time"
+)
+
+// clock returns the provider's current time
+func (p *Provider) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// civilDay is t's calendar date as midnight UTC, so subtracting two of
+// them counts calendar days regardless of DST changes in between
+func civilDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// daysSince is how many calendar days lie between the YYYY-MM-DD date and
+// now, in now's zone
+func daysSince(date string, now time.Time) (int, bool) {
+	day, err := time.ParseInLocation(dateLayout, date, time.UTC)
+	if err != nil {
+		return 0, false
+	}
+	return int(civilDay(now).Sub(day).Hours() / 24), true
+}
+
+// CheckAndResetStreaks zeroes streaks that lapsed while Partner wasn't
+// looking: the needle-mover streak once a calendar day passes without a
+// completion (after carrying it into Longest), and the weekly outreach
+// count once its week is over, starting a new week on Monday. Each reset
+// is described in state.StreakResets.
+func (p *Provider) CheckAndResetStreaks(state *State) {
+	now := p.clock()
+	state.StreakResets = nil
+
+	nm := &state.Streaks.NeedleMover
+	if days, ok := daysSince(nm.LastCompleted, now); ok && days > 1 {
+		nm.Longest = max(nm.Longest, nm.Current)
+		if nm.Current > 0 {
+			state.StreakResets = append(state.StreakResets,
+				fmt.Sprintf("Needle-mover streak of %d days ended (last: %s)", nm.Current, nm.LastCompleted))
+		}
+		nm.Current = 0
+	}
+
+	o := &state.Streaks.Outreach
+	if days, ok := daysSince(o.WeekStart, now); ok && days >= 7 {
+		if o.CurrentWeek > 0 {
+			state.StreakResets = append(state.StreakResets,
+				fmt.Sprintf("New outreach week: %d/%d last week", o.CurrentWeek, o.WeeklyTarget))
+		}
+		o.CurrentWeek = 0
+		today := civilDay(now)
+		offset := (int(today.Weekday()) + 6) % 7 // Days since Monday
+		o.WeekStart = today.AddDate(0, 0, -offset).Format(dateLayout)
+	}
+}
@@ -0,0 +1,116 @@
+package cos
+
+import (
+	"testing"
+	"time"
+)
+
+// newYork is where the DST cases run; 2026 springs forward on March 8
+// and falls back on November 1
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	return loc
+}
+
+// at returns a clock stuck at the given wall time in loc
+func at(loc *time.Location, month time.Month, day, hour, min int) func() time.Time {
+	return func() time.Time { return time.Date(2026, month, day, hour, min, 0, 0, loc) }
+}
+
+func TestCheckAndResetNeedleMover(t *testing.T) {
+	ny := newYork(t)
+
+	tests := []struct {
+		name        string
+		last        string
+		now         func() time.Time
+		wantCurrent int
+		wantLongest int
+		wantResets  int
+	}{
+		{"same day", "2026-03-10", at(ny, time.March, 10, 23, 59), 5, 7, 0},
+		{"23:59 to 00:01", "2026-03-10", at(ny, time.March, 11, 0, 1), 5, 7, 0},
+		{"a full day missed", "2026-03-10", at(ny, time.March, 12, 0, 1), 0, 7, 1},
+		{"spring forward, 23-hour day", "2026-03-07", at(ny, time.March, 8, 23, 30), 5, 7, 0},
+		{"spring forward, day missed", "2026-03-07", at(ny, time.March, 9, 0, 30), 0, 7, 1},
+		{"fall back, 25-hour day", "2026-10-31", at(ny, time.November, 1, 23, 59), 5, 7, 0},
+		{"multi-day lapse", "2026-03-01", at(time.UTC, time.March, 10, 9, 0), 0, 7, 1},
+		{"no completion yet", "", at(time.UTC, time.March, 10, 9, 0), 5, 7, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := pending()
+			state.Streaks.NeedleMover = NeedleMoverStreak{Current: 5, Longest: 7, LastCompleted: tt.last}
+			p := &Provider{now: tt.now}
+
+			p.CheckAndResetStreaks(state)
+
+			nm := state.Streaks.NeedleMover
+			if nm.Current != tt.wantCurrent || nm.Longest != tt.wantLongest {
+				t.Errorf("streak = %d (longest %d), want %d (longest %d)", nm.Current, nm.Longest, tt.wantCurrent, tt.wantLongest)
+			}
+			if len(state.StreakResets) != tt.wantResets {
+				t.Errorf("StreakResets = %q, want %d", state.StreakResets, tt.wantResets)
+			}
+		})
+	}
+}
+
+func TestCheckAndResetNeedleMoverLongest(t *testing.T) {
+	state := pending()
+	state.Streaks.NeedleMover = NeedleMoverStreak{Current: 12, Longest: 7, LastCompleted: "2026-03-01"}
+	p := &Provider{now: at(time.UTC, time.March, 10, 9, 0)}
+
+	p.CheckAndResetStreaks(state)
+	if nm := state.Streaks.NeedleMover; nm.Current != 0 || nm.Longest != 12 {
+		t.Errorf("streak = %+v, want 0 with the lapsed 12 kept as longest", nm)
+	}
+
+	// A second check reports nothing new
+	p.CheckAndResetStreaks(state)
+	if len(state.StreakResets) != 0 {
+		t.Errorf("StreakResets = %q after an idle recheck, want none", state.StreakResets)
+	}
+}
+
+func TestCheckAndResetOutreachWeek(t *testing.T) {
+	ny := newYork(t)
+
+	tests := []struct {
+		name          string
+		weekStart     string
+		now           func() time.Time
+		wantWeek      int
+		wantWeekStart string
+		wantResets    int
+	}{
+		{"Sunday 23:59", "2026-03-09", at(ny, time.March, 15, 23, 59), 3, "2026-03-09", 0},
+		{"Monday 00:01", "2026-03-09", at(ny, time.March, 16, 0, 1), 0, "2026-03-16", 1},
+		{"week across spring forward", "2026-03-02", at(ny, time.March, 9, 0, 30), 0, "2026-03-09", 1},
+		{"multi-week lapse", "2026-03-09", at(time.UTC, time.April, 2, 9, 0), 0, "2026-03-30", 1},
+		{"no week yet", "", at(time.UTC, time.March, 10, 9, 0), 3, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := pending()
+			state.Streaks.Outreach = OutreachStreak{CurrentWeek: 3, WeeklyTarget: 10, WeekStart: tt.weekStart}
+			p := &Provider{now: tt.now}
+
+			p.CheckAndResetStreaks(state)
+
+			o := state.Streaks.Outreach
+			if o.CurrentWeek != tt.wantWeek || o.WeekStart != tt.wantWeekStart {
+				t.Errorf("outreach = %d from %q, want %d from %q", o.CurrentWeek, o.WeekStart, tt.wantWeek, tt.wantWeekStart)
+			}
+			if len(state.StreakResets) != tt.wantResets {
+				t.Errorf("StreakResets = %q, want %d", state.StreakResets, tt.wantResets)
+			}
+		})
+	}
+}
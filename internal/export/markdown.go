@@ -0,0 +1,188 @@
+// Package export renders pane data as Markdown documents
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cosstate "github.com/szoloth/partner/internal/cos"
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes"
+)
+
+// Exporter turns a pane's loaded data into a document
+type Exporter interface {
+	Export(pane panes.Pane) (string, error)
+}
+
+// stateGetter is implemented by the Chief of Staff pane
+type stateGetter interface {
+	GetState() *cosstate.State
+}
+
+// Markdown exports panes as Markdown
+type Markdown struct{}
+
+// NewMarkdown returns a Markdown exporter
+func NewMarkdown() *Markdown {
+	return &Markdown{}
+}
+
+// Export renders the tasks, calendar and Chief of Staff panes
+func (e *Markdown) Export(pane panes.Pane) (string, error) {
+	switch pane.Type() {
+	case panes.PaneTasks:
+		data, _ := pane.GetData().(map[string]interface{})
+		tasks, _ := data["tasks"].([]providers.Task)
+		view, _ := data["view"].(string)
+		return exportTasks(view, tasks), nil
+	case panes.PaneCalendar:
+		events, _ := pane.GetData().([]providers.CalendarEvent)
+		return exportEvents(events), nil
+	case panes.PaneCoS:
+		getter, ok := pane.(stateGetter)
+		if !ok || getter.GetState() == nil {
+			return "", fmt.Errorf("no Chief of Staff state loaded")
+		}
+		return exportCoS(getter.GetState()), nil
+	}
+	return "", fmt.Errorf("export not supported for %s pane", pane.Type())
+}
+
+func exportTasks(view string, tasks []providers.Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Tasks: %s\n\n", view)
+	if len(tasks) == 0 {
+		b.WriteString("No tasks.\n")
+		return b.String()
+	}
+	for _, t := range tasks {
+		fmt.Fprintf(&b, "- [ ] %s\n", t.Title)
+		if notes := strings.TrimSpace(t.Notes); notes != "" {
+			for _, line := range strings.Split(notes, "\n") {
+				fmt.Fprintf(&b, "  - %s\n", strings.TrimSpace(line))
+			}
+		}
+		for _, item := range t.ChecklistItems {
+			box := " "
+			if item.Status == "completed" {
+				box = "x"
+			}
+			fmt.Fprintf(&b, "  - [%s] %s\n", box, item.Title)
+		}
+	}
+	return b.String()
+}
+
+func exportEvents(events []providers.CalendarEvent) string {
+	var b strings.Builder
+	b.WriteString("# Calendar\n")
+	if len(events) == 0 {
+		b.WriteString("\nNo events.\n")
+		return b.String()
+	}
+
+	var day string
+	for _, e := range events {
+		if d := e.StartTime.Format("Monday, January 2"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n\n", day)
+		}
+		start := e.StartTime.Format("15:04")
+		if e.AllDay {
+			start = "All day"
+		}
+		line := fmt.Sprintf("- %s – %s", start, e.Title)
+		if e.Location != "" {
+			line += fmt.Sprintf(" (%s)", e.Location)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func exportCoS(state *cosstate.State) string {
+	var b strings.Builder
+	b.WriteString("# Chief of Staff\n\n")
+
+	b.WriteString("## Needle Mover\n\n")
+	if pending := state.ActionQueue.Pending; len(pending) > 0 {
+		fmt.Fprintf(&b, "%s\n", actionLabel(pending[0]))
+		if pending[0].Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", pending[0].Description)
+		}
+	} else {
+		b.WriteString("Nothing queued.\n")
+	}
+
+	s := state.Streaks
+	b.WriteString("\n## Streaks\n\n")
+	b.WriteString("| Streak | Current | Detail |\n")
+	b.WriteString("|--------|---------|--------|\n")
+	fmt.Fprintf(&b, "| Needle mover | %d days | longest %d |\n", s.NeedleMover.Current, s.NeedleMover.Longest)
+	fmt.Fprintf(&b, "| Outreach | %d this week | target %d |\n", s.Outreach.CurrentWeek, s.Outreach.WeeklyTarget)
+	fmt.Fprintf(&b, "| Training | %d days this week | last %s |\n", s.Training.DaysThisWeek, orDash(s.Training.LastActivity))
+
+	b.WriteString("\n## Action Queue\n\n")
+	if len(state.ActionQueue.Pending) == 0 {
+		b.WriteString("Empty.\n")
+	}
+	for i, a := range state.ActionQueue.Pending {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, actionLabel(a))
+	}
+	return b.String()
+}
+
+// actionLabel matches the CoS pane's "type - Company (Contact)" format
+func actionLabel(a cosstate.PendingAction) string {
+	label := a.Type
+	if a.Company != "" {
+		label += " - " + a.Company
+	}
+	if a.Contact != "" {
+		label += fmt.Sprintf(" (%s)", a.Contact)
+	}
+	return label
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// DefaultPath is ~/Desktop/partner-export-<pane>-<date>.md
+func DefaultPath(pane panes.PaneType, now time.Time) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory: %w", err)
+	}
+	name := fmt.Sprintf("partner-export-%s-%s.md", pane, now.Format("2006-01-02"))
+	return filepath.Join(home, "Desktop", name), nil
+}
+
+// WeeklyReviewPath is ~/Documents/weekly-review-<year>-W<ISO week>.md
+func WeeklyReviewPath(now time.Time) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory: %w", err)
+	}
+	year, week := now.ISOWeek()
+	name := fmt.Sprintf("weekly-review-%d-W%02d.md", year, week)
+	return filepath.Join(home, "Documents", name), nil
+}
+
+// WriteFile writes content to path, creating parent directories
+func WriteFile(content, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package fuzzy ranks short labels against a typed query
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Score reports whether every rune of query appears in label in order
+// (case-insensitive). Consecutive runs and matches at word starts score
+// higher; gaps cost a little.
+func Score(label, query string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	text := []rune(strings.ToLower(label))
+	q := []rune(strings.ToLower(query))
+	score, qi, last := 0, 0, -1
+	for i, r := range text {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+		switch {
+		case last == i-1:
+			score += 10
+		case i == 0 || !unicode.IsLetter(text[i-1]):
+			score += 8
+		default:
+			score += 1 - min(i-last, 5)
+		}
+		last = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
@@ -3,6 +3,12 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/transport"
 )
 
 // ToolResult represents the result of an MCP tool call
@@ -23,28 +29,226 @@ type Transport interface {
 	Close() error
 }
 
+// ToolCaller is the part of Client providers depend on, so calls can be
+// wrapped (e.g. by a cache)
+type ToolCaller interface {
+	CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*ToolResult, error)
+	Close() error
+}
+
+// Restarter is implemented by transports that can respawn a dead server
+type Restarter interface {
+	Restart() error
+}
+
+// Reconnect policy: delays double from 1s up to 30s, for at most 5 attempts
+const (
+	reconnectBaseDelay   = time.Second
+	reconnectMaxDelay    = 30 * time.Second
+	reconnectMaxAttempts = 5
+)
+
+// toolsRetryDelay is how long argument validation stays off after the
+// tool list couldn't be fetched
+const toolsRetryDelay = 30 * time.Second
+
+// ErrReconnecting is sent on the HealthCheck channel when a reconnect starts
+var ErrReconnecting = errors.New("reconnecting")
+
 // Client provides a unified interface to MCP servers
 type Client struct {
 	transport Transport
 	serverID  string
+
+	// Tool schemas for argument validation, loaded on first CallTool
+	toolsMu      sync.Mutex
+	tools        map[string]Tool
+	toolsLoaded  bool
+	toolsRetryAt time.Time // After a failed tools/list, no refetch before this
+
+	// Connection health; reconnectMu serializes restarts
+	healthy     atomic.Bool
+	reconnectMu sync.Mutex
+	backoff     func(attempt int) time.Duration
+	eventsMu    sync.Mutex
+	events      map[chan error]struct{} // One per running HealthCheck
 }
 
 // NewClient creates a new MCP client
 func NewClient(transport Transport, serverID string) *Client {
-	return &Client{
+	c := &Client{
 		transport: transport,
 		serverID:  serverID,
+		backoff:   reconnectDelay,
+	}
+	c.healthy.Store(true)
+	return c
+}
+
+// reconnectDelay is the wait before reconnect attempt n (0-based)
+func reconnectDelay(attempt int) time.Duration {
+	d := reconnectBaseDelay << attempt
+	if d > reconnectMaxDelay || d <= 0 {
+		return reconnectMaxDelay
+	}
+	return d
+}
+
+// IsHealthy reports whether the last call reached the server
+func (c *Client) IsHealthy() bool {
+	return c.healthy.Load()
+}
+
+// HealthCheck pings the server every interval until ctx is done. The
+// channel receives ErrReconnecting when a reconnect starts, nil once it
+// succeeds, and the final error when it gives up; reconnects triggered by
+// tool calls are reported too.
+func (c *Client) HealthCheck(ctx context.Context, interval time.Duration) <-chan error {
+	events := make(chan error, 4)
+	c.eventsMu.Lock()
+	if c.events == nil {
+		c.events = make(map[chan error]struct{})
+	}
+	c.events[events] = struct{}{}
+	c.eventsMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer func() {
+			c.eventsMu.Lock()
+			delete(c.events, events)
+			c.eventsMu.Unlock()
+			close(events)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.call(ctx, "ping", nil)
+			}
+		}
+	}()
+
+	return events
+}
+
+// notify reports a health event to every watcher without blocking callers
+func (c *Client) notify(err error) {
+	c.eventsMu.Lock()
+	defer c.eventsMu.Unlock()
+	for events := range c.events {
+		select {
+		case events <- err:
+		default:
+		}
+	}
+}
+
+// retryableMethods are safe to send twice. A tool call may have reached
+// the server before the connection died, so retrying it could create or
+// complete a task twice.
+var retryableMethods = map[string]bool{
+	"initialize": true,
+	"ping":       true,
+	"tools/list": true,
+}
+
+// call sends a request, restarting the server when the connection has
+// died and retrying once if the method is retryable
+func (c *Client) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	result, err := c.transport.Call(ctx, method, params)
+	var rpcErr *transport.JSONRPCError
+	if err == nil || errors.As(err, &rpcErr) {
+		// Any answer, even an error, means the server is up
+		c.healthy.Store(true)
+		return result, err
+	}
+	if !transport.IsConnectionError(err) {
+		return nil, err
+	}
+
+	c.healthy.Store(false)
+	if rerr := c.reconnect(ctx); rerr != nil || !retryableMethods[method] {
+		return nil, err
+	}
+	return c.transport.Call(ctx, method, params)
+}
+
+// reconnect restarts the transport with exponential backoff. It returns
+// immediately if another caller already reconnected.
+func (c *Client) reconnect(ctx context.Context) error {
+	restarter, ok := c.transport.(Restarter)
+	if !ok {
+		return errors.New("transport cannot reconnect")
+	}
+
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+	if c.healthy.Load() {
+		return nil
+	}
+
+	c.notify(ErrReconnecting)
+	var err error
+	for attempt := 0; attempt < reconnectMaxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			c.notify(ctx.Err())
+			return ctx.Err()
+		case <-time.After(c.backoff(attempt)):
+		}
+
+		if err = restarter.Restart(); err == nil {
+			c.healthy.Store(true)
+			c.notify(nil)
+			return nil
+		}
+	}
+	c.notify(err)
+	return err
+}
+
+// Restart respawns the server even though no call has failed, e.g. after
+// the machine wakes and its pipes may be stale. Transports that can't
+// restart, such as HTTP, are left alone.
+func (c *Client) Restart() error {
+	restarter, ok := c.transport.(Restarter)
+	if !ok {
+		return nil
+	}
+
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+	if err := restarter.Restart(); err != nil {
+		c.healthy.Store(false)
+		return err
 	}
+	c.healthy.Store(true)
+	return nil
+}
+
+// ServerID returns the identifier the client was created with
+func (c *Client) ServerID() string {
+	return c.serverID
 }
 
 // CallTool invokes an MCP tool
 func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*ToolResult, error) {
+	if tool, ok := c.lookupTool(ctx, toolName); ok {
+		if err := tool.Validate(args); err != nil {
+			return nil, err
+		}
+	}
+
 	params := map[string]interface{}{
 		"name":      toolName,
 		"arguments": args,
 	}
 
-	result, err := c.transport.Call(ctx, "tools/call", params)
+	result, err := c.call(ctx, "tools/call", params)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +263,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 
 // ListTools returns available tools from the server
 func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
-	result, err := c.transport.Call(ctx, "tools/list", nil)
+	result, err := c.call(ctx, "tools/list", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +275,44 @@ func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
 		return nil, err
 	}
 
+	c.toolsMu.Lock()
+	c.tools = make(map[string]Tool, len(response.Tools))
+	for _, t := range response.Tools {
+		c.tools[t.Name] = t
+	}
+	c.toolsLoaded = true
+	c.toolsMu.Unlock()
+
 	return response.Tools, nil
 }
 
+// lookupTool returns the cached schema for a tool, fetching the tool list
+// if needed. Validation is skipped when the list can't be fetched; the
+// fetch is retried once toolsRetryDelay has passed.
+func (c *Client) lookupTool(ctx context.Context, name string) (Tool, bool) {
+	c.toolsMu.Lock()
+	loaded := c.toolsLoaded
+	retryAt := c.toolsRetryAt
+	c.toolsMu.Unlock()
+
+	if !loaded {
+		if time.Now().Before(retryAt) {
+			return Tool{}, false
+		}
+		if _, err := c.ListTools(ctx); err != nil {
+			c.toolsMu.Lock()
+			c.toolsRetryAt = time.Now().Add(toolsRetryDelay) // Don't retry on every call
+			c.toolsMu.Unlock()
+			return Tool{}, false
+		}
+	}
+
+	c.toolsMu.Lock()
+	defer c.toolsMu.Unlock()
+	tool, ok := c.tools[name]
+	return tool, ok
+}
+
 // Tool represents an MCP tool definition
 type Tool struct {
 	Name        string                 `json:"name"`
@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyTransport loses the connection on the first call of each method in
+// failOnce, then answers every call with an empty object
+type flakyTransport struct {
+	mu       sync.Mutex
+	failOnce map[string]bool
+	calls    map[string]int
+	restarts int
+}
+
+func (t *flakyTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls[method]++
+	if t.failOnce[method] {
+		t.failOnce[method] = false
+		return nil, io.EOF
+	}
+	return json.RawMessage(`{}`), nil
+}
+
+func (t *flakyTransport) Restart() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.restarts++
+	return nil
+}
+
+func (t *flakyTransport) Close() error {
+	return nil
+}
+
+// newFlakyClient returns a client over a transport that drops the first
+// call of each method in failOnce, with no reconnect delay
+func newFlakyClient(failOnce ...string) (*Client, *flakyTransport) {
+	tr := &flakyTransport{failOnce: make(map[string]bool), calls: make(map[string]int)}
+	for _, method := range failOnce {
+		tr.failOnce[method] = true
+	}
+	c := NewClient(tr, "test")
+	c.backoff = func(int) time.Duration { return 0 }
+	return c, tr
+}
+
+func TestCallRetriesReadOnlyMethods(t *testing.T) {
+	c, tr := newFlakyClient("tools/list")
+
+	if _, err := c.ListTools(context.Background()); err != nil {
+		t.Fatalf("ListTools() = %v, want a retried success", err)
+	}
+	if tr.calls["tools/list"] != 2 || tr.restarts != 1 {
+		t.Errorf("tools/list sent %d times with %d restarts, want 2 and 1", tr.calls["tools/list"], tr.restarts)
+	}
+}
+
+func TestCallDoesNotRetryToolCalls(t *testing.T) {
+	c, tr := newFlakyClient("tools/call")
+
+	if _, err := c.call(context.Background(), "tools/call", map[string]interface{}{"name": "add_todo"}); err == nil {
+		t.Fatal("tools/call after a lost connection succeeded, want the connection error")
+	}
+	if tr.calls["tools/call"] != 1 {
+		t.Errorf("tools/call sent %d times, want 1", tr.calls["tools/call"])
+	}
+	if tr.restarts != 1 || !c.IsHealthy() {
+		t.Errorf("restarts = %d, healthy = %v; want the server restarted", tr.restarts, c.IsHealthy())
+	}
+}
+
+func TestHealthCheckWatchers(t *testing.T) {
+	c, _ := newFlakyClient("ping")
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	first := c.HealthCheck(ctx1, time.Hour)
+	second := c.HealthCheck(ctx2, time.Hour)
+
+	// Stopping the first watcher must leave the second subscribed
+	cancel1()
+	for range first {
+	}
+
+	if _, err := c.call(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("ping = %v", err)
+	}
+	for _, want := range []error{ErrReconnecting, nil} {
+		select {
+		case got := <-second:
+			if got != want {
+				t.Errorf("event = %v, want %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("second watcher got no %v event", want)
+		}
+	}
+}
+
+// schemaTransport fails tools/list while listErr is set and otherwise
+// lists one tool that requires a title
+type schemaTransport struct {
+	listErr error
+	lists   int
+}
+
+func (t *schemaTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if method == "tools/list" {
+		t.lists++
+		if t.listErr != nil {
+			return nil, t.listErr
+		}
+		return json.RawMessage(`{"tools": [{"name": "add_todo", "inputSchema": {"required": ["title"]}}]}`), nil
+	}
+	return json.RawMessage(`{"content": []}`), nil
+}
+
+func (t *schemaTransport) Close() error {
+	return nil
+}
+
+func TestCallToolRetriesFailedToolList(t *testing.T) {
+	tr := &schemaTransport{listErr: errors.New("server busy")}
+	c := NewClient(tr, "test")
+	ctx := context.Background()
+
+	// Without a schema the call goes through unvalidated
+	if _, err := c.CallTool(ctx, "add_todo", map[string]interface{}{}); err != nil {
+		t.Fatalf("CallTool() without a tool list = %v", err)
+	}
+	// Within the retry delay the list isn't refetched
+	if _, err := c.CallTool(ctx, "add_todo", map[string]interface{}{}); err != nil || tr.lists != 1 {
+		t.Fatalf("CallTool() = %v after %d lists, want no refetch", err, tr.lists)
+	}
+
+	tr.listErr = nil
+	c.toolsRetryAt = time.Time{}
+	_, err := c.CallTool(ctx, "add_todo", map[string]interface{}{})
+	var invalid *ErrInvalidArgs
+	if !errors.As(err, &invalid) {
+		t.Fatalf("CallTool() after the list loaded = %v, want *ErrInvalidArgs", err)
+	}
+	if tr.lists != 2 {
+		t.Errorf("tools/list sent %d times, want 2", tr.lists)
+	}
+}
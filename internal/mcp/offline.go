@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type offlineKey struct{}
+
+// Offline records whether any call made with a context was answered from
+// data saved before the server became unreachable, and how old it is
+type Offline struct {
+	mu        sync.Mutex
+	fetchedAt time.Time
+}
+
+// WithOffline returns a context whose tool calls report offline answers to
+// the returned Offline
+func WithOffline(ctx context.Context) (context.Context, *Offline) {
+	o := &Offline{}
+	return context.WithValue(ctx, offlineKey{}, o), o
+}
+
+// MarkOffline records that a call made with ctx was answered with data
+// fetched at fetchedAt. It does nothing if ctx isn't tracked.
+func MarkOffline(ctx context.Context, fetchedAt time.Time) {
+	o, ok := ctx.Value(offlineKey{}).(*Offline)
+	if !ok {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// Several calls can be combined into one view; report the oldest
+	if o.fetchedAt.IsZero() || fetchedAt.Before(o.fetchedAt) {
+		o.fetchedAt = fetchedAt
+	}
+}
+
+// FetchedAt returns when the data was fetched and whether any of it was
+// offline. It returns the current time if every call reached its server.
+func (o *Offline) FetchedAt() (time.Time, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.fetchedAt.IsZero() {
+		return time.Now(), false
+	}
+	return o.fetchedAt, true
+}
+
+type noCacheKey struct{}
+
+// WithoutCache returns a context whose tool calls always go to the server:
+// caching clients neither answer them from the cache nor fall back to an
+// offline snapshot when the server is unreachable
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// CacheBypassed reports whether ctx was made by WithoutCache
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
@@ -6,19 +6,52 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 )
 
 // CalendarEvent represents a calendar event
 type CalendarEvent struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	Location  string    `json:"location,omitempty"`
-	Notes     string    `json:"notes,omitempty"`
-	Calendar  string    `json:"calendar,omitempty"`
-	AllDay    bool      `json:"all_day"`
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	StartTime  time.Time  `json:"start_time"`
+	EndTime    time.Time  `json:"end_time"`
+	Location   string     `json:"location,omitempty"`
+	Notes      string     `json:"notes,omitempty"`
+	Calendar   string     `json:"calendar,omitempty"`
+	CalendarID string     `json:"calendar_id,omitempty"` // Source calendar the event was fetched from
+	AllDay     bool       `json:"all_day"`
+	Attendees  []Attendee `json:"attendees,omitempty"`
+}
+
+// Attendee is a person invited to an event
+type Attendee struct {
+	Name           string `json:"name,omitempty"`
+	Email          string `json:"email"`
+	ResponseStatus string `json:"response_status,omitempty"` // accepted, tentative, declined, needsAction
+	Self           bool   `json:"self,omitempty"`            // The attendee is the current user
+}
+
+// SelfAttendee returns the current user's attendee entry, if invited
+func (e CalendarEvent) SelfAttendee() (*Attendee, bool) {
+	for i := range e.Attendees {
+		if e.Attendees[i].Self {
+			return &e.Attendees[i], true
+		}
+	}
+	return nil, false
+}
+
+// RSVP response statuses accepted by RSVPUpdater
+const (
+	RSVPAccepted  = "accepted"
+	RSVPDeclined  = "declined"
+	RSVPTentative = "tentative"
+)
+
+// RSVPUpdater is implemented by providers that can answer invitations
+type RSVPUpdater interface {
+	UpdateRSVP(ctx context.Context, eventID, status string) error
 }
 
 // CalendarProviderInterface defines the calendar provider contract
@@ -26,36 +59,84 @@ type CalendarProviderInterface interface {
 	GetTodayEvents(ctx context.Context) ([]CalendarEvent, error)
 	GetUpcomingEvents(ctx context.Context, days int) ([]CalendarEvent, error)
 	GetEventsInRange(ctx context.Context, start, end time.Time) ([]CalendarEvent, error)
+	CreateEvent(ctx context.Context, e CreateEventInput) (CalendarEvent, error)
 	Close() error
 }
 
+// CreateEventInput describes a timed event to create
+type CreateEventInput struct {
+	Title        string
+	StartTime    time.Time
+	EndTime      time.Time
+	CalendarName string // Empty means the default calendar
+	Location     string
+	Notes        string
+}
+
+// todayCacheTTL is how long today's Apple Calendar events are reused
+const todayCacheTTL = 60 * time.Second
+
 // AppleCalendarProvider reads from Apple Calendar via AppleScript
-type AppleCalendarProvider struct{}
+type AppleCalendarProvider struct {
+	timeFunc     func() time.Time // Injectable clock for cache expiry
+	homeTimezone *time.Location   // Event times are reported in this zone
+
+	// fetchRange loads events for GetTodayEvents; nil means GetEventsInRange.
+	// Tests replace it to avoid running icalBuddy.
+	fetchRange func(ctx context.Context, start, end time.Time) ([]CalendarEvent, error)
+
+	mu          sync.Mutex
+	todayCache  []CalendarEvent
+	todayCached time.Time
+}
 
 // NewAppleCalendarProvider creates a new Apple Calendar provider
-func NewAppleCalendarProvider() *AppleCalendarProvider {
-	return &AppleCalendarProvider{}
+func NewAppleCalendarProvider(opts ...CalendarOption) *AppleCalendarProvider {
+	o := newCalendarOptions(opts)
+	return &AppleCalendarProvider{timeFunc: time.Now, homeTimezone: o.homeTimezone}
 }
 
-// GetTodayEvents returns events for today
+// GetTodayEvents returns events for today, cached for todayCacheTTL
 func (p *AppleCalendarProvider) GetTodayEvents(ctx context.Context) ([]CalendarEvent, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	now := p.timeFunc()
+
+	p.mu.Lock()
+	if !p.todayCached.IsZero() && now.Sub(p.todayCached) < todayCacheTTL {
+		cached := p.todayCache
+		p.mu.Unlock()
+		return cached, nil
+	}
+	p.mu.Unlock()
+
+	fetch := p.fetchRange
+	if fetch == nil {
+		fetch = p.GetEventsInRange
+	}
+	start := startOfDay(now, p.homeTimezone)
+	events, err := fetch(ctx, start, start.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.todayCache = events
+	p.todayCached = now
+	p.mu.Unlock()
 
-	return p.GetEventsInRange(ctx, startOfDay, endOfDay)
+	return events, nil
 }
 
 // GetUpcomingEvents returns events for the next N days
 func (p *AppleCalendarProvider) GetUpcomingEvents(ctx context.Context, days int) ([]CalendarEvent, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endDate := startOfDay.Add(time.Duration(days) * 24 * time.Hour)
-
-	return p.GetEventsInRange(ctx, startOfDay, endDate)
+	start := startOfDay(time.Now(), p.homeTimezone)
+	return p.GetEventsInRange(ctx, start, start.AddDate(0, 0, days))
 }
 
-// GetEventsInRange returns events between two dates
+// icalBuddyDateLayout is how ranges are passed to icalBuddy, which reads
+// dates with an explicit offset unambiguously
+const icalBuddyDateLayout = "2006-01-02 15:04:05 -0700"
+
+// GetEventsInRange returns events starting between two dates
 func (p *AppleCalendarProvider) GetEventsInRange(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
 	// Use icalBuddy for fast calendar access (brew install ical-buddy)
 	// Fall back to simple AppleScript if not available
@@ -64,12 +145,13 @@ func (p *AppleCalendarProvider) GetEventsInRange(ctx context.Context, start, end
 		"-ps", "|",
 		"-po", "datetime,title,location",
 		"-tf", "%H:%M",
-		"-df", "",
-		"eventsToday")
+		"-df", "%Y-%m-%d",
+		"eventsFrom:"+start.Format(icalBuddyDateLayout),
+		"to:"+end.Format(icalBuddyDateLayout))
 
 	output, err := cmd.Output()
 	if err == nil && len(output) > 0 {
-		return p.parseIcalBuddyOutput(string(output))
+		return p.parseIcalBuddyOutput(string(output), time.Local, end), nil
 	}
 
 	// Fallback: AppleScript to query ALL calendars
@@ -77,27 +159,25 @@ func (p *AppleCalendarProvider) GetEventsInRange(ctx context.Context, start, end
 	launchCmd := exec.CommandContext(ctx, "open", "-ga", "Calendar")
 	launchCmd.Run() // Ignore errors - Calendar might already be running
 
-	script := `
+	script := appleDate("rangeStart", start) + "\n" + appleDate("rangeEnd", end) + `
 set output to "["
 set isFirst to true
-set todayDate to current date
-set todayStart to todayDate - (time of todayDate)
-set todayEnd to todayStart + (1 * days)
 
 tell application "Calendar"
 	repeat with cal in calendars
 		set calName to name of cal
 		try
-			set eventList to (every event of cal whose start date >= todayStart and start date < todayEnd)
+			set eventList to (every event of cal whose start date >= rangeStart and start date < rangeEnd)
 			repeat with evt in eventList
 				if not isFirst then set output to output & ","
 				set isFirst to false
 				set evtTitle to summary of evt
 				set evtStart to start date of evt
-				set startHour to hours of evtStart
-				set startMin to minutes of evtStart
+				set evtEnd to end date of evt
+				set startDay to ((year of evtStart) as string) & "-" & ((month of evtStart as integer) as string) & "-" & ((day of evtStart) as string)
+				set endDay to ((year of evtEnd) as string) & "-" & ((month of evtEnd as integer) as string) & "-" & ((day of evtEnd) as string)
 				set evtAllDay to allday event of evt
-				set evtJSON to "{\"title\":\"" & evtTitle & "\",\"start_hour\":" & startHour & ",\"start_min\":" & startMin & ",\"all_day\":" & evtAllDay & ",\"calendar\":\"" & calName & "\",\"location\":\"\",\"end_hour\":0,\"end_min\":0}"
+				set evtJSON to "{\"title\":\"" & evtTitle & "\",\"start_date\":\"" & startDay & "\",\"start_hour\":" & (hours of evtStart) & ",\"start_min\":" & (minutes of evtStart) & ",\"end_date\":\"" & endDay & "\",\"end_hour\":" & (hours of evtEnd) & ",\"end_min\":" & (minutes of evtEnd) & ",\"all_day\":" & evtAllDay & ",\"calendar\":\"" & calName & "\",\"location\":\"\"}"
 				set output to output & evtJSON
 			end repeat
 		end try
@@ -111,13 +191,20 @@ return output & "]"
 	if err != nil {
 		return nil, fmt.Errorf("failed to run AppleScript: %w", err)
 	}
+	return p.parseAppleScriptOutput(output, time.Local)
+}
 
-	// Parse JSON output
+// parseAppleScriptOutput converts the JSON built by the AppleScript
+// fallback. Calendar reports wall-clock dates and times in the system
+// zone loc, so the instants are built there and converted to the home zone.
+func (p *AppleCalendarProvider) parseAppleScriptOutput(output []byte, loc *time.Location) ([]CalendarEvent, error) {
 	var rawEvents []struct {
 		Title     string `json:"title"`
 		Calendar  string `json:"calendar"`
+		StartDate string `json:"start_date"` // Y-M-D, not zero-padded
 		StartHour int    `json:"start_hour"`
 		StartMin  int    `json:"start_min"`
+		EndDate   string `json:"end_date"`
 		EndHour   int    `json:"end_hour"`
 		EndMin    int    `json:"end_min"`
 		Location  string `json:"location"`
@@ -128,86 +215,210 @@ return output & "]"
 		return nil, fmt.Errorf("failed to parse calendar events: %w (output: %s)", err, string(output))
 	}
 
-	// Convert to CalendarEvent
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	events := make([]CalendarEvent, len(rawEvents))
-	for i, raw := range rawEvents {
-		startTime := today.Add(time.Duration(raw.StartHour)*time.Hour + time.Duration(raw.StartMin)*time.Minute)
-		endTime := today.Add(time.Duration(raw.EndHour)*time.Hour + time.Duration(raw.EndMin)*time.Minute)
+	events := make([]CalendarEvent, 0, len(rawEvents))
+	for _, raw := range rawEvents {
+		startDay, err := time.ParseInLocation("2006-1-2", raw.StartDate, loc)
+		if err != nil {
+			continue
+		}
+		endDay, err := time.ParseInLocation("2006-1-2", raw.EndDate, loc)
+		if err != nil {
+			endDay = startDay
+		}
 
-		events[i] = CalendarEvent{
-			ID:        fmt.Sprintf("%s-%d", raw.Title, raw.StartHour*100+raw.StartMin),
-			Title:     raw.Title,
-			StartTime: startTime,
-			EndTime:   endTime,
-			Location:  raw.Location,
-			Calendar:  raw.Calendar,
-			AllDay:    raw.AllDay,
+		event := CalendarEvent{
+			ID:       fmt.Sprintf("%s-%s-%d", raw.Title, raw.StartDate, raw.StartHour*100+raw.StartMin),
+			Title:    raw.Title,
+			Location: raw.Location,
+			Calendar: raw.Calendar,
+			AllDay:   raw.AllDay,
+		}
+		if raw.AllDay {
+			// Dates, not instants, as the Google Calendar provider reports them
+			event.StartTime = civilDate(startDay)
+			event.EndTime = civilDate(endDay)
+		} else {
+			event.StartTime = startDay.Add(time.Duration(raw.StartHour)*time.Hour + time.Duration(raw.StartMin)*time.Minute)
+			event.EndTime = endDay.Add(time.Duration(raw.EndHour)*time.Hour + time.Duration(raw.EndMin)*time.Minute)
 		}
+		events = append(events, inTimezone(event, p.homeTimezone))
 	}
 
 	return events, nil
 }
 
-// parseIcalBuddyOutput parses icalBuddy output format
-func (p *AppleCalendarProvider) parseIcalBuddyOutput(output string) ([]CalendarEvent, error) {
+// parseIcalBuddyOutput parses icalBuddy's "datetime|title|location"
+// lines. datetime is "2026-03-10 at 09:00 - 10:00" for a timed event (the
+// end carries its own date when it falls on another day) and "2026-03-10"
+// or "2026-03-10 - 2026-03-12" for an all-day one, in the system zone loc.
+// icalBuddy also lists events still running at the start of the range, but
+// events starting at or after end are dropped.
+func (p *AppleCalendarProvider) parseIcalBuddyOutput(output string, loc *time.Location, end time.Time) []CalendarEvent {
 	var events []CalendarEvent
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
+	for _, line := range strings.Split(output, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
 
-		// Format: "HH:MM|Title|Location" or "Title|Location" for all-day
 		parts := strings.SplitN(line, "|", 3)
-		if len(parts) < 2 {
+		if len(parts) < 2 || parts[1] == "" {
+			continue
+		}
+		startAt, endAt, allDay, ok := parseIcalBuddyDateTime(parts[0], loc)
+		if !ok {
 			continue
 		}
 
-		var event CalendarEvent
-		event.ID = fmt.Sprintf("%d-%s", len(events), parts[0])
-
-		// Check if first part is time
-		if len(parts[0]) == 5 && strings.Contains(parts[0], ":") {
-			// Timed event
-			timeParts := strings.Split(parts[0], ":")
-			if len(timeParts) == 2 {
-				hour, _ := fmt.Sscanf(timeParts[0], "%d", new(int))
-				min, _ := fmt.Sscanf(timeParts[1], "%d", new(int))
-				if hour > 0 && min >= 0 {
-					var h, m int
-					fmt.Sscanf(parts[0], "%d:%d", &h, &m)
-					event.StartTime = today.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
-				}
-			}
-			event.Title = parts[1]
-			if len(parts) > 2 {
-				event.Location = parts[2]
-			}
-		} else {
-			// All-day event
-			event.AllDay = true
-			event.Title = parts[0]
-			if len(parts) > 1 {
-				event.Location = parts[1]
+		event := CalendarEvent{
+			ID:        fmt.Sprintf("%s-%s", parts[0], parts[1]),
+			Title:     parts[1],
+			StartTime: startAt,
+			EndTime:   endAt,
+			AllDay:    allDay,
+		}
+		if len(parts) > 2 {
+			event.Location = parts[2]
+		}
+
+		// Compare all-day dates as days in end's zone
+		first := startAt
+		if allDay {
+			first = time.Date(startAt.Year(), startAt.Month(), startAt.Day(), 0, 0, 0, 0, end.Location())
+		}
+		if !first.Before(end) {
+			continue
+		}
+		events = append(events, inTimezone(event, p.homeTimezone))
+	}
+
+	return events
+}
+
+// parseIcalBuddyDateTime parses the datetime property described at
+// parseIcalBuddyOutput
+func parseIcalBuddyDateTime(s string, loc *time.Location) (start, end time.Time, allDay, ok bool) {
+	from, to, hasEnd := strings.Cut(s, " - ")
+
+	startDate, startClock, timed := strings.Cut(strings.TrimSpace(from), " at ")
+	day, err := time.ParseInLocation(DateLayout, startDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, false
+	}
+
+	if !timed {
+		start = civilDate(day)
+		end = start
+		if hasEnd {
+			if last, err := time.ParseInLocation(DateLayout, strings.TrimSpace(to), loc); err == nil {
+				end = civilDate(last)
 			}
 		}
+		return start, end, true, true
+	}
 
-		if event.Title != "" {
-			events = append(events, event)
+	start, err = atClock(day, startClock)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, false
+	}
+	end = start
+	if hasEnd {
+		endDay, endClock := day, strings.TrimSpace(to)
+		if d, c, ok := strings.Cut(endClock, " at "); ok {
+			if parsed, err := time.ParseInLocation(DateLayout, d, loc); err == nil {
+				endDay, endClock = parsed, c
+			}
+		}
+		if t, err := atClock(endDay, endClock); err == nil {
+			end = t
 		}
 	}
+	return start, end, false, true
+}
 
-	return events, nil
+// atClock is the wall-clock time "HH:MM" on day, in day's zone
+func atClock(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
+
+// civilDate is t's date as midnight UTC, the form all-day events use
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CreateEvent adds a timed event to Apple Calendar and returns it with
+// the UID Calendar assigned
+func (p *AppleCalendarProvider) CreateEvent(ctx context.Context, e CreateEventInput) (CalendarEvent, error) {
+	target := "first calendar whose writable is true"
+	if e.CalendarName != "" {
+		target = "first calendar whose name is " + appleQuote(e.CalendarName)
+	}
+
+	script := fmt.Sprintf(`
+%s
+%s
+tell application "Calendar"
+	set targetCal to %s
+	tell targetCal
+		set newEvent to make new event at end of events with properties {summary:%s, start date:startDate, end date:endDate, location:%s, description:%s}
+	end tell
+	return uid of newEvent
+end tell
+`, appleDate("startDate", e.StartTime), appleDate("endDate", e.EndTime), target,
+		appleQuote(e.Title), appleQuote(e.Location), appleQuote(e.Notes))
+
+	output, err := exec.CommandContext(ctx, "osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to create event: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	uid := strings.TrimSpace(string(output))
+	if uid == "" {
+		return CalendarEvent{}, fmt.Errorf("failed to create event: Calendar returned no UID")
+	}
+
+	// Today's cached events no longer include everything
+	p.mu.Lock()
+	p.todayCached = time.Time{}
+	p.mu.Unlock()
+
+	return CalendarEvent{
+		ID:        uid,
+		Title:     e.Title,
+		StartTime: e.StartTime,
+		EndTime:   e.EndTime,
+		Location:  e.Location,
+		Notes:     e.Notes,
+		Calendar:  e.CalendarName,
+	}, nil
+}
+
+// appleDate builds an AppleScript date variable for t in local time. The
+// day is reset first so changing the month can't overflow (Jan 31 -> Feb).
+func appleDate(name string, t time.Time) string {
+	t = t.Local()
+	return fmt.Sprintf(`set %[1]s to current date
+set day of %[1]s to 1
+set year of %[1]s to %[2]d
+set month of %[1]s to %[3]d
+set day of %[1]s to %[4]d
+set time of %[1]s to %[5]d`, name, t.Year(), int(t.Month()), t.Day(), t.Hour()*3600+t.Minute()*60+t.Second())
+}
+
+// appleQuote renders s as an AppleScript string literal
+func appleQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
 }
 
 // Close is a no-op for the calendar provider
 func (p *AppleCalendarProvider) Close() error {
 	return nil
 }
+
+var _ CalendarProviderInterface = (*AppleCalendarProvider)(nil)
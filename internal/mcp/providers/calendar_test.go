@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAppleGetTodayEventsCache(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	fetches := 0
+
+	p := NewAppleCalendarProvider(WithTimezone("UTC"))
+	p.timeFunc = func() time.Time { return now }
+	p.fetchRange = func(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+		fetches++
+		if want := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC); !start.Equal(want) || !end.Equal(want.AddDate(0, 0, 1)) {
+			t.Errorf("fetched %v to %v, want today", start, end)
+		}
+		return []CalendarEvent{{ID: "standup", Title: "Standup", StartTime: now}}, nil
+	}
+
+	first, err := p.GetTodayEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(todayCacheTTL - time.Second)
+	second, err := p.GetTodayEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 1 {
+		t.Errorf("fetched %d times within the TTL, want 1", fetches)
+	}
+	if &first[0] != &second[0] {
+		t.Error("second call within the TTL returned a different slice")
+	}
+
+	now = now.Add(time.Second)
+	third, err := p.GetTodayEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 2 {
+		t.Errorf("fetched %d times after the TTL, want 2", fetches)
+	}
+	if &first[0] == &third[0] {
+		t.Error("call after the TTL returned the cached slice")
+	}
+}
+
+func TestParseIcalBuddyOutput(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(day, hour, min int) time.Time { return time.Date(2026, time.March, day, hour, min, 0, 0, ny) }
+	date := func(day int) time.Time { return time.Date(2026, time.March, day, 0, 0, 0, 0, time.UTC) }
+	end := at(12, 0, 0) // Range is March 10 and 11
+
+	output := `2026-03-10 at 09:00 - 09:30|Standup|Room 4
+2026-03-11 at 14:00 - 15:15|Review
+2026-03-10|Holiday
+2026-03-09 - 2026-03-11|Offsite|Lisbon
+2026-03-11 at 23:00 - 2026-03-12 at 01:00|Late deploy
+2026-03-12 at 09:00 - 09:30|Tomorrow's standup
+2026-03-12|Tomorrow's holiday
+09:00 - 10:00|No date
+2026-03-10 at 9am|Bad clock
+
+2026-03-10 at 10:00 - 11:00|`
+
+	p := NewAppleCalendarProvider(WithTimezone("UTC"))
+	events := p.parseIcalBuddyOutput(output, ny, end)
+
+	want := []CalendarEvent{
+		{Title: "Standup", Location: "Room 4", StartTime: at(10, 9, 0), EndTime: at(10, 9, 30)},
+		{Title: "Review", StartTime: at(11, 14, 0), EndTime: at(11, 15, 15)},
+		{Title: "Holiday", StartTime: date(10), EndTime: date(10), AllDay: true},
+		{Title: "Offsite", Location: "Lisbon", StartTime: date(9), EndTime: date(11), AllDay: true},
+		{Title: "Late deploy", StartTime: at(11, 23, 0), EndTime: at(12, 1, 0)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events %+v, want %d", len(events), events, len(want))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Title != w.Title || e.Location != w.Location || e.AllDay != w.AllDay ||
+			!e.StartTime.Equal(w.StartTime) || !e.EndTime.Equal(w.EndTime) {
+			t.Errorf("event %d = %+v, want %+v", i, e, w)
+		}
+		if !e.AllDay && e.StartTime.Location() != time.UTC {
+			t.Errorf("event %d is in %v, want the home zone UTC", i, e.StartTime.Location())
+		}
+		if e.ID == "" {
+			t.Errorf("event %d has no ID", i)
+		}
+	}
+}
+
+func TestParseAppleScriptOutput(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	output := `[
+		{"title":"Standup","start_date":"2026-3-10","start_hour":9,"start_min":5,"end_date":"2026-3-10","end_hour":9,"end_min":30,"all_day":false,"calendar":"Work","location":""},
+		{"title":"Holiday","start_date":"2026-3-11","start_hour":0,"start_min":0,"end_date":"2026-3-12","end_hour":0,"end_min":0,"all_day":true,"calendar":"Home","location":""},
+		{"title":"Broken","start_date":"","start_hour":0,"start_min":0,"all_day":false,"calendar":"Work","location":""}
+	]`
+
+	p := NewAppleCalendarProvider(WithTimezone("UTC"))
+	events, err := p.parseAppleScriptOutput([]byte(output), ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2 (the undated one dropped)", len(events))
+	}
+
+	standup := events[0]
+	if want := time.Date(2026, time.March, 10, 13, 5, 0, 0, time.UTC); !standup.StartTime.Equal(want) || standup.StartTime.Location() != time.UTC {
+		t.Errorf("StartTime = %v, want %v", standup.StartTime, want)
+	}
+	if standup.EndTime.Sub(standup.StartTime) != 25*time.Minute || standup.Calendar != "Work" {
+		t.Errorf("standup = %+v", standup)
+	}
+
+	holiday := events[1]
+	if !holiday.AllDay || !holiday.StartTime.Equal(time.Date(2026, time.March, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("holiday = %+v", holiday)
+	}
+
+	if _, err := p.parseAppleScriptOutput([]byte("execution error"), ny); err == nil {
+		t.Error("parseAppleScriptOutput() accepted non-JSON output")
+	}
+}
@@ -0,0 +1,37 @@
+package providers
+
+import "sort"
+
+// ConflictPair is two timed events that overlap, A starting first
+type ConflictPair struct {
+	A CalendarEvent `json:"a"`
+	B CalendarEvent `json:"b"`
+}
+
+// FindConflicts returns every pair of timed events whose times overlap.
+// All-day events are ignored, and back-to-back events (one ending as the
+// next starts) don't conflict.
+func FindConflicts(events []CalendarEvent) []ConflictPair {
+	var timed []CalendarEvent
+	for _, e := range events {
+		if !e.AllDay {
+			timed = append(timed, e)
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].StartTime.Before(timed[j].StartTime)
+	})
+
+	var pairs []ConflictPair
+	for i, a := range timed {
+		for _, b := range timed[i+1:] {
+			if !b.StartTime.Before(a.EndTime) {
+				break
+			}
+			if a.StartTime.Before(b.EndTime) {
+				pairs = append(pairs, ConflictPair{A: a, B: b})
+			}
+		}
+	}
+	return pairs
+}
@@ -4,51 +4,105 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/szoloth/partner/internal/mcp"
+
+	"golang.org/x/sync/errgroup"
 )
 
+// primaryCalendarID is the calendar read when none are configured
+const primaryCalendarID = "primary"
+
 // GCalProvider reads from Google Calendar via MCP
 type GCalProvider struct {
-	client *mcp.Client
+	client       mcp.ToolCaller
+	calendarIDs  []string
+	userEmail    string         // Marks matching attendees as Self
+	homeTimezone *time.Location // Event times are reported in this zone
+
+	// Calendar each listed event came from, for RSVP updates
+	mu             sync.Mutex
+	eventCalendars map[string]string
+}
+
+// NewGCalProvider creates a new Google Calendar provider reading the given
+// calendars, or just the primary calendar when calendarIDs is empty
+func NewGCalProvider(client mcp.ToolCaller, calendarIDs []string, opts ...CalendarOption) *GCalProvider {
+	o := newCalendarOptions(opts)
+	return &GCalProvider{
+		client:         client,
+		calendarIDs:    calendarIDs,
+		homeTimezone:   o.homeTimezone,
+		eventCalendars: make(map[string]string),
+	}
 }
 
-// NewGCalProvider creates a new Google Calendar provider
-func NewGCalProvider(client *mcp.Client) *GCalProvider {
-	return &GCalProvider{client: client}
+// SetUserEmail identifies the current user among attendees, for servers
+// that don't flag the "self" attendee
+func (p *GCalProvider) SetUserEmail(email string) {
+	p.userEmail = email
 }
 
 // GetTodayEvents returns events for today
 func (p *GCalProvider) GetTodayEvents(ctx context.Context) ([]CalendarEvent, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
-
-	return p.GetEventsInRange(ctx, startOfDay, endOfDay)
+	start := startOfDay(time.Now(), p.homeTimezone)
+	return p.GetEventsInRange(ctx, start, start.AddDate(0, 0, 1))
 }
 
 // GetUpcomingEvents returns events for the next N days
 func (p *GCalProvider) GetUpcomingEvents(ctx context.Context, days int) ([]CalendarEvent, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endDate := startOfDay.Add(time.Duration(days) * 24 * time.Hour)
-
-	return p.GetEventsInRange(ctx, startOfDay, endDate)
+	start := startOfDay(time.Now(), p.homeTimezone)
+	return p.GetEventsInRange(ctx, start, start.AddDate(0, 0, days))
 }
 
-// GetEventsInRange returns events between two dates
+// GetEventsInRange returns events between two dates from every
+// configured calendar, merged in start order
 func (p *GCalProvider) GetEventsInRange(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
-	// Format times for Google Calendar API
-	timeMin := start.Format("2006-01-02T15:04:05")
-	timeMax := end.Format("2006-01-02T15:04:05")
+	if len(p.calendarIDs) == 0 {
+		return p.listEvents(ctx, primaryCalendarID, start, end)
+	}
 
-	// Call list-events with "primary" calendar
+	results := make([][]CalendarEvent, len(p.calendarIDs))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, id := range p.calendarIDs {
+		g.Go(func() error {
+			events, err := p.listEvents(gctx, id, start, end)
+			if err != nil {
+				return fmt.Errorf("calendar %s: %w", id, err)
+			}
+			// Label events by the configured calendar so they can be told apart
+			for j := range events {
+				events[j].Calendar = id
+			}
+			results[i] = events
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var merged []CalendarEvent
+	for _, events := range results {
+		merged = append(merged, events...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].StartTime.Before(merged[j].StartTime)
+	})
+	return merged, nil
+}
+
+// listEvents calls list-events for a single calendar
+func (p *GCalProvider) listEvents(ctx context.Context, calendarID string, start, end time.Time) ([]CalendarEvent, error) {
+	// The offset is required; without one the server guesses the zone
 	args := map[string]interface{}{
-		"calendarId": "primary",
-		"timeMin":    timeMin,
-		"timeMax":    timeMax,
+		"calendarId": calendarID,
+		"timeMin":    start.Format(time.RFC3339),
+		"timeMax":    end.Format(time.RFC3339),
 	}
 
 	result, err := p.client.CallTool(ctx, "list-events", args)
@@ -56,24 +110,116 @@ func (p *GCalProvider) GetEventsInRange(ctx context.Context, start, end time.Tim
 		return nil, fmt.Errorf("list-events failed: %w", err)
 	}
 
-	return p.parseEvents(result)
+	events, err := p.parseEvents(result)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	for i := range events {
+		events[i].CalendarID = calendarID
+		p.eventCalendars[events[i].ID] = calendarID
+	}
+	p.mu.Unlock()
+	return events, nil
+}
+
+// UpdateRSVP sets the current user's response to an event
+func (p *GCalProvider) UpdateRSVP(ctx context.Context, eventID, status string) error {
+	switch status {
+	case RSVPAccepted, RSVPDeclined, RSVPTentative:
+	default:
+		return fmt.Errorf("invalid RSVP status %q", status)
+	}
+
+	p.mu.Lock()
+	calendarID, ok := p.eventCalendars[eventID]
+	p.mu.Unlock()
+	if !ok {
+		calendarID = primaryCalendarID
+	}
+
+	args := map[string]interface{}{
+		"calendarId":     calendarID,
+		"eventId":        eventID,
+		"responseStatus": status,
+	}
+	result, err := p.client.CallTool(ctx, "update-event", args)
+	if err != nil {
+		return fmt.Errorf("update-event failed: %w", err)
+	}
+	if result.IsError {
+		return fmt.Errorf("update-event failed: %s", truncate(resultText(result), 200))
+	}
+	return nil
 }
 
-// parseEvents converts MCP tool result to CalendarEvents
-func (p *GCalProvider) parseEvents(result *mcp.ToolResult) ([]CalendarEvent, error) {
-	if len(result.Content) == 0 {
-		return []CalendarEvent{}, nil
+// CreateEvent adds a timed event to Google Calendar
+func (p *GCalProvider) CreateEvent(ctx context.Context, in CreateEventInput) (CalendarEvent, error) {
+	calendarID := in.CalendarName
+	if calendarID == "" || strings.EqualFold(calendarID, primaryCalendarID) {
+		calendarID = primaryCalendarID
+	}
+
+	args := map[string]interface{}{
+		"calendarId": calendarID,
+		"summary":    in.Title,
+		"start":      in.StartTime.Format(time.RFC3339),
+		"end":        in.EndTime.Format(time.RFC3339),
+	}
+	// The offset pins the instant; a named zone also gives the event its zone
+	if tz := in.StartTime.Location().String(); tz != "Local" && tz != "" {
+		args["timeZone"] = tz
+	}
+	if in.Location != "" {
+		args["location"] = in.Location
+	}
+	if in.Notes != "" {
+		args["description"] = in.Notes
 	}
 
-	// Get the text content
-	var text string
+	result, err := p.client.CallTool(ctx, "create-event", args)
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("create-event failed: %w", err)
+	}
+	if result.IsError {
+		return CalendarEvent{}, fmt.Errorf("create-event failed: %s", truncate(resultText(result), 200))
+	}
+
+	// Prefer the server's view of the event; fall back to what we sent
+	var created gcalEvent
+	if err := json.Unmarshal([]byte(resultText(result)), &created); err == nil && created.ID != "" {
+		event := created.toCalendarEvent()
+		event.CalendarID = calendarID
+		return event, nil
+	}
+	return CalendarEvent{
+		Title:      in.Title,
+		StartTime:  in.StartTime,
+		EndTime:    in.EndTime,
+		Location:   in.Location,
+		Notes:      in.Notes,
+		Calendar:   in.CalendarName,
+		CalendarID: calendarID,
+	}, nil
+}
+
+// resultText returns the first text block of a tool result
+func resultText(result *mcp.ToolResult) string {
 	for _, block := range result.Content {
 		if block.Type == "text" {
-			text = block.Text
-			break
+			return block.Text
 		}
 	}
+	return ""
+}
 
+// parseEvents converts MCP tool result to CalendarEvents
+func (p *GCalProvider) parseEvents(result *mcp.ToolResult) ([]CalendarEvent, error) {
+	if len(result.Content) == 0 {
+		return []CalendarEvent{}, nil
+	}
+
+	text := resultText(result)
 	if text == "" {
 		return []CalendarEvent{}, nil
 	}
@@ -94,52 +240,15 @@ func (p *GCalProvider) parseEvents(result *mcp.ToolResult) ([]CalendarEvent, err
 	// Convert to CalendarEvent
 	events := make([]CalendarEvent, 0, len(gcalEvents))
 	for _, ge := range gcalEvents {
-		event := CalendarEvent{
-			ID:       ge.ID,
-			Title:    ge.Summary,
-			Location: ge.Location,
-		}
-
-		// Parse start time
-		if ge.Start.DateTime != "" {
-			t, err := time.Parse(time.RFC3339, ge.Start.DateTime)
-			if err == nil {
-				event.StartTime = t
-			}
-		} else if ge.Start.Date != "" {
-			// All-day event
-			t, err := time.Parse("2006-01-02", ge.Start.Date)
-			if err == nil {
-				event.StartTime = t
-				event.AllDay = true
-			}
-		}
-
-		// Parse end time
-		if ge.End.DateTime != "" {
-			t, err := time.Parse(time.RFC3339, ge.End.DateTime)
-			if err == nil {
-				event.EndTime = t
-			}
-		} else if ge.End.Date != "" {
-			t, err := time.Parse("2006-01-02", ge.End.Date)
-			if err == nil {
-				event.EndTime = t
+		event := ge.toCalendarEvent()
+		if p.userEmail != "" {
+			for i := range event.Attendees {
+				if strings.EqualFold(event.Attendees[i].Email, p.userEmail) {
+					event.Attendees[i].Self = true
+				}
 			}
 		}
-
-		// Extract calendar name from organizer or set default
-		if ge.Organizer.DisplayName != "" {
-			event.Calendar = ge.Organizer.DisplayName
-		} else if ge.Organizer.Email != "" {
-			// Use email prefix as calendar name
-			parts := strings.Split(ge.Organizer.Email, "@")
-			event.Calendar = parts[0]
-		} else {
-			event.Calendar = "Primary"
-		}
-
-		events = append(events, event)
+		events = append(events, inTimezone(event, p.homeTimezone))
 	}
 
 	return events, nil
@@ -150,17 +259,83 @@ func (p *GCalProvider) Close() error {
 	return p.client.Close()
 }
 
+var (
+	_ CalendarProviderInterface = (*GCalProvider)(nil)
+	_ RSVPUpdater               = (*GCalProvider)(nil)
+)
+
 // gcalEvent represents a Google Calendar event from the API
 type gcalEvent struct {
-	ID        string         `json:"id"`
-	Summary   string         `json:"summary"`
-	Location  string         `json:"location,omitempty"`
-	Start     gcalDateTime   `json:"start"`
-	End       gcalDateTime   `json:"end"`
-	Status    string         `json:"status"`
-	HTMLLink  string         `json:"htmlLink"`
-	Organizer gcalOrganizer  `json:"organizer,omitempty"`
-	Attendees []gcalAttendee `json:"attendees,omitempty"`
+	ID          string         `json:"id"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description,omitempty"`
+	Location    string         `json:"location,omitempty"`
+	Start       gcalDateTime   `json:"start"`
+	End         gcalDateTime   `json:"end"`
+	Status      string         `json:"status"`
+	HTMLLink    string         `json:"htmlLink"`
+	Organizer   gcalOrganizer  `json:"organizer,omitempty"`
+	Attendees   []gcalAttendee `json:"attendees,omitempty"`
+}
+
+// toCalendarEvent converts the API representation to a CalendarEvent
+func (e gcalEvent) toCalendarEvent() CalendarEvent {
+	event := CalendarEvent{
+		ID:       e.ID,
+		Title:    e.Summary,
+		Location: e.Location,
+		Notes:    e.Description,
+	}
+
+	for _, a := range e.Attendees {
+		event.Attendees = append(event.Attendees, Attendee{
+			Name:           a.DisplayName,
+			Email:          a.Email,
+			ResponseStatus: a.ResponseStatus,
+			Self:           a.Self,
+		})
+	}
+
+	// Parse start time
+	if e.Start.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, e.Start.DateTime)
+		if err == nil {
+			event.StartTime = t
+		}
+	} else if e.Start.Date != "" {
+		// All-day event
+		t, err := time.Parse("2006-01-02", e.Start.Date)
+		if err == nil {
+			event.StartTime = t
+			event.AllDay = true
+		}
+	}
+
+	// Parse end time
+	if e.End.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, e.End.DateTime)
+		if err == nil {
+			event.EndTime = t
+		}
+	} else if e.End.Date != "" {
+		t, err := time.Parse("2006-01-02", e.End.Date)
+		if err == nil {
+			event.EndTime = t
+		}
+	}
+
+	// Extract calendar name from organizer or set default
+	if e.Organizer.DisplayName != "" {
+		event.Calendar = e.Organizer.DisplayName
+	} else if e.Organizer.Email != "" {
+		// Use email prefix as calendar name
+		parts := strings.Split(e.Organizer.Email, "@")
+		event.Calendar = parts[0]
+	} else {
+		event.Calendar = "Primary"
+	}
+
+	return event
 }
 
 type gcalDateTime struct {
@@ -179,6 +354,7 @@ type gcalAttendee struct {
 	Email          string `json:"email"`
 	DisplayName    string `json:"displayName,omitempty"`
 	ResponseStatus string `json:"responseStatus,omitempty"`
+	Self           bool   `json:"self,omitempty"`
 }
 
 func truncate(s string, max int) string {
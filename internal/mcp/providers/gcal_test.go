@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+)
+
+func TestParseEvents(t *testing.T) {
+	const standup = `{"id":"e1","summary":"Standup","start":{"dateTime":"2026-03-10T09:00:00-05:00"},"end":{"dateTime":"2026-03-10T09:15:00-05:00"},"organizer":{"email":"team@example.com"}}`
+	const holiday = `{"id":"e2","summary":"Holiday","start":{"date":"2026-03-11"},"end":{"date":"2026-03-12"}}`
+
+	tests := []struct {
+		name    string
+		texts   []string
+		wantIDs []string
+		wantErr bool
+	}{
+		{"no content", nil, []string{}, false},
+		{"empty text", []string{""}, []string{}, false},
+		{"empty array", []string{"[]"}, []string{}, false},
+		{"array", []string{"[" + standup + "," + holiday + "]"}, []string{"e1", "e2"}, false},
+		{"wrapped", []string{`{"events":[` + holiday + "," + standup + "]}"}, []string{"e2", "e1"}, false},
+		{"wrapped empty", []string{`{"events":[]}`}, []string{}, false},
+		{"missing fields", []string{`[{"id":"e3"}]`}, []string{"e3"}, false},
+		{"first text block only", []string{"[" + standup + "]", "[" + holiday + "]"}, []string{"e1"}, false},
+		{"malformed", []string{"No events found."}, nil, true},
+		{"truncated", []string{"[" + standup}, nil, true},
+	}
+
+	start := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := mockClient("list-events", toolResponse(t, tt.texts...))
+			p := NewGCalProvider(client, nil, WithTimezone("UTC"))
+			events, err := p.GetEventsInRange(context.Background(), start, start.AddDate(0, 0, 2))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetEventsInRange() = %d events, want an error", len(events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetEventsInRange() = %v", err)
+			}
+
+			ids := []string{}
+			for _, e := range events {
+				ids = append(ids, e.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("IDs = %q, want %q", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestParseEventsFields(t *testing.T) {
+	text := `{"events":[
+		{"id":"e1","summary":"Standup","location":"Room 4","description":"Daily",
+		 "start":{"dateTime":"2026-03-10T09:00:00-05:00"},"end":{"dateTime":"2026-03-10T09:15:00-05:00"},
+		 "organizer":{"email":"team@example.com"},
+		 "attendees":[{"email":"me@example.com","responseStatus":"accepted"},{"email":"bo@example.com","displayName":"Bo"}]},
+		{"id":"e2","summary":"Holiday","start":{"date":"2026-03-11"},"end":{"date":"2026-03-12"}},
+		{"id":"e3"}
+	]}`
+	result := &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: text}}}
+
+	p := NewGCalProvider(nil, nil, WithTimezone("UTC"))
+	p.SetUserEmail("ME@example.com")
+	events, err := p.parseEvents(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+
+	timed := events[0]
+	if want := time.Date(2026, time.March, 10, 14, 0, 0, 0, time.UTC); !timed.StartTime.Equal(want) || timed.StartTime.Location() != time.UTC {
+		t.Errorf("StartTime = %v, want %v in UTC", timed.StartTime, want)
+	}
+	if timed.EndTime.Sub(timed.StartTime) != 15*time.Minute || timed.AllDay {
+		t.Errorf("EndTime = %v, AllDay = %v", timed.EndTime, timed.AllDay)
+	}
+	if timed.Title != "Standup" || timed.Location != "Room 4" || timed.Notes != "Daily" || timed.Calendar != "team" {
+		t.Errorf("event = %+v", timed)
+	}
+	if len(timed.Attendees) != 2 || !timed.Attendees[0].Self || timed.Attendees[1].Self || timed.Attendees[1].Name != "Bo" {
+		t.Errorf("Attendees = %+v", timed.Attendees)
+	}
+
+	allDay := events[1]
+	if !allDay.AllDay || !allDay.StartTime.Equal(time.Date(2026, time.March, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("all-day event = %+v", allDay)
+	}
+
+	bare := events[2]
+	if !bare.StartTime.IsZero() || !bare.EndTime.IsZero() || bare.Title != "" || bare.Calendar != "Primary" {
+		t.Errorf("bare event = %+v", bare)
+	}
+}
+
+func TestEventTimesCarryOffsets(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	start := time.Date(2026, time.March, 10, 0, 0, 0, 0, ny)
+
+	client, mock := mockClient("list-events", toolResponse(t, "[]"))
+	p := NewGCalProvider(client, nil, WithTimezone("America/New_York"))
+	if _, err := p.GetEventsInRange(context.Background(), start, start.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	args := toolCalls(t, mock, "list-events")[0]
+	if args["timeMin"] != "2026-03-10T00:00:00-04:00" {
+		t.Errorf("timeMin = %v, want RFC 3339 with the New York offset", args["timeMin"])
+	}
+	if args["timeMax"] != "2026-03-11T00:00:00-04:00" {
+		t.Errorf("timeMax = %v, want RFC 3339 with the New York offset", args["timeMax"])
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		wantZone interface{} // nil means no timeZone argument
+	}{
+		{"named zone", time.Date(2026, time.March, 10, 9, 0, 0, 0, ny), "America/New_York"},
+		{"local zone", time.Date(2026, time.March, 10, 9, 0, 0, 0, time.Local), nil},
+		{"fixed offset", time.Date(2026, time.March, 10, 9, 0, 0, 0, time.FixedZone("", 2*3600)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := mockClient("create-event", toolResponse(t, "Created"))
+			in := CreateEventInput{Title: "Standup", StartTime: tt.start, EndTime: tt.start.Add(30 * time.Minute)}
+			if _, err := NewGCalProvider(client, nil).CreateEvent(context.Background(), in); err != nil {
+				t.Fatal(err)
+			}
+
+			args := toolCalls(t, mock, "create-event")[0]
+			for key, at := range map[string]time.Time{"start": in.StartTime, "end": in.EndTime} {
+				got, err := time.Parse(time.RFC3339, args[key].(string))
+				if err != nil {
+					t.Errorf("%s = %v, want RFC 3339: %v", key, args[key], err)
+				} else if !got.Equal(at) {
+					t.Errorf("%s = %v, want the instant %v", key, got, at)
+				}
+			}
+			if args["timeZone"] != tt.wantZone {
+				t.Errorf("timeZone = %v, want %v", args["timeZone"], tt.wantZone)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// MultiCalendarProvider merges the events of several calendar providers,
+// e.g. Google Calendar and Apple Calendar, into one list
+type MultiCalendarProvider struct {
+	providers []CalendarProviderInterface
+	logger    *slog.Logger
+
+	// owners maps each fetched event ID to the provider it came from, so
+	// RSVPs reach the right one
+	mu     sync.Mutex
+	owners map[string]int
+}
+
+// NewMultiCalendarProvider merges events from providers, in the order
+// given for CreateEvent. Providers that fail are logged to logger (nil
+// discards) while the others' events are still returned.
+func NewMultiCalendarProvider(logger *slog.Logger, providers ...CalendarProviderInterface) *MultiCalendarProvider {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &MultiCalendarProvider{
+		providers: providers,
+		logger:    logger,
+		owners:    make(map[string]int),
+	}
+}
+
+// GetTodayEvents returns today's events from every provider
+func (p *MultiCalendarProvider) GetTodayEvents(ctx context.Context) ([]CalendarEvent, error) {
+	return p.gather(ctx, "today", func(c CalendarProviderInterface) ([]CalendarEvent, error) {
+		return c.GetTodayEvents(ctx)
+	})
+}
+
+// GetUpcomingEvents returns the next days of events from every provider
+func (p *MultiCalendarProvider) GetUpcomingEvents(ctx context.Context, days int) ([]CalendarEvent, error) {
+	return p.gather(ctx, "upcoming", func(c CalendarProviderInterface) ([]CalendarEvent, error) {
+		return c.GetUpcomingEvents(ctx, days)
+	})
+}
+
+// GetEventsInRange returns events between two dates from every provider
+func (p *MultiCalendarProvider) GetEventsInRange(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+	return p.gather(ctx, "range", func(c CalendarProviderInterface) ([]CalendarEvent, error) {
+		return c.GetEventsInRange(ctx, start, end)
+	})
+}
+
+// gather calls fetch on every provider concurrently and merges the
+// results, dropping duplicates and sorting by start time. It fails only
+// when every provider does.
+func (p *MultiCalendarProvider) gather(ctx context.Context, query string, fetch func(CalendarProviderInterface) ([]CalendarEvent, error)) ([]CalendarEvent, error) {
+	results := make([][]CalendarEvent, len(p.providers))
+	errs := make([]error, len(p.providers))
+
+	// Errors are kept per provider so one failing doesn't cancel the rest
+	var g errgroup.Group
+	for i, c := range p.providers {
+		g.Go(func() error {
+			results[i], errs[i] = fetch(c)
+			return nil
+		})
+	}
+	g.Wait()
+
+	var failed []error
+	seen := make(map[string]bool)
+	owners := make(map[string]int)
+	var merged []CalendarEvent
+	for i, events := range results {
+		if errs[i] != nil {
+			name := fmt.Sprintf("%T", p.providers[i])
+			p.logger.Warn("calendar provider failed", "provider", name, "query", query, "error", errs[i])
+			failed = append(failed, fmt.Errorf("%s: %w", name, errs[i]))
+			continue
+		}
+		for _, e := range events {
+			key := eventKey(e)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			owners[e.ID] = i
+			merged = append(merged, e)
+		}
+	}
+	if len(p.providers) > 0 && len(failed) == len(p.providers) {
+		return nil, errors.Join(failed...)
+	}
+
+	p.mu.Lock()
+	for id, i := range owners {
+		p.owners[id] = i
+	}
+	p.mu.Unlock()
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].StartTime.Before(merged[j].StartTime)
+	})
+	return merged, nil
+}
+
+// eventKey identifies an event across providers for deduplication
+func eventKey(e CalendarEvent) string {
+	return fmt.Sprintf("%s\x00%s\x00%d", e.ID, e.Title, e.StartTime.UnixNano())
+}
+
+// CreateEvent creates the event with the first provider
+func (p *MultiCalendarProvider) CreateEvent(ctx context.Context, e CreateEventInput) (CalendarEvent, error) {
+	if len(p.providers) == 0 {
+		return CalendarEvent{}, fmt.Errorf("no calendar providers configured")
+	}
+	return p.providers[0].CreateEvent(ctx, e)
+}
+
+// UpdateRSVP answers an invitation through the provider the event came
+// from, if it supports RSVPs
+func (p *MultiCalendarProvider) UpdateRSVP(ctx context.Context, eventID, status string) error {
+	p.mu.Lock()
+	i, ok := p.owners[eventID]
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("event %s is not loaded", eventID)
+	}
+	updater, ok := p.providers[i].(RSVPUpdater)
+	if !ok {
+		return fmt.Errorf("this event's calendar does not support RSVPs")
+	}
+	return updater.UpdateRSVP(ctx, eventID, status)
+}
+
+// Close closes every provider
+func (p *MultiCalendarProvider) Close() error {
+	var errs []error
+	for _, c := range p.providers {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,186 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+)
+
+// NotionPage is a Notion page as shown in the Knowledge pane
+type NotionPage struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	LastEdited  time.Time `json:"last_edited"`
+	ParentTitle string    `json:"parent_title,omitempty"` // Empty when the parent wasn't among the results
+}
+
+// NotionProvider reads and creates Notion pages via the Notion MCP server
+type NotionProvider struct {
+	client mcp.ToolCaller
+}
+
+// NewNotionProvider creates a new Notion provider
+func NewNotionProvider(client mcp.ToolCaller) *NotionProvider {
+	return &NotionProvider{client: client}
+}
+
+// SearchPages returns pages matching query, most recently edited first. An
+// empty query lists recent pages.
+func (p *NotionProvider) SearchPages(ctx context.Context, query string) ([]NotionPage, error) {
+	args := map[string]interface{}{
+		"query": query,
+		"sort": map[string]interface{}{
+			"direction": "descending",
+			"timestamp": "last_edited_time",
+		},
+	}
+	text, err := p.call(ctx, "notion_search", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Results []notionObject `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(text), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse Notion search results: %w (text: %s)", err, truncate(text, 200))
+	}
+
+	// Parents are only named when they came back in the same results
+	titles := make(map[string]string, len(list.Results))
+	for _, o := range list.Results {
+		titles[o.ID] = o.title()
+	}
+
+	var pages []NotionPage
+	for _, o := range list.Results {
+		if o.Object != "page" {
+			continue
+		}
+		page := o.toNotionPage()
+		page.ParentTitle = titles[o.Parent.id()]
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// GetPage returns a single page
+func (p *NotionProvider) GetPage(ctx context.Context, pageID string) (NotionPage, error) {
+	text, err := p.call(ctx, "notion_retrieve_page", map[string]interface{}{"page_id": pageID})
+	if err != nil {
+		return NotionPage{}, err
+	}
+	var o notionObject
+	if err := json.Unmarshal([]byte(text), &o); err != nil {
+		return NotionPage{}, fmt.Errorf("failed to parse Notion page: %w (text: %s)", err, truncate(text, 200))
+	}
+	return o.toNotionPage(), nil
+}
+
+// CreatePage adds a page titled title under parentID with content as its
+// body text
+func (p *NotionProvider) CreatePage(ctx context.Context, title, content, parentID string) (NotionPage, error) {
+	args := map[string]interface{}{
+		"parent_id": parentID,
+		"title":     title,
+	}
+	if content != "" {
+		args["content"] = content
+	}
+	text, err := p.call(ctx, "notion_create_page", args)
+	if err != nil {
+		return NotionPage{}, err
+	}
+
+	// Prefer the server's view of the page; fall back to what we sent
+	var o notionObject
+	if err := json.Unmarshal([]byte(text), &o); err == nil && o.ID != "" {
+		return o.toNotionPage(), nil
+	}
+	return NotionPage{Title: title, LastEdited: time.Now()}, nil
+}
+
+// call invokes tool and returns its text, turning tool errors into Go errors
+func (p *NotionProvider) call(ctx context.Context, tool string, args map[string]interface{}) (string, error) {
+	result, err := p.client.CallTool(ctx, tool, args)
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", tool, err)
+	}
+	if result.IsError {
+		return "", fmt.Errorf("%s failed: %s", tool, truncate(resultText(result), 200))
+	}
+	return resultText(result), nil
+}
+
+// Close closes the provider
+func (p *NotionProvider) Close() error {
+	return p.client.Close()
+}
+
+// notionObject is a page or database as returned by the Notion API
+type notionObject struct {
+	Object         string                    `json:"object"`
+	ID             string                    `json:"id"`
+	URL            string                    `json:"url"`
+	LastEditedTime string                    `json:"last_edited_time"`
+	Parent         notionParent              `json:"parent"`
+	Title          []notionRichText          `json:"title,omitempty"` // Databases only
+	Properties     map[string]notionProperty `json:"properties,omitempty"`
+}
+
+type notionParent struct {
+	Type       string `json:"type"`
+	PageID     string `json:"page_id,omitempty"`
+	DatabaseID string `json:"database_id,omitempty"`
+}
+
+type notionProperty struct {
+	Type  string           `json:"type"`
+	Title []notionRichText `json:"title,omitempty"`
+}
+
+type notionRichText struct {
+	PlainText string `json:"plain_text"`
+}
+
+// id returns the parent page or database ID, empty for the workspace
+func (p notionParent) id() string {
+	if p.PageID != "" {
+		return p.PageID
+	}
+	return p.DatabaseID
+}
+
+// title joins the object's title text: a database's own title, or a page's
+// title property (named "title", "Name" or anything else)
+func (o notionObject) title() string {
+	parts := o.Title
+	for _, prop := range o.Properties {
+		if prop.Type == "title" {
+			parts = prop.Title
+			break
+		}
+	}
+	var b strings.Builder
+	for _, t := range parts {
+		b.WriteString(t.PlainText)
+	}
+	if b.Len() == 0 {
+		return "Untitled"
+	}
+	return b.String()
+}
+
+// toNotionPage converts the API representation to a NotionPage
+func (o notionObject) toNotionPage() NotionPage {
+	page := NotionPage{ID: o.ID, Title: o.title(), URL: o.URL}
+	if t, err := time.Parse(time.RFC3339, o.LastEditedTime); err == nil {
+		page.LastEdited = t
+	}
+	return page
+}
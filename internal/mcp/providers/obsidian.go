@@ -0,0 +1,222 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// notePreviewLen is how many characters of a note's body Note.Preview holds
+const notePreviewLen = 200
+
+// Note is a Markdown file in an Obsidian vault
+type Note struct {
+	Path         string    `json:"path"` // Relative to the vault
+	Title        string    `json:"title"`
+	LastModified time.Time `json:"last_modified"`
+	Preview      string    `json:"preview,omitempty"`
+	Content      string    `json:"content,omitempty"` // Set by GetNote only
+}
+
+// ObsidianProvider reads and writes the Markdown files of a local
+// Obsidian vault directly, without Obsidian running
+type ObsidianProvider struct {
+	vaultPath string
+}
+
+// NewObsidianProvider creates a provider for the vault at vaultPath
+func NewObsidianProvider(vaultPath string) *ObsidianProvider {
+	return &ObsidianProvider{vaultPath: vaultPath}
+}
+
+// SearchNotes returns the notes whose title or content contains query,
+// ignoring case, most recently modified first. An empty query lists every
+// note. Hidden directories such as .obsidian and .trash are skipped, as
+// are files and directories that can't be read.
+func (p *ObsidianProvider) SearchNotes(ctx context.Context, query string) ([]Note, error) {
+	query = strings.ToLower(query)
+	notes := []Note{}
+
+	err := filepath.WalkDir(p.vaultPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == p.vaultPath {
+				return err
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != p.vaultPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+
+		note, err := p.readNote(path)
+		if err != nil {
+			return nil
+		}
+		if query != "" && !strings.Contains(strings.ToLower(note.Title), query) &&
+			!strings.Contains(strings.ToLower(note.Content), query) {
+			return nil
+		}
+		note.Content = ""
+		notes = append(notes, note)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search vault %s: %w", p.vaultPath, err)
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].LastModified.After(notes[j].LastModified)
+	})
+	return notes, nil
+}
+
+// GetNote returns the note at path, relative to the vault, with its full
+// content
+func (p *ObsidianProvider) GetNote(ctx context.Context, path string) (Note, error) {
+	full, err := p.resolve(path)
+	if err != nil {
+		return Note{}, err
+	}
+	return p.readNote(full)
+}
+
+// CreateNote writes title.md at the top of the vault with YAML
+// frontmatter naming the title and creation time. An existing note is
+// never overwritten.
+func (p *ObsidianProvider) CreateNote(ctx context.Context, title, content string) error {
+	name := noteFileName(title)
+	if name == "" {
+		return errors.New("note title is empty")
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("title: \"" + yamlEscaper.Replace(strings.TrimSpace(title)) + "\"\n")
+	b.WriteString("created: " + time.Now().Format(time.RFC3339) + "\n")
+	b.WriteString("---\n\n")
+	b.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		b.WriteString("\n")
+	}
+
+	f, err := os.OpenFile(filepath.Join(p.vaultPath, name+".md"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	return f.Close()
+}
+
+// NoteURL is the obsidian:// link that opens note in the Obsidian app
+func (p *ObsidianProvider) NoteURL(note Note) string {
+	return "obsidian://open?path=" + url.PathEscape(filepath.Join(p.vaultPath, note.Path))
+}
+
+// Close is a no-op; the provider holds no open files
+func (p *ObsidianProvider) Close() error {
+	return nil
+}
+
+// resolve joins a vault-relative path to the vault, refusing paths that
+// lead outside it
+func (p *ObsidianProvider) resolve(path string) (string, error) {
+	if filepath.IsAbs(path) || !filepath.IsLocal(path) {
+		return "", fmt.Errorf("note path %q is outside the vault", path)
+	}
+	return filepath.Join(p.vaultPath, path), nil
+}
+
+// readNote loads the note at the absolute path full
+func (p *ObsidianProvider) readNote(full string) (Note, error) {
+	info, err := os.Stat(full)
+	if err != nil {
+		return Note{}, fmt.Errorf("failed to read note: %w", err)
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return Note{}, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	rel, err := filepath.Rel(p.vaultPath, full)
+	if err != nil {
+		rel = full
+	}
+	content := string(data)
+	body := stripFrontmatter(content)
+
+	return Note{
+		Path:         rel,
+		Title:        noteTitle(body, full),
+		LastModified: info.ModTime(),
+		Preview:      notePreview(body),
+		Content:      content,
+	}, nil
+}
+
+// stripFrontmatter drops a leading "---" YAML block
+func stripFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return content
+	}
+	rest := content[4+end+4:]
+	return strings.TrimPrefix(rest, "\n")
+}
+
+// noteTitle is the first "# " heading of body, or the file name without
+// its extension
+func noteTitle(body, path string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			if title := strings.TrimSpace(line[2:]); title != "" {
+				return title
+			}
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// notePreview is the start of body on one line, at most notePreviewLen
+// characters
+func notePreview(body string) string {
+	preview := strings.Join(strings.Fields(body), " ")
+	if runes := []rune(preview); len(runes) > notePreviewLen {
+		preview = string(runes[:notePreviewLen])
+	}
+	return preview
+}
+
+// noteFileName makes title safe to use as a file name
+func noteFileName(title string) string {
+	return strings.TrimSpace(fileNameReplacer.Replace(title))
+}
+
+var (
+	fileNameReplacer = strings.NewReplacer("/", "-", "\\", "-", ":", "-", "*", "-", "?", "-", "\"", "-", "<", "-", ">", "-", "|", "-", "\n", " ")
+	yamlEscaper      = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", " ")
+)
@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeVault creates a vault in a temp dir from path→contents pairs.
+// Files are dated an hour apart in the order given, oldest first.
+func writeVault(t *testing.T, files [][2]string) string {
+	t.Helper()
+	vault := t.TempDir()
+	base := time.Now().Add(-24 * time.Hour)
+	for i, f := range files {
+		path := filepath.Join(vault, f[0])
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f[1]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := base.Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return vault
+}
+
+func TestSearchNotes(t *testing.T) {
+	vault := writeVault(t, [][2]string{
+		{"old.md", "# Quarterly plan\nHiring and budget"},
+		{"projects/launch.md", "---\ntitle: x\n---\n# Launch checklist\nBudget sign-off"},
+		{"untitled.md", "no heading here"},
+		{".obsidian/workspace.md", "# Budget config"},
+		{"image.png", "Budget"},
+	})
+	// A dangling link can't be read and must not stop the search
+	if err := os.Symlink(filepath.Join(vault, "missing.md"), filepath.Join(vault, "broken.md")); err != nil {
+		t.Fatal(err)
+	}
+	p := NewObsidianProvider(vault)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"untitled", "Launch checklist", "Quarterly plan"}},
+		{"budget", []string{"Launch checklist", "Quarterly plan"}},
+		{"QUARTERLY", []string{"Quarterly plan"}},
+		{"nothing matches", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			notes, err := p.SearchNotes(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("SearchNotes() error = %v", err)
+			}
+			var titles []string
+			for _, n := range notes {
+				titles = append(titles, n.Title)
+				if n.Content != "" {
+					t.Errorf("%s: search result carries its content", n.Path)
+				}
+			}
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("titles = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchNotesMissingVault(t *testing.T) {
+	p := NewObsidianProvider(filepath.Join(t.TempDir(), "nope"))
+	if _, err := p.SearchNotes(context.Background(), ""); err == nil {
+		t.Error("SearchNotes() on a missing vault succeeded")
+	}
+}
+
+func TestGetNote(t *testing.T) {
+	vault := writeVault(t, [][2]string{{"dir/note.md", "# Title\nBody text"}})
+	p := NewObsidianProvider(vault)
+
+	note, err := p.GetNote(context.Background(), "dir/note.md")
+	if err != nil {
+		t.Fatalf("GetNote() error = %v", err)
+	}
+	if note.Title != "Title" || note.Content != "# Title\nBody text" || note.Preview != "# Title Body text" {
+		t.Errorf("GetNote() = %+v", note)
+	}
+
+	for _, path := range []string{"../outside.md", "/etc/passwd"} {
+		if _, err := p.GetNote(context.Background(), path); err == nil {
+			t.Errorf("GetNote(%q) succeeded, want an outside-the-vault error", path)
+		}
+	}
+}
+
+func TestCreateNote(t *testing.T) {
+	vault := t.TempDir()
+	p := NewObsidianProvider(vault)
+	ctx := context.Background()
+
+	if err := p.CreateNote(ctx, `Plan: "Q3"`, "Body"); err != nil {
+		t.Fatalf("CreateNote() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(vault, `Plan- -Q3-.md`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "---\ntitle: \"Plan: \\\"Q3\\\"\"\ncreated: ") || !strings.HasSuffix(content, "---\n\nBody\n") {
+		t.Errorf("note content = %q", content)
+	}
+
+	if err := p.CreateNote(ctx, `Plan: "Q3"`, "Other"); err == nil {
+		t.Error("CreateNote() overwrote an existing note")
+	}
+	if err := p.CreateNote(ctx, "  ", "Body"); err == nil {
+		t.Error("CreateNote() with an empty title succeeded")
+	}
+}
@@ -3,6 +3,7 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -44,6 +45,7 @@ type Project struct {
 	Deadline  *time.Time `json:"deadline,omitempty"`
 	AreaUUID  string     `json:"area_uuid,omitempty"`
 	AreaTitle string     `json:"area_title,omitempty"`
+	Items     []Task     `json:"items,omitempty"` // Populated when include_items is set
 }
 
 // Area represents a Things 3 area
@@ -52,13 +54,45 @@ type Area struct {
 	Title string `json:"title"`
 }
 
+// ErrThingsNotInstalled is returned when the Things MCP server cannot reach Things 3
+var ErrThingsNotInstalled = errors.New("things 3 not installed")
+
+// ErrThingsToolError is returned when the Things MCP server reports any
+// other tool error, e.g. a denied automation permission
+var ErrThingsToolError = errors.New("Things MCP tool error")
+
+// thingsMissingMarkers are lowercase fragments of the tool errors that
+// mean Things 3 itself is missing, as opposed to e.g. a denied automation
+// permission ("not authorized to send apple events")
+var thingsMissingMarkers = []string{
+	"not installed",
+	"unable to find application",
+	"can't get application",
+	"can’t get application",
+	"application isn't running",
+	"application can’t be found",
+	"(-10814)",
+	"(-1728)",
+}
+
+// thingsMissing reports whether a tool error says Things 3 is missing
+func thingsMissing(text string) bool {
+	text = strings.ToLower(text)
+	for _, marker := range thingsMissingMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // ThingsProvider wraps the Things 3 MCP server
 type ThingsProvider struct {
-	client *mcp.Client
+	client mcp.ToolCaller
 }
 
 // NewThingsProvider creates a new Things provider
-func NewThingsProvider(client *mcp.Client) *ThingsProvider {
+func NewThingsProvider(client mcp.ToolCaller) *ThingsProvider {
 	return &ThingsProvider{client: client}
 }
 
@@ -72,6 +106,43 @@ func (p *ThingsProvider) GetToday(ctx context.Context) ([]Task, error) {
 	return parseTasks(result)
 }
 
+// healthCheckTool is the cheap read HealthCheck probes the server with
+const healthCheckTool = "get_today"
+
+// HealthCheck verifies the Things MCP server can reach Things 3. It always
+// asks the live server, never the cache. A call that fails outright means
+// the server itself is down. A tool error saying Things 3 can't be found
+// is reported as ErrThingsNotInstalled, any other as ErrThingsToolError.
+func (p *ThingsProvider) HealthCheck(ctx context.Context) error {
+	result, err := p.client.CallTool(mcp.WithoutCache(ctx), healthCheckTool, map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	if result.IsError {
+		text := resultText(result)
+		if thingsMissing(text) {
+			return fmt.Errorf("%w: %s", ErrThingsNotInstalled, truncate(text, 200))
+		}
+		return fmt.Errorf("%w: %s", ErrThingsToolError, truncate(text, 200))
+	}
+	return nil
+}
+
+// GetTodayWithAreas returns today's tasks grouped by area title.
+// Tasks without an area are grouped under "".
+func (p *ThingsProvider) GetTodayWithAreas(ctx context.Context) (map[string][]Task, error) {
+	tasks, err := p.GetToday(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[string][]Task)
+	for _, t := range tasks {
+		grouped[t.AreaTitle] = append(grouped[t.AreaTitle], t)
+	}
+	return grouped, nil
+}
+
 // GetTodayDebug returns raw debug info for troubleshooting
 func (p *ThingsProvider) GetTodayDebug(ctx context.Context) (map[string]interface{}, error) {
 	result, err := p.client.CallTool(ctx, "get_today", map[string]interface{}{})
@@ -115,6 +186,15 @@ func (p *ThingsProvider) GetInbox(ctx context.Context) ([]Task, error) {
 	return parseTasks(result)
 }
 
+// GetInboxCount returns the number of inbox tasks
+func (p *ThingsProvider) GetInboxCount(ctx context.Context) (int, error) {
+	tasks, err := p.GetInbox(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(tasks), nil
+}
+
 // GetUpcoming returns upcoming tasks
 func (p *ThingsProvider) GetUpcoming(ctx context.Context) ([]Task, error) {
 	result, err := p.client.CallTool(ctx, "get_upcoming", map[string]interface{}{})
@@ -135,6 +215,18 @@ func (p *ThingsProvider) GetAnytime(ctx context.Context) ([]Task, error) {
 	return parseTasks(result)
 }
 
+// GetLogbook returns tasks completed within period, e.g. "7d"
+func (p *ThingsProvider) GetLogbook(ctx context.Context, period string) ([]Task, error) {
+	result, err := p.client.CallTool(ctx, "get_logbook", map[string]interface{}{
+		"period": period,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get_logbook failed: %w", err)
+	}
+
+	return parseTasks(result)
+}
+
 // GetProjects returns all projects
 func (p *ThingsProvider) GetProjects(ctx context.Context, includeItems bool) ([]Project, error) {
 	args := map[string]interface{}{
@@ -189,6 +281,94 @@ func (p *ThingsProvider) UpdateTodo(ctx context.Context, id string, updates map[
 	return nil
 }
 
+// CreateTaskInput describes a task to create, or the fields to change in
+// UpdateTodoFields (zero values are left untouched)
+type CreateTaskInput struct {
+	Title       string
+	Notes       string
+	ProjectUUID string
+	AreaUUID    string // Used when ProjectUUID is empty
+	Tags        []string
+	Deadline    *time.Time
+	List        string // today, anytime, someday or inbox
+}
+
+// args converts the input to Things MCP arguments, omitting empty fields
+func (in CreateTaskInput) args() map[string]interface{} {
+	args := map[string]interface{}{}
+	if in.Title != "" {
+		args["title"] = in.Title
+	}
+	if in.Notes != "" {
+		args["notes"] = in.Notes
+	}
+	if in.ProjectUUID != "" {
+		args["list_id"] = in.ProjectUUID
+	} else if in.AreaUUID != "" {
+		args["list_id"] = in.AreaUUID
+	}
+	if len(in.Tags) > 0 {
+		args["tags"] = in.Tags
+	}
+	if in.Deadline != nil {
+		args["deadline"] = in.Deadline.Format(DateLayout)
+	}
+	if in.List != "" && in.List != "inbox" {
+		args["when"] = in.List
+	}
+	return args
+}
+
+// CreateTask creates a task and returns it as the server reports it
+func (p *ThingsProvider) CreateTask(ctx context.Context, t CreateTaskInput) (Task, error) {
+	if t.Title == "" {
+		return Task{}, fmt.Errorf("create_todo failed: title is required")
+	}
+
+	result, err := p.client.CallTool(ctx, "create_todo", t.args())
+	if err != nil {
+		return Task{}, fmt.Errorf("create_todo failed: %w", err)
+	}
+
+	// Prefer the server's view of the task; fall back to what we sent
+	if tasks, _ := parseTasks(result); len(tasks) > 0 {
+		return tasks[0], nil
+	}
+	return Task{
+		Title:       t.Title,
+		Status:      "incomplete",
+		Notes:       t.Notes,
+		Tags:        t.Tags,
+		Deadline:    t.Deadline,
+		ProjectUUID: t.ProjectUUID,
+		AreaUUID:    t.AreaUUID,
+	}, nil
+}
+
+// UpdateTodoFields applies the non-empty fields of fields to a task
+func (p *ThingsProvider) UpdateTodoFields(ctx context.Context, id string, fields CreateTaskInput) error {
+	args := fields.args()
+	if len(args) == 0 {
+		return nil
+	}
+	if err := p.UpdateTodo(ctx, id, args); err != nil {
+		return fmt.Errorf("failed to update task %s: %w", id, err)
+	}
+	return nil
+}
+
+// UpdateChecklist replaces a task's checklist items
+func (p *ThingsProvider) UpdateChecklist(ctx context.Context, id string, items []ChecklistItem) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checklist: %w", err)
+	}
+
+	return p.UpdateTodo(ctx, id, map[string]interface{}{
+		"checklist_items": string(data),
+	})
+}
+
 // MarkComplete marks a task as completed
 func (p *ThingsProvider) MarkComplete(ctx context.Context, id string) error {
 	return p.UpdateTodo(ctx, id, map[string]interface{}{
@@ -196,6 +376,32 @@ func (p *ThingsProvider) MarkComplete(ctx context.Context, id string) error {
 	})
 }
 
+// UncompleteTask marks a completed task as open again
+func (p *ThingsProvider) UncompleteTask(ctx context.Context, id string) error {
+	return p.UpdateTodo(ctx, id, map[string]interface{}{
+		"completed": false,
+	})
+}
+
+// RestoreStatus reopens a completed task, or cancels it again when
+// status is "canceled"; undo uses it to put back the status a task had
+// before it was completed
+func (p *ThingsProvider) RestoreStatus(ctx context.Context, id, status string) error {
+	if status == "canceled" {
+		return p.UpdateTodo(ctx, id, map[string]interface{}{
+			"canceled": true,
+		})
+	}
+	return p.UncompleteTask(ctx, id)
+}
+
+// MoveToInbox clears a task's project assignment so it lands back in the Inbox
+func (p *ThingsProvider) MoveToInbox(ctx context.Context, id string) error {
+	return p.UpdateTodo(ctx, id, map[string]interface{}{
+		"list_id": "",
+	})
+}
+
 // Close closes the provider
 func (p *ThingsProvider) Close() error {
 	return p.client.Close()
@@ -263,7 +469,7 @@ func parseTaskBlock(block string) Task {
 
 		// Handle multi-line notes
 		if inNotes {
-			if strings.HasPrefix(line, "Project:") || strings.HasPrefix(line, "Tags:") ||
+			if strings.HasPrefix(line, "Project:") || strings.HasPrefix(line, "Area:") || strings.HasPrefix(line, "Tags:") ||
 			   strings.HasPrefix(line, "Checklist:") || strings.HasPrefix(line, "Deadline:") {
 				inNotes = false
 				task.Notes = strings.TrimSpace(notesBuilder.String())
@@ -292,6 +498,8 @@ func parseTaskBlock(block string) Task {
 				notesBuilder.WriteString("\n")
 			case "Project":
 				task.ProjectTitle = value
+			case "Area":
+				task.AreaTitle = value
 			case "Tags":
 				if value != "" {
 					task.Tags = strings.Split(value, ", ")
@@ -299,12 +507,12 @@ func parseTaskBlock(block string) Task {
 			case "Checklist":
 				inChecklist = true
 			case "Start Date":
-				if t, err := time.Parse("2006-01-02", value); err == nil {
-					task.StartDate = &t
+				if t, err := ParseDate(value); err == nil {
+					task.StartDate = t
 				}
 			case "Deadline":
-				if t, err := time.Parse("2006-01-02", value); err == nil {
-					task.Deadline = &t
+				if t, err := ParseDate(value); err == nil {
+					task.Deadline = t
 				}
 			}
 		}
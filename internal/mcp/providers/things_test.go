@@ -0,0 +1,373 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/mcp/transport"
+)
+
+// toolResponse encodes a tool result with one text block per entry
+func toolResponse(t *testing.T, texts ...string) json.RawMessage {
+	t.Helper()
+	result := mcp.ToolResult{Content: []mcp.ContentBlock{}}
+	for _, text := range texts {
+		result.Content = append(result.Content, mcp.ContentBlock{Type: "text", Text: text})
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// mockClient returns a client whose server answers tool with response.
+// tools/list is left unanswered, so argument validation is skipped.
+func mockClient(tool string, response json.RawMessage) (*mcp.Client, *transport.MockTransport) {
+	mock := transport.NewMockTransport(map[string]json.RawMessage{
+		"tools/call/" + tool: response,
+	}, nil)
+	return mcp.NewClient(mock, "test"), mock
+}
+
+func TestParseTasks(t *testing.T) {
+	tests := []struct {
+		name   string
+		texts  []string
+		titles []string
+	}{
+		{"no content", nil, nil},
+		{"empty text", []string{""}, nil},
+		{"whitespace only", []string{"\n\n  \n"}, nil},
+		{"single task", []string{"Title: Write report\nUUID: a1"}, []string{"Write report"}},
+		{
+			"separated tasks",
+			[]string{"Title: One\nUUID: a1\n---\nTitle: Two\nUUID: a2\n---\nTitle: Three\nUUID: a3"},
+			[]string{"One", "Two", "Three"},
+		},
+		{
+			"multiple content blocks",
+			[]string{"Title: One\nUUID: a1", "Title: Two\nUUID: a2"},
+			[]string{"One", "Two"},
+		},
+		{
+			"untitled block dropped",
+			[]string{"UUID: a1\nStatus: incomplete\n---\nTitle: Kept\nUUID: a2"},
+			[]string{"Kept"},
+		},
+		{"malformed text", []string{"no tasks today\n---\n:::"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := mockClient("get_today", toolResponse(t, tt.texts...))
+			tasks, err := NewThingsProvider(client).GetToday(context.Background())
+			if err != nil {
+				t.Fatalf("GetToday() = %v", err)
+			}
+
+			var titles []string
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			if !slices.Equal(titles, tt.titles) {
+				t.Errorf("titles = %q, want %q", titles, tt.titles)
+			}
+		})
+	}
+}
+
+func TestGetTodayToolError(t *testing.T) {
+	mock := transport.NewMockTransport(nil, map[string]error{
+		"tools/call/get_today": errors.New("boom"),
+	})
+	if _, err := NewThingsProvider(mcp.NewClient(mock, "test")).GetToday(context.Background()); err == nil {
+		t.Fatal("GetToday() succeeded, want the tool error")
+	}
+}
+
+func TestHealthCheckToolErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want error
+	}{
+		{"missing app", `Can't get application "Things3"`, ErrThingsNotInstalled},
+		{"not installed", "Things 3 is not installed", ErrThingsNotInstalled},
+		{"permission denied", "Not authorized to send Apple events to Things3. (-1743)", ErrThingsToolError},
+		{"other", "unexpected failure", ErrThingsToolError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(mcp.ToolResult{
+				Content: []mcp.ContentBlock{{Type: "text", Text: tt.text}},
+				IsError: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, _ := mockClient("get_today", raw)
+			err = NewThingsProvider(client).HealthCheck(context.Background())
+			if !errors.Is(err, tt.want) || !strings.Contains(err.Error(), tt.text) {
+				t.Errorf("HealthCheck() = %v, want %v with the tool's text", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTaskBlock(t *testing.T) {
+	deadline := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC)
+
+	full := parseTaskBlock(`Title: Ship release
+UUID: abc-123
+Status: incomplete
+Notes: first line
+second line
+Project: Launch
+Area: Work
+Tags: urgent, q1
+Deadline: 2026-03-12
+Checklist:
+  □ Write notes
+  ☑ Tag build`)
+
+	if full.Title != "Ship release" || full.UUID != "abc-123" || full.Status != "incomplete" {
+		t.Errorf("identity = %q/%q/%q", full.Title, full.UUID, full.Status)
+	}
+	if want := "first line\nsecond line"; full.Notes != want {
+		t.Errorf("Notes = %q, want %q", full.Notes, want)
+	}
+	if full.ProjectTitle != "Launch" || full.AreaTitle != "Work" {
+		t.Errorf("project/area = %q/%q", full.ProjectTitle, full.AreaTitle)
+	}
+	if want := []string{"urgent", "q1"}; !slices.Equal(full.Tags, want) {
+		t.Errorf("Tags = %q, want %q", full.Tags, want)
+	}
+	if full.Deadline == nil || !full.Deadline.Equal(deadline) {
+		t.Errorf("Deadline = %v, want %v", full.Deadline, deadline)
+	}
+	wantItems := []ChecklistItem{{"Write notes", "incomplete"}, {"Tag build", "completed"}}
+	if !slices.Equal(full.ChecklistItems, wantItems) {
+		t.Errorf("ChecklistItems = %+v, want %+v", full.ChecklistItems, wantItems)
+	}
+
+	tests := []struct {
+		name  string
+		block string
+		check func(t *testing.T, task Task)
+	}{
+		{"title only", "Title: Bare", func(t *testing.T, task Task) {
+			if task.Title != "Bare" || task.UUID != "" || task.Tags != nil || task.Deadline != nil || task.StartDate != nil {
+				t.Errorf("task = %+v, want only a title", task)
+			}
+		}},
+		{"bad dates ignored", "Title: X\nDeadline: next week\nStart Date: 12/03/2026", func(t *testing.T, task Task) {
+			if task.Deadline != nil || task.StartDate != nil {
+				t.Errorf("dates = %v/%v, want nil", task.Deadline, task.StartDate)
+			}
+		}},
+		{"empty tags", "Title: X\nTags:", func(t *testing.T, task Task) {
+			if task.Tags != nil {
+				t.Errorf("Tags = %q, want nil", task.Tags)
+			}
+		}},
+		{"notes run to end", "Title: X\nNotes: only\ntrailing", func(t *testing.T, task Task) {
+			if task.Notes != "only\ntrailing" {
+				t.Errorf("Notes = %q", task.Notes)
+			}
+		}},
+		{"no key value pairs", "just some text", func(t *testing.T, task Task) {
+			if task.Title != "" {
+				t.Errorf("Title = %q, want empty", task.Title)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, parseTaskBlock(tt.block))
+		})
+	}
+}
+
+// toolCalls decodes the arguments of each call to tool, in order
+func toolCalls(t *testing.T, mock *transport.MockTransport, tool string) []map[string]interface{} {
+	t.Helper()
+	var calls []map[string]interface{}
+	for _, call := range mock.RecordedCalls() {
+		if call.Method != "tools/call" {
+			continue
+		}
+		var params struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments"`
+		}
+		if err := json.Unmarshal(call.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		if params.Name == tool {
+			calls = append(calls, params.Arguments)
+		}
+	}
+	return calls
+}
+
+func TestCreateTaskArguments(t *testing.T) {
+	deadline := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input CreateTaskInput
+		want  map[string]interface{}
+	}{
+		{"title only", CreateTaskInput{Title: "Call Jane"}, map[string]interface{}{"title": "Call Jane"}},
+		{
+			"every field",
+			CreateTaskInput{
+				Title:       "Ship release",
+				Notes:       "Tag the build",
+				ProjectUUID: "proj-1",
+				AreaUUID:    "area-1",
+				Tags:        []string{"urgent", "q1"},
+				Deadline:    &deadline,
+				List:        "today",
+			},
+			map[string]interface{}{
+				"title":    "Ship release",
+				"notes":    "Tag the build",
+				"list_id":  "proj-1", // The project wins over the area
+				"tags":     []interface{}{"urgent", "q1"},
+				"deadline": "2026-03-12",
+				"when":     "today",
+			},
+		},
+		{"area only", CreateTaskInput{Title: "X", AreaUUID: "area-1"}, map[string]interface{}{"title": "X", "list_id": "area-1"}},
+		{"inbox is the default", CreateTaskInput{Title: "X", List: "inbox"}, map[string]interface{}{"title": "X"}},
+		{"someday", CreateTaskInput{Title: "X", List: "someday"}, map[string]interface{}{"title": "X", "when": "someday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := mockClient("create_todo", toolResponse(t))
+			if _, err := NewThingsProvider(client).CreateTask(context.Background(), tt.input); err != nil {
+				t.Fatalf("CreateTask() = %v", err)
+			}
+
+			calls := toolCalls(t, mock, "create_todo")
+			if len(calls) != 1 {
+				t.Fatalf("got %d create_todo calls, want 1", len(calls))
+			}
+			if !reflect.DeepEqual(calls[0], tt.want) {
+				t.Errorf("arguments = %v, want %v", calls[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTaskResult(t *testing.T) {
+	client, _ := mockClient("create_todo", toolResponse(t, "Title: Call Jane\nUUID: new-1\nStatus: incomplete"))
+	task, err := NewThingsProvider(client).CreateTask(context.Background(), CreateTaskInput{Title: "Call Jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.UUID != "new-1" {
+		t.Errorf("UUID = %q, want the server's new-1", task.UUID)
+	}
+
+	// Without a task block in the reply, the input is echoed back
+	client, _ = mockClient("create_todo", toolResponse(t, "Created"))
+	task, err = NewThingsProvider(client).CreateTask(context.Background(), CreateTaskInput{Title: "Call Jane", ProjectUUID: "proj-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Title != "Call Jane" || task.Status != "incomplete" || task.ProjectUUID != "proj-1" {
+		t.Errorf("task = %+v, want the input echoed", task)
+	}
+}
+
+func TestCreateTaskErrors(t *testing.T) {
+	client, mock := mockClient("create_todo", toolResponse(t))
+	if _, err := NewThingsProvider(client).CreateTask(context.Background(), CreateTaskInput{Notes: "no title"}); err == nil {
+		t.Error("CreateTask() without a title succeeded")
+	}
+	if calls := toolCalls(t, mock, "create_todo"); len(calls) != 0 {
+		t.Errorf("untitled task reached the server: %v", calls)
+	}
+
+	mock = transport.NewMockTransport(nil, map[string]error{"tools/call/create_todo": errors.New("boom")})
+	_, err := NewThingsProvider(mcp.NewClient(mock, "test")).CreateTask(context.Background(), CreateTaskInput{Title: "X"})
+	if err == nil || !strings.HasPrefix(err.Error(), "create_todo failed: ") {
+		t.Errorf("CreateTask() = %v, want a wrapped create_todo error", err)
+	}
+}
+
+func TestUpdateTodoFieldsArguments(t *testing.T) {
+	deadline := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		fields CreateTaskInput
+		want   []map[string]interface{} // nil means no call is made
+	}{
+		{"nothing to change", CreateTaskInput{}, nil},
+		{"title", CreateTaskInput{Title: "Renamed"}, []map[string]interface{}{{"id": "t1", "title": "Renamed"}}},
+		{
+			"deadline and tags",
+			CreateTaskInput{Deadline: &deadline, Tags: []string{"q1"}},
+			[]map[string]interface{}{{"id": "t1", "deadline": "2026-03-12", "tags": []interface{}{"q1"}}},
+		},
+		{"move to area", CreateTaskInput{AreaUUID: "area-1"}, []map[string]interface{}{{"id": "t1", "list_id": "area-1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := mockClient("update_todo", toolResponse(t))
+			if err := NewThingsProvider(client).UpdateTodoFields(context.Background(), "t1", tt.fields); err != nil {
+				t.Fatalf("UpdateTodoFields() = %v", err)
+			}
+			if got := toolCalls(t, mock, "update_todo"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("update_todo calls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreStatusArguments(t *testing.T) {
+	tests := []struct {
+		status string
+		want   map[string]interface{}
+	}{
+		{"incomplete", map[string]interface{}{"id": "t1", "completed": false}},
+		{"canceled", map[string]interface{}{"id": "t1", "canceled": true}},
+		{"", map[string]interface{}{"id": "t1", "completed": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			client, mock := mockClient("update_todo", toolResponse(t))
+			if err := NewThingsProvider(client).RestoreStatus(context.Background(), "t1", tt.status); err != nil {
+				t.Fatalf("RestoreStatus() = %v", err)
+			}
+			want := []map[string]interface{}{tt.want}
+			if got := toolCalls(t, mock, "update_todo"); !reflect.DeepEqual(got, want) {
+				t.Errorf("update_todo calls = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateTodoFieldsError(t *testing.T) {
+	mock := transport.NewMockTransport(nil, map[string]error{"tools/call/update_todo": errors.New("boom")})
+	err := NewThingsProvider(mcp.NewClient(mock, "test")).UpdateTodoFields(context.Background(), "t1", CreateTaskInput{Title: "X"})
+	if err == nil || !strings.Contains(err.Error(), "t1") || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("UpdateTodoFields() = %v, want an error naming the task and the cause", err)
+	}
+}
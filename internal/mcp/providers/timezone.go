@@ -0,0 +1,49 @@
+package providers
+
+import "time"
+
+// CalendarOption configures a calendar provider
+type CalendarOption func(*calendarOptions)
+
+// calendarOptions holds the settings shared by the calendar providers
+type calendarOptions struct {
+	homeTimezone *time.Location
+}
+
+// WithTimezone reports event times in the IANA zone tz, for example
+// "America/Los_Angeles", and takes "today" to mean today there. An empty
+// or unknown name keeps the system zone.
+func WithTimezone(tz string) CalendarOption {
+	return func(o *calendarOptions) {
+		if tz == "" {
+			return
+		}
+		if loc, err := time.LoadLocation(tz); err == nil {
+			o.homeTimezone = loc
+		}
+	}
+}
+
+func newCalendarOptions(opts []CalendarOption) calendarOptions {
+	o := calendarOptions{homeTimezone: time.Local}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// startOfDay is midnight of t's day in loc
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// inTimezone converts a timed event's start and end to loc. All-day
+// events are dates, not instants, and are left alone.
+func inTimezone(e CalendarEvent, loc *time.Location) CalendarEvent {
+	if !e.AllDay {
+		e.StartTime = e.StartTime.In(loc)
+		e.EndTime = e.EndTime.In(loc)
+	}
+	return e
+}
@@ -0,0 +1,19 @@
+package providers
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateLayout is the YYYY-MM-DD form Things uses for start dates and
+// deadlines
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a YYYY-MM-DD date as Things reports and accepts it
+func ParseDate(s string) (*time.Time, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, want YYYY-MM-DD", s)
+	}
+	return &t, nil
+}
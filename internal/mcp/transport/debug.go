@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultDebugLogPath is where --debug-mcp writes its log
+const DefaultDebugLogPath = "~/.config/partner/mcp-debug.log"
+
+// maxLoggedParams caps how much of each request's params is logged
+const maxLoggedParams = 500
+
+// Caller is the transport surface DebugTransport wraps and provides. It
+// matches mcp.Transport, which this package can't import.
+type Caller interface {
+	Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error)
+	Close() error
+}
+
+// DebugTransport logs every JSON-RPC call made through another transport
+type DebugTransport struct {
+	inner    Caller
+	serverID string
+	logger   *slog.Logger
+}
+
+// restartableDebugTransport is a DebugTransport over a transport that can
+// respawn its server; it keeps Restart visible to mcp.Client
+type restartableDebugTransport struct {
+	*DebugTransport
+	restarter interface{ Restart() error }
+}
+
+// NewDebugTransport wraps inner so each call is logged at debug level. The
+// result offers Restart only when inner does, so reconnect behavior is the
+// same with and without logging.
+func NewDebugTransport(inner Caller, serverID string, logger *slog.Logger) Caller {
+	d := &DebugTransport{inner: inner, serverID: serverID, logger: logger}
+	if r, ok := inner.(interface{ Restart() error }); ok {
+		return &restartableDebugTransport{DebugTransport: d, restarter: r}
+	}
+	return d
+}
+
+// Call forwards to the wrapped transport and logs the exchange
+func (t *DebugTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	start := time.Now()
+	result, err := t.inner.Call(ctx, method, params)
+
+	attrs := []any{
+		slog.String("server", t.serverID),
+		slog.String("method", method),
+		slog.String("params", truncateParams(params)),
+		slog.Int("response_bytes", len(result)),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	t.logger.DebugContext(ctx, "mcp call", attrs...)
+	return result, err
+}
+
+// Close closes the wrapped transport
+func (t *DebugTransport) Close() error {
+	return t.inner.Close()
+}
+
+// Restart respawns the wrapped server and logs the outcome
+func (t *restartableDebugTransport) Restart() error {
+	err := t.restarter.Restart()
+	attrs := []any{slog.String("server", t.serverID)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	t.logger.Debug("mcp restart", attrs...)
+	return err
+}
+
+func truncateParams(params interface{}) string {
+	if params == nil {
+		return ""
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Sprintf("<unmarshalable: %v>", err)
+	}
+	if len(data) > maxLoggedParams {
+		return string(data[:maxLoggedParams]) + "..."
+	}
+	return string(data)
+}
+
+// OpenDebugLog opens (appending) the log file at path and returns a
+// debug-level logger writing to it. The caller closes the file.
+func OpenDebugLog(path string) (*slog.Logger, *os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, fmt.Errorf("creating log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening debug log: %w", err)
+	}
+	handler := slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(handler), f, nil
+}
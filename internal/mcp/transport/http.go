@@ -0,0 +1,216 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// HTTPTransport communicates with MCP servers over HTTP by POSTing
+// JSON-RPC 2.0 requests to a single endpoint
+type HTTPTransport struct {
+	url    string
+	token  string
+	client *http.Client
+
+	reqID int64
+
+	mu          sync.Mutex
+	initialized bool
+
+	sessionID atomic.Value // string; Mcp-Session-Id assigned by the server, if any
+}
+
+// HTTPOption configures an HTTPTransport
+type HTTPOption func(*HTTPTransport)
+
+// WithBearerToken sends an Authorization: Bearer header with every request
+func WithBearerToken(token string) HTTPOption {
+	return func(t *HTTPTransport) {
+		t.token = token
+	}
+}
+
+// WithHTTPClient overrides the HTTP client used for requests
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(t *HTTPTransport) {
+		t.client = client
+	}
+}
+
+// NewHTTPTransport creates a new HTTP transport for the given endpoint URL
+func NewHTTPTransport(url string, opts ...HTTPOption) *HTTPTransport {
+	t := &HTTPTransport{
+		url:    url,
+		client: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Call makes a JSON-RPC call to the MCP server, performing the
+// initialize handshake first if needed
+func (t *HTTPTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if err := t.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+	return t.call(ctx, method, params)
+}
+
+// ensureInitialized runs the MCP handshake once; failures are retried on the next call
+func (t *HTTPTransport) ensureInitialized(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.initialized {
+		return nil
+	}
+
+	initParams := map[string]interface{}{
+		"protocolVersion": "2024-11-05",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "partner",
+			"version": "0.1.0",
+		},
+	}
+
+	if _, err := t.call(ctx, "initialize", initParams); err != nil {
+		return fmt.Errorf("failed to initialize MCP connection: %w", err)
+	}
+
+	notif := JSONRPCNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+	}
+	resp, err := t.post(ctx, notif)
+	if err != nil {
+		return fmt.Errorf("failed to send initialized notification: %w", err)
+	}
+	resp.Body.Close()
+
+	t.initialized = true
+	return nil
+}
+
+// call sends a request and waits for its response
+func (t *HTTPTransport) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	id := atomic.AddInt64(&t.reqID, 1)
+	req := JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	}
+
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if sid := resp.Header.Get("Mcp-Session-Id"); sid != "" {
+		t.sessionID.Store(sid)
+	}
+
+	var rpcResp *JSONRPCResponse
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		rpcResp, err = readEventStream(resp.Body, id)
+	} else {
+		rpcResp, err = readJSONResponse(resp.Body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	return rpcResp.Result, nil
+}
+
+// post sends a JSON-RPC message and checks the HTTP status
+func (t *HTTPTransport) post(ctx context.Context, msg interface{}) (*http.Response, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+	if t.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	if sid, _ := t.sessionID.Load().(string); sid != "" {
+		httpReq.Header.Set("Mcp-Session-Id", sid)
+	}
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return nil, fmt.Errorf("MCP server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// readJSONResponse decodes a plain JSON-RPC response body
+func readJSONResponse(r io.Reader) (*JSONRPCResponse, error) {
+	var resp JSONRPCResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
+
+// readEventStream scans an SSE body for the response matching id
+func readEventStream(r io.Reader, id int64) (*JSONRPCResponse, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var resp JSONRPCResponse
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &resp); err != nil {
+			// Skip malformed events
+			continue
+		}
+		if resp.ID == id {
+			return &resp, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+	return nil, fmt.Errorf("event stream ended without a response")
+}
+
+// Close releases the transport. HTTP connections are pooled by the client,
+// so there is no process to terminate.
+func (t *HTTPTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// RecordedCall is one call made against a MockTransport
+type RecordedCall struct {
+	Method string
+	Params json.RawMessage
+}
+
+// MockTransport serves canned responses instead of talking to a server.
+//
+// Responses and errors are keyed by method. For "tools/call", a key of the
+// form "tools/call/<tool name>" takes precedence over the plain method so
+// different tools can return different results.
+type MockTransport struct {
+	responses map[string]json.RawMessage
+	errors    map[string]error
+
+	mu     sync.Mutex
+	calls  []RecordedCall
+	closed bool
+}
+
+// NewMockTransport creates a mock transport with method→response pairs and
+// optional method→error pairs (pass nil for none)
+func NewMockTransport(responses map[string]json.RawMessage, errors map[string]error) *MockTransport {
+	return &MockTransport{
+		responses: responses,
+		errors:    errors,
+	}
+}
+
+// Call records the call and returns the configured response or error
+func (t *MockTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, fmt.Errorf("transport closed")
+	}
+	t.calls = append(t.calls, RecordedCall{Method: method, Params: raw})
+
+	for _, key := range mockKeys(method, params) {
+		if err, ok := t.errors[key]; ok {
+			return nil, err
+		}
+		if resp, ok := t.responses[key]; ok {
+			return resp, nil
+		}
+	}
+	return nil, fmt.Errorf("no mock response for %s", method)
+}
+
+// mockKeys returns the lookup keys for a call, most specific first
+func mockKeys(method string, params interface{}) []string {
+	if method == "tools/call" {
+		if p, ok := params.(map[string]interface{}); ok {
+			if name, ok := p["name"].(string); ok {
+				return []string{method + "/" + name, method}
+			}
+		}
+	}
+	return []string{method}
+}
+
+// RecordedCalls returns the calls made so far, in order
+func (t *MockTransport) RecordedCalls() []RecordedCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	calls := make([]RecordedCall, len(t.calls))
+	copy(calls, t.calls)
+	return calls
+}
+
+// Close marks the transport closed; later calls fail
+func (t *MockTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 )
 
 // JSONRPCRequest represents a JSON-RPC 2.0 request
@@ -46,14 +50,26 @@ func (e *JSONRPCError) Error() string {
 
 // StdioTransport communicates with MCP servers via stdio
 type StdioTransport struct {
-	cmd       *exec.Cmd
-	stdin     io.WriteCloser
-	stdout    *bufio.Reader
-	stderr    io.ReadCloser
-	mu        sync.Mutex
-	reqID     int64
-	started   bool
-	startOnce sync.Once
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr io.ReadCloser
+	mu     sync.Mutex // Held for a whole request/response exchange
+	procMu sync.Mutex // Guards cmd and stdin for Close, which must not wait on mu
+	reqID  int64
+
+	// Lifecycle, guarded by lifeMu. gen counts spawned processes and
+	// startedGen is the one Start last ran for, so a Restart makes the next
+	// Start launch the new process; startErr is that launch's result.
+	lifeMu     sync.Mutex
+	gen        uint64
+	startedGen uint64
+	startErr   error
+
+	// Kept so Restart can spawn a fresh process
+	command string
+	args    []string
+	opts    []StdioOption
 }
 
 // StdioOption configures a StdioTransport
@@ -68,56 +84,128 @@ func WithEnv(env ...string) StdioOption {
 
 // NewStdioTransport creates a new stdio transport
 func NewStdioTransport(command string, args []string, opts ...StdioOption) (*StdioTransport, error) {
-	cmd := exec.Command(command, args...)
-	for _, opt := range opts {
+	t := &StdioTransport{command: command, args: args, opts: opts}
+	if err := t.spawn(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// spawn prepares a new, not yet started, server process and its pipes and
+// bumps the generation. The caller holds mu, or has the transport to itself.
+func (t *StdioTransport) spawn() error {
+	cmd := exec.Command(t.command, t.args...)
+	for _, opt := range t.opts {
 		opt(cmd)
 	}
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
 	}
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	t.procMu.Lock()
+	t.cmd = cmd
+	t.stdin = stdin
+	t.procMu.Unlock()
+	t.stdout = bufio.NewReader(stdout)
+	t.stderr = stderr
+	t.gen++
+	return nil
+}
+
+// Restart kills the server process, if any, and starts a new one. Calls
+// blocked on the old process fail with a connection error; calls made
+// while it runs wait for the new process.
+func (t *StdioTransport) Restart() error {
+	t.lifeMu.Lock()
+	defer t.lifeMu.Unlock()
+
+	// Killing first unblocks a call reading the old process's stdout, so
+	// mu can be taken
+	t.Close()
+
+	t.mu.Lock()
+	if t.cmd.Process != nil {
+		// Reap the old process; the error is expected after Kill
+		_ = t.cmd.Wait()
+	}
+	err := t.spawn()
+	t.mu.Unlock()
+	if err != nil {
+		return err
 	}
 
-	return &StdioTransport{
-		cmd:    cmd,
-		stdin:  stdin,
-		stdout: bufio.NewReader(stdout),
-		stderr: stderr,
-	}, nil
+	return t.start()
+}
+
+// IsConnectionError reports whether err means the server process is gone
+// (EOF on its stdout or a broken pipe on its stdin), as opposed to an
+// error returned by the server
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr *JSONRPCError
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, os.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		strings.Contains(err.Error(), "broken pipe")
 }
 
-// Start starts the MCP server process
+// Start starts the MCP server process. Only the first call per process
+// does anything; later ones return its result.
 func (t *StdioTransport) Start() error {
-	var startErr error
-	t.startOnce.Do(func() {
-		if err := t.cmd.Start(); err != nil {
-			startErr = fmt.Errorf("failed to start MCP server: %w", err)
-			return
-		}
-		t.started = true
+	t.lifeMu.Lock()
+	defer t.lifeMu.Unlock()
+	return t.start()
+}
 
-		// Drain stderr in background to prevent blocking
-		go func() {
-			io.Copy(io.Discard, t.stderr)
-		}()
+// start is Start with lifeMu held
+func (t *StdioTransport) start() error {
+	if t.startedGen == t.gen {
+		return t.startErr
+	}
+	t.startedGen = t.gen
+	t.startErr = t.launch()
+	return t.startErr
+}
 
-		// Initialize the connection
-		if err := t.initialize(); err != nil {
-			startErr = fmt.Errorf("failed to initialize MCP connection: %w", err)
-			return
-		}
-	})
-	return startErr
+// launch starts the spawned process and performs the MCP handshake
+func (t *StdioTransport) launch() error {
+	t.procMu.Lock()
+	err := t.cmd.Start()
+	t.procMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to start MCP server: %w", err)
+	}
+
+	// Drain stderr in background to prevent blocking
+	stderr := t.stderr
+	go func() {
+		io.Copy(io.Discard, stderr)
+	}()
+
+	// Initialize the connection
+	if err := t.initialize(); err != nil {
+		return fmt.Errorf("failed to initialize MCP connection: %w", err)
+	}
+	return nil
 }
 
 // initialize sends the MCP initialization handshake
@@ -134,7 +222,7 @@ func (t *StdioTransport) initialize() error {
 		},
 	}
 
-	_, err := t.Call(ctx, "initialize", initParams)
+	_, err := t.roundTrip(ctx, "initialize", initParams)
 	if err != nil {
 		return fmt.Errorf("initialize failed: %w", err)
 	}
@@ -152,15 +240,16 @@ func (t *StdioTransport) initialize() error {
 	return err
 }
 
-// Call makes a JSON-RPC call to the MCP server
+// Call makes a JSON-RPC call to the MCP server, starting it first if needed
 func (t *StdioTransport) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
-	// Ensure started
-	if !t.started {
-		if err := t.Start(); err != nil {
-			return nil, err
-		}
+	if err := t.Start(); err != nil {
+		return nil, err
 	}
+	return t.roundTrip(ctx, method, params)
+}
 
+// roundTrip sends one request to the running process and reads its response
+func (t *StdioTransport) roundTrip(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -212,6 +301,9 @@ func (t *StdioTransport) Call(ctx context.Context, method string, params interfa
 
 // Close terminates the MCP server process
 func (t *StdioTransport) Close() error {
+	t.procMu.Lock()
+	defer t.procMu.Unlock()
+
 	if t.cmd != nil && t.cmd.Process != nil {
 		t.stdin.Close()
 		return t.cmd.Process.Kill()
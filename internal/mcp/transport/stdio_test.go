@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+// fakeServerEnv makes the test binary act as an MCP server (see TestMain)
+const fakeServerEnv = "PARTNER_FAKE_MCP_SERVER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeServerEnv) == "1" {
+		fakeServer()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeServer answers every request on stdin with its method and the
+// server's pid, preceded by a notification the client has to skip
+func fakeServer() {
+	in := bufio.NewScanner(os.Stdin)
+	for in.Scan() {
+		var req JSONRPCRequest
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil || req.ID == 0 {
+			continue
+		}
+		fmt.Println(`{"jsonrpc":"2.0","method":"notifications/message","params":{}}`)
+		fmt.Printf(`{"jsonrpc":"2.0","id":%d,"result":{"method":%q,"pid":%d}}`+"\n", req.ID, req.Method, os.Getpid())
+	}
+}
+
+// newFakeTransport starts a transport talking to fakeServer
+func newFakeTransport(t *testing.T) *StdioTransport {
+	t.Helper()
+	tr, err := NewStdioTransport(os.Args[0], []string{"-test.run=^$"}, WithEnv(fakeServerEnv+"=1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tr.Close() })
+	return tr
+}
+
+// callPid makes a call and returns the pid of the process that answered
+func callPid(t *testing.T, tr *StdioTransport) int {
+	t.Helper()
+	raw, err := tr.Call(context.Background(), "ping", nil)
+	if err != nil {
+		t.Fatalf("Call() = %v", err)
+	}
+	var result struct {
+		Method string `json:"method"`
+		Pid    int    `json:"pid"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Method != "ping" {
+		t.Errorf("answered method %q, want ping", result.Method)
+	}
+	return result.Pid
+}
+
+func TestCallStartsServer(t *testing.T) {
+	tr := newFakeTransport(t)
+	first := callPid(t, tr)
+	if second := callPid(t, tr); second != first {
+		t.Errorf("second call answered by pid %d, want the same process %d", second, first)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	tr := newFakeTransport(t)
+	before := callPid(t, tr)
+
+	if err := tr.Restart(); err != nil {
+		t.Fatalf("Restart() = %v", err)
+	}
+	after := callPid(t, tr)
+	if after == before {
+		t.Errorf("call after Restart answered by the old process %d", before)
+	}
+
+	// Start after a restart is a no-op for the new process
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	if pid := callPid(t, tr); pid != after {
+		t.Errorf("Start spawned another process: pid %d, want %d", pid, after)
+	}
+}
+
+// TestRestartConcurrentCall is meant for go test -race: calls racing a
+// restart may fail with a connection error but must not race on the
+// transport's state, and the transport works once restarts stop
+func TestRestartConcurrentCall(t *testing.T) {
+	tr := newFakeTransport(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				_, err := tr.Call(ctx, "ping", nil)
+				if err != nil && !IsConnectionError(err) {
+					t.Errorf("Call() = %v, want success or a connection error", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for range 5 {
+		if err := tr.Restart(); err != nil {
+			t.Errorf("Restart() = %v", err)
+		}
+	}
+	cancel()
+	wg.Wait()
+
+	callPid(t, tr)
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidArgs reports tool arguments that don't satisfy the tool's input schema
+type ErrInvalidArgs struct {
+	Tool           string
+	Missing        []string
+	TypeMismatches []string
+}
+
+func (e *ErrInvalidArgs) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing required: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.TypeMismatches) > 0 {
+		parts = append(parts, "wrong type: "+strings.Join(e.TypeMismatches, ", "))
+	}
+	return fmt.Sprintf("invalid arguments for %s: %s", e.Tool, strings.Join(parts, "; "))
+}
+
+// Validate checks args against the tool's JSON Schema: required fields must
+// be present and declared property types must match. Returns *ErrInvalidArgs
+// on failure.
+func (t Tool) Validate(args map[string]interface{}) error {
+	if t.InputSchema == nil {
+		return nil
+	}
+
+	invalid := &ErrInvalidArgs{Tool: t.Name}
+
+	if required, ok := t.InputSchema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, present := args[name]; !present {
+				invalid.Missing = append(invalid.Missing, name)
+			}
+		}
+	}
+
+	if props, ok := t.InputSchema["properties"].(map[string]interface{}); ok {
+		for name, value := range args {
+			prop, ok := props[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			types := schemaTypes(prop["type"])
+			if len(types) == 0 {
+				continue
+			}
+			if !matchesAnyType(value, types) {
+				invalid.TypeMismatches = append(invalid.TypeMismatches,
+					fmt.Sprintf("%s (want %s)", name, strings.Join(types, "|")))
+			}
+		}
+	}
+
+	if len(invalid.Missing) == 0 && len(invalid.TypeMismatches) == 0 {
+		return nil
+	}
+
+	// Map iteration order is random; keep messages stable
+	sort.Strings(invalid.TypeMismatches)
+	return invalid
+}
+
+// schemaTypes normalizes a JSON Schema "type" (string or array of strings)
+func schemaTypes(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, t := range types {
+		if matchesType(value, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesType reports whether a Go value would encode as the given JSON type
+func matchesType(value interface{}, jsonType string) bool {
+	if value == nil {
+		return jsonType == "null"
+	}
+
+	kind := reflect.TypeOf(value).Kind()
+	switch jsonType {
+	case "string":
+		return kind == reflect.String
+	case "boolean":
+		return kind == reflect.Bool
+	case "integer":
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return true
+		case reflect.Float32, reflect.Float64:
+			f := reflect.ValueOf(value).Float()
+			return f == float64(int64(f))
+		}
+		return false
+	case "number":
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	case "array":
+		return kind == reflect.Slice || kind == reflect.Array
+	case "object":
+		return kind == reflect.Map || kind == reflect.Struct
+	case "null":
+		return false
+	}
+	// Unknown schema type - don't reject
+	return true
+}
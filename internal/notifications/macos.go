@@ -0,0 +1,40 @@
+// Package notifications shows desktop notifications.
+package notifications
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Notifier displays a notification; swap in a fake for tests
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// MacNotifier posts notifications through AppleScript
+type MacNotifier struct{}
+
+// NewMacNotifier creates a notifier backed by osascript
+func NewMacNotifier() *MacNotifier {
+	return &MacNotifier{}
+}
+
+// Notify shows message under a "Partner" notification with title as subtitle
+func (n *MacNotifier) Notify(title, message string) error {
+	script := fmt.Sprintf(`display notification %s with title "Partner" subtitle %s`,
+		quote(message), quote(title))
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("osascript failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// quote renders s as an AppleScript string literal
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+var _ Notifier = (*MacNotifier)(nil)
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// taskCSVHeader names the columns WriteTasksCSV writes
+var taskCSVHeader = []string{"UUID", "Title", "Status", "Project", "Area", "Tags", "Deadline", "StartDate", "Notes"}
+
+// WriteTasksCSV writes one row per task, preceded by a header row when
+// header is set. Tags are joined with semicolons and dates are YYYY-MM-DD.
+func WriteTasksCSV(w io.Writer, tasks []providers.Task, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(taskCSVHeader); err != nil {
+			return err
+		}
+	}
+	for _, t := range tasks {
+		row := []string{
+			t.UUID,
+			t.Title,
+			t.Status,
+			t.ProjectTitle,
+			t.AreaTitle,
+			strings.Join(t.Tags, ";"),
+			csvDate(t.Deadline),
+			csvDate(t.StartDate),
+			t.Notes,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,56 @@
+// Package output encodes headless results for the command line
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Marshal encodes v as block-style YAML. v goes through encoding/json
+// first, so the keys and omitted fields match the --json output exactly and
+// the pane data types need no yaml tags.
+func Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// JSON is valid YAML; decoding into a node keeps the key order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert output: %w", err)
+	}
+	blockStyle(&doc)
+
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	return []byte(b.String()), nil
+}
+
+// yaml11Bools are plain scalars that YAML 1.1 parsers read as booleans.
+// yaml.v3 emits them unquoted, so strings with these values are quoted.
+var yaml11Bools = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
+
+// blockStyle drops the flow and quoting styles the JSON source carried;
+// the encoder still quotes strings that would otherwise read as another type
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && yaml11Bools[strings.ToLower(n.Value)] {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/szoloth/partner/internal/bus"
+	"github.com/szoloth/partner/internal/panes/tasks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SubscribeBus reloads the calendar when a task with a linked event is
+// completed, so the time block's status catches up, and keeps the tasks
+// pane's list for the detail modal's related tasks
+func (m *Model) SubscribeBus(b *bus.EventBus) {
+	b.Subscribe(reflect.TypeOf(tasks.TaskCompletedMsg{}), func(msg tea.Msg) tea.Cmd {
+		done := msg.(tasks.TaskCompletedMsg)
+		if done.Err != nil {
+			return nil
+		}
+		m.markTaskCompleted(done.ID)
+		if !m.hasLinkedEvent(done.Title) {
+			return nil
+		}
+		return m.ForceRefresh()
+	})
+	b.Subscribe(reflect.TypeOf(tasks.TasksLoadedMsg{}), func(msg tea.Msg) tea.Cmd {
+		if loaded := msg.(tasks.TasksLoadedMsg); loaded.Err == nil {
+			m.setTasks(loaded.Tasks)
+		}
+		return nil
+	})
+}
+
+// hasLinkedEvent reports whether a loaded event was blocked out for the
+// task, going by a shared title
+func (m *Model) hasLinkedEvent(taskTitle string) bool {
+	taskTitle = strings.TrimSpace(taskTitle)
+	if taskTitle == "" {
+		return false
+	}
+	for _, e := range m.events {
+		if strings.EqualFold(strings.TrimSpace(e.Title), taskTitle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"fmt"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// conflictMark prefixes events that overlap another event
+const conflictMark = "⚠"
+
+// setConflicts records which loaded events overlap another one
+func (m *Model) setConflicts() {
+	m.conflicts = providers.FindConflicts(m.events)
+	m.conflictIDs = make(map[string]bool, 2*len(m.conflicts))
+	for _, c := range m.conflicts {
+		m.conflictIDs[c.A.ID] = true
+		m.conflictIDs[c.B.ID] = true
+	}
+}
+
+// isConflicted reports whether an event overlaps another loaded event
+func (m *Model) isConflicted(e providers.CalendarEvent) bool {
+	return m.conflictIDs[e.ID]
+}
+
+// todayConflicts counts the conflicts whose first event starts today
+func (m *Model) todayConflicts() int {
+	today := m.now().Format("2006-01-02")
+	n := 0
+	for _, c := range m.conflicts {
+		if m.startTime(c.A).Format("2006-01-02") == today {
+			n++
+		}
+	}
+	return n
+}
+
+// conflictNote is the AI context line about today's conflicts
+func (m *Model) conflictNote() string {
+	switch n := m.todayConflicts(); n {
+	case 0:
+		return ""
+	case 1:
+		return "Note: you have 1 scheduling conflict today"
+	default:
+		return fmt.Sprintf("Note: you have %d scheduling conflicts today", n)
+	}
+}
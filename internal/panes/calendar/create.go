@@ -0,0 +1,251 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Form fields, in tab order
+const (
+	fieldTitle = iota
+	fieldDate
+	fieldStart
+	fieldEnd
+	fieldCalendar
+	fieldLocation
+	fieldNotes
+	fieldCount
+)
+
+var fieldLabels = [fieldCount]string{"Title", "Date", "Start", "End", "Calendar", "Location", "Notes"}
+
+// defaultCalendar is offered first in the calendar picker
+const defaultCalendar = "Primary"
+
+// EventCreatedMsg reports the result of submitting the creation form
+type EventCreatedMsg struct {
+	Event providers.CalendarEvent
+	Err   error
+}
+
+// eventForm is the state of the new-event modal. The calendar field is a
+// picker rather than a text input; its inputs entry is unused.
+type eventForm struct {
+	inputs     [fieldCount]textinput.Model
+	field      int
+	calendars  []string
+	calendar   int
+	errors     map[int]string // Inline errors keyed by field
+	submitErr  error          // Error returned by the provider
+	submitting bool
+	timezone   *time.Location // Zone the date and times are entered in
+}
+
+// openForm shows the creation form with today's date filled in
+func (m *Model) openForm() tea.Cmd {
+	f := &eventForm{calendars: m.calendarNames(), errors: map[int]string{}, timezone: m.timezone}
+	placeholders := [fieldCount]string{"Required", "YYYY-MM-DD", "HH:MM", "HH:MM", "", "Optional", "Optional"}
+	for i := range f.inputs {
+		in := textinput.New()
+		in.Prompt = ""
+		in.Placeholder = placeholders[i]
+		in.Width = max(10, m.width-24)
+		f.inputs[i] = in
+	}
+	f.inputs[fieldDate].SetValue(m.now().Format("2006-01-02"))
+
+	m.form = f
+	return f.inputs[fieldTitle].Focus()
+}
+
+// calendarNames lists the loaded calendars, default first
+func (m *Model) calendarNames() []string {
+	seen := map[string]bool{defaultCalendar: true}
+	var names []string
+	for _, e := range m.events {
+		if e.Calendar != "" && !seen[e.Calendar] {
+			seen[e.Calendar] = true
+			names = append(names, e.Calendar)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{defaultCalendar}, names...)
+}
+
+// focusField moves the cursor to field i
+func (f *eventForm) focusField(i int) tea.Cmd {
+	f.inputs[f.field].Blur()
+	f.field = (i + fieldCount) % fieldCount
+	if f.field == fieldCalendar {
+		return nil
+	}
+	return f.inputs[f.field].Focus()
+}
+
+// updateForm handles keys while the creation form is open
+func (m *Model) updateForm(msg tea.KeyMsg) tea.Cmd {
+	f := m.form
+	if f.submitting {
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.form = nil
+		return nil
+	case "tab", "down":
+		return f.focusField(f.field + 1)
+	case "shift+tab", "up":
+		return f.focusField(f.field - 1)
+	case "enter":
+		in, ok := f.validate()
+		if !ok {
+			return nil
+		}
+		f.submitting = true
+		f.submitErr = nil
+		return m.createEvent(in)
+	}
+
+	if f.field == fieldCalendar {
+		switch msg.String() {
+		case "left", "h":
+			f.calendar = (f.calendar - 1 + len(f.calendars)) % len(f.calendars)
+		case "right", "l", " ":
+			f.calendar = (f.calendar + 1) % len(f.calendars)
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.field], cmd = f.inputs[f.field].Update(msg)
+	delete(f.errors, f.field)
+	return cmd
+}
+
+// validate checks every field, recording inline errors, and builds the
+// provider input when all of them pass
+func (f *eventForm) validate() (providers.CreateEventInput, bool) {
+	f.errors = map[int]string{}
+	value := func(i int) string { return strings.TrimSpace(f.inputs[i].Value()) }
+
+	title := value(fieldTitle)
+	if title == "" {
+		f.errors[fieldTitle] = "title is required"
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value(fieldDate), f.timezone)
+	if err != nil {
+		f.errors[fieldDate] = "use YYYY-MM-DD"
+	}
+
+	clock := func(i int) (time.Duration, bool) {
+		t, err := time.Parse("15:04", value(i))
+		if err != nil {
+			f.errors[i] = "use HH:MM"
+			return 0, false
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+	}
+	start, startOK := clock(fieldStart)
+	end, endOK := clock(fieldEnd)
+	if startOK && endOK && start >= end {
+		f.errors[fieldEnd] = "must be after start"
+	}
+
+	if len(f.errors) > 0 {
+		return providers.CreateEventInput{}, false
+	}
+
+	in := providers.CreateEventInput{
+		Title:     title,
+		StartTime: date.Add(start),
+		EndTime:   date.Add(end),
+		Location:  value(fieldLocation),
+		Notes:     value(fieldNotes),
+	}
+	if cal := f.calendars[f.calendar]; cal != defaultCalendar {
+		in.CalendarName = cal
+	}
+	return in, true
+}
+
+// createEvent submits the form to the provider
+func (m *Model) createEvent(in providers.CreateEventInput) tea.Cmd {
+	return func() tea.Msg {
+		event, err := m.provider.CreateEvent(context.Background(), in)
+		return EventCreatedMsg{Event: event, Err: err}
+	}
+}
+
+// handleEventCreated closes the form on success or shows the error in it
+func (m *Model) handleEventCreated(msg EventCreatedMsg) tea.Cmd {
+	if m.form == nil {
+		return nil
+	}
+	if msg.Err != nil {
+		m.form.submitting = false
+		m.form.submitErr = msg.Err
+		return nil
+	}
+	m.form = nil
+	m.loading = true
+	return m.loadEvents()
+}
+
+// formView renders the creation form inside the modal
+func (m *Model) formView(width int) string {
+	f := m.form
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("New event"))
+	b.WriteString("\n\n")
+
+	for i := 0; i < fieldCount; i++ {
+		label := fmt.Sprintf("%-9s", fieldLabels[i])
+		if i == f.field {
+			label = m.styles.ListItemSelected.UnsetPaddingLeft().Render(label)
+		} else {
+			label = m.styles.Muted.Render(label)
+		}
+
+		var value string
+		if i == fieldCalendar {
+			value = "‹ " + f.calendars[f.calendar] + " ›"
+		} else {
+			value = f.inputs[i].View()
+		}
+		b.WriteString(label + " " + value + "\n")
+
+		if err, ok := f.errors[i]; ok {
+			b.WriteString(m.styles.Error.Render(strings.Repeat(" ", 10) + err))
+			b.WriteString("\n")
+		}
+	}
+
+	if f.submitErr != nil {
+		b.WriteString("\n")
+		b.WriteString(m.styles.Error.Width(width).Render(fmt.Sprintf("Error: %v", f.submitErr)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	help := "tab:next field  enter:create  esc:cancel"
+	switch {
+	case f.submitting:
+		help = "Creating..."
+	case f.field == fieldCalendar:
+		help = "←/→:calendar  tab:next field  enter:create  esc:cancel"
+	}
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
@@ -0,0 +1,241 @@
+package calendar
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// urlPattern finds meeting links (Zoom, Meet, Teams, ...) in a location
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// LinkOpenedMsg reports the result of opening an event's join link
+type LinkOpenedMsg struct {
+	URL string
+	Err error
+}
+
+// openDetail shows the detail modal for the event under the cursor
+func (m *Model) openDetail() {
+	if m.cursor >= len(m.events) {
+		return
+	}
+	m.detailOpen = true
+	m.detailID = m.events[m.cursor].ID
+	m.detailScroll = 0
+	m.detailTask = 0
+}
+
+// detailEvent returns the event shown in the detail modal, if still loaded
+func (m *Model) detailEvent() (providers.CalendarEvent, bool) {
+	for _, e := range m.events {
+		if e.ID == m.detailID {
+			return e, true
+		}
+	}
+	return providers.CalendarEvent{}, false
+}
+
+// updateDetail handles keys while the detail modal is open
+func (m *Model) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	event, ok := m.detailEvent()
+	if !ok {
+		m.detailOpen = false
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.detailOpen = false
+	case "j", "down":
+		m.detailScroll++
+	case "k", "up":
+		if m.detailScroll > 0 {
+			m.detailScroll--
+		}
+	case "o":
+		if url := meetingURL(event); url != "" {
+			return openURL(url)
+		}
+	case "tab":
+		if n := len(m.relatedTasks(event)); n > 0 {
+			m.detailTask = (m.detailTask + 1) % n
+		}
+	case "shift+tab":
+		if n := len(m.relatedTasks(event)); n > 0 {
+			m.detailTask = (m.detailTask - 1 + n) % n
+		}
+	case "d":
+		if related := m.relatedTasks(event); m.detailTask < len(related) {
+			return m.completeRelatedTask(related[m.detailTask])
+		}
+	}
+	return nil
+}
+
+// meetingURL returns the first link in the event location
+func meetingURL(event providers.CalendarEvent) string {
+	return urlPattern.FindString(event.Location)
+}
+
+// openURL launches a link in the default browser (macOS specific)
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		err := exec.Command("open", url).Start()
+		return LinkOpenedMsg{URL: url, Err: err}
+	}
+}
+
+// CapturingInput keeps global keys away while a modal is open
+func (m *Model) CapturingInput() bool {
+	return m.detailOpen || m.form != nil
+}
+
+// ModalVisible reports whether the detail or creation modal is open
+func (m *Model) ModalVisible() bool {
+	return m.detailOpen || m.form != nil
+}
+
+// SetModalSize records the modal's content size and keeps the detail
+// scroll in range for it
+func (m *Model) SetModalSize(width, height int) {
+	m.modalWidth = width
+	m.modalHeight = height
+	m.clampDetailScroll()
+}
+
+// maxDetailScroll is the furthest the detail modal can scroll when lines
+// are shown in height rows
+func maxDetailScroll(lines []string, height int) int {
+	visible := max(1, height-2) // Reserve lines for the help footer
+	return max(0, len(lines)-visible)
+}
+
+// clampDetailScroll keeps detailScroll within the open event's details,
+// after a scroll key, a reload or a resize
+func (m *Model) clampDetailScroll() {
+	event, ok := m.detailEvent()
+	if !m.detailOpen || !ok || m.modalHeight <= 0 {
+		return
+	}
+	m.detailScroll = min(m.detailScroll, maxDetailScroll(m.detailLines(event, m.modalWidth), m.modalHeight))
+}
+
+// ModalView renders the open modal's content
+func (m *Model) ModalView(width, height int) string {
+	if m.form != nil {
+		return m.formView(width)
+	}
+
+	event, ok := m.detailEvent()
+	if !ok {
+		return m.styles.Muted.Render("Event no longer loaded")
+	}
+
+	lines := m.detailLines(event, width)
+
+	// Update keeps detailScroll in range; this only guards a size it
+	// hasn't seen yet
+	scroll := min(m.detailScroll, maxDetailScroll(lines, height))
+	end := min(scroll+max(1, height-2), len(lines))
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[scroll:end], "\n"))
+	b.WriteString("\n\n")
+
+	help := "j/k:scroll"
+	if meetingURL(event) != "" {
+		help += "  o:open link"
+	}
+	if len(m.relatedTasks(event)) > 0 && m.taskProvider != nil {
+		help += "  tab:task  d:done"
+	}
+	help += "  esc:close"
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
+
+// detailLines lays out every field of an event as display lines
+func (m *Model) detailLines(event providers.CalendarEvent, width int) []string {
+	var lines []string
+
+	lines = append(lines, m.styles.Title.Render(event.Title), "")
+
+	field := func(label, value string) {
+		if value == "" {
+			return
+		}
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("%-10s", label))+value)
+	}
+
+	if event.AllDay {
+		field("When:", event.StartTime.Format("Mon, Jan 2")+" (all day)")
+	} else {
+		when := m.startTime(event).Format("Mon, Jan 2 3:04 PM")
+		if !event.EndTime.IsZero() {
+			when += " – " + m.endTime(event).Format("3:04 PM")
+		}
+		field("When:", when)
+	}
+	field("Where:", event.Location)
+	field("Calendar:", event.Calendar)
+
+	if len(event.Attendees) > 0 {
+		lines = append(lines, "", m.styles.Subtitle.Render(fmt.Sprintf("Attendees (%d)", len(event.Attendees))))
+		for _, a := range event.Attendees {
+			name := a.Name
+			if name == "" {
+				name = a.Email
+			}
+			icon, style := m.responseIcon(a.ResponseStatus)
+			lines = append(lines, "  "+style.Render(icon)+" "+name)
+		}
+	}
+
+	if event.Notes != "" {
+		lines = append(lines, "", m.styles.Subtitle.Render("Notes"))
+		wrapped := lipgloss.NewStyle().Width(width).Render(event.Notes)
+		lines = append(lines, strings.Split(wrapped, "\n")...)
+	}
+
+	if related := m.relatedTasks(event); len(related) > 0 {
+		lines = append(lines, "", m.styles.Subtitle.Render("Related Tasks"))
+		for i, t := range related {
+			box := "□"
+			style := m.styles.Base
+			if t.Status == "completed" {
+				box = "☑"
+				style = m.styles.Muted
+			}
+			cursor := "  "
+			if i == m.detailTask {
+				cursor = "> "
+				style = m.styles.ListItemSelected.PaddingLeft(0)
+			}
+			lines = append(lines, cursor+style.Render(box+" "+t.Title))
+		}
+	}
+
+	return lines
+}
+
+// responseIcon maps an RSVP status to an icon and style
+func (m *Model) responseIcon(status string) (string, lipgloss.Style) {
+	switch status {
+	case "accepted":
+		return "✓", m.styles.Success
+	case "tentative":
+		return "?", m.styles.Warning
+	case "declined":
+		return "✗", m.styles.Error
+	default:
+		return "·", m.styles.Muted
+	}
+}
@@ -3,14 +3,17 @@ package calendar
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"strings"
 	"time"
 
+	"github.com/szoloth/partner/internal/mcp"
 	"github.com/szoloth/partner/internal/mcp/providers"
 	"github.com/szoloth/partner/internal/panes"
 	"github.com/szoloth/partner/internal/theme"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ViewMode determines calendar display
@@ -20,6 +23,8 @@ const (
 	ViewToday ViewMode = iota
 	ViewWeek
 	ViewAgenda
+
+	viewCount = ViewAgenda + 1
 )
 
 // Model represents the calendar pane
@@ -34,20 +39,64 @@ type Model struct {
 	loading  bool
 	err      error
 	styles   *theme.Styles
+
+	// First hour row shown in the week grid
+	weekStartHour int
+
+	// Overlapping timed events among the loaded ones (see setConflicts)
+	conflicts   []providers.ConflictPair
+	conflictIDs map[string]bool
+
+	// Zone event times are shown in (see SetTimezone)
+	timezone *time.Location
+
+	// New-event form modal; nil when closed
+	form *eventForm
+
+	// Event detail modal; detailTask is the cursor in its related tasks
+	detailOpen   bool
+	detailID     string
+	detailScroll int
+	detailTask   int
+
+	// Content size of the modal, from SetModalSize
+	modalWidth  int
+	modalHeight int
+
+	// The tasks pane's list, kept from the bus for the detail modal's
+	// related tasks, and the provider that completes them
+	tasks        []providers.Task
+	taskProvider *providers.ThingsProvider
+
+	// Refresh is a no-op within minRefreshInterval of the last load
+	minRefreshInterval time.Duration
+	lastRefreshed      time.Time
+
+	// Offline data: when the shown data was fetched, and whether it was
+	// served from the cache because the server was unreachable
+	lastFetchedAt time.Time
+	isOffline     bool
+	retryPending  bool
 }
 
 // EventsLoadedMsg is sent when events are loaded
 type EventsLoadedMsg struct {
-	Events []providers.CalendarEvent
-	Err    error
+	Events    []providers.CalendarEvent
+	Err       error
+	FetchedAt time.Time
+	Offline   bool // Served from the cache while the server was unreachable
 }
 
 // New creates a new calendar pane
 func New(provider providers.CalendarProviderInterface) *Model {
 	return &Model{
-		provider: provider,
-		viewMode: ViewToday,
-		styles:   theme.NewStyles(),
+		provider:      provider,
+		viewMode:      ViewToday,
+		weekStartHour: defaultStartHour,
+		timezone:      time.Local,
+		styles:        theme.NewStyles(theme.Default),
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
 	}
 }
 
@@ -59,19 +108,37 @@ func (m *Model) Init() tea.Cmd {
 
 // Update implements tea.Model
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer m.clampDetailScroll()
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if !m.focused {
 			return m, nil
 		}
+		if m.form != nil {
+			return m, m.updateForm(msg)
+		}
+		if m.detailOpen {
+			return m, m.updateDetail(msg)
+		}
 
 		switch msg.String() {
+		case "enter":
+			m.openDetail()
+		case "n":
+			return m, m.openForm()
+		case "a", "d", "m":
+			return m, m.rsvp(rsvpKeys[msg.String()])
 		case "j", "down":
-			if m.cursor < len(m.events)-1 {
+			if m.viewMode == ViewWeek {
+				m.scrollWeek(1)
+			} else if m.cursor < len(m.events)-1 {
 				m.cursor++
 			}
 		case "k", "up":
-			if m.cursor > 0 {
+			if m.viewMode == ViewWeek {
+				m.scrollWeek(-1)
+			} else if m.cursor > 0 {
 				m.cursor--
 			}
 		case "g":
@@ -81,22 +148,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor = len(m.events) - 1
 			}
 		case "r":
+			return m, m.Refresh()
+		// View switching; the digits stay pane shortcuts
+		case "]":
+			m.viewMode = (m.viewMode + 1) % viewCount
 			m.loading = true
 			return m, m.loadEvents()
-		case "1":
-			m.viewMode = ViewToday
-			m.loading = true
-			return m, m.loadEvents()
-		case "2":
-			m.viewMode = ViewWeek
-			m.loading = true
-			return m, m.loadEvents()
-		case "3":
-			m.viewMode = ViewAgenda
+		case "[":
+			m.viewMode = (m.viewMode + viewCount - 1) % viewCount
 			m.loading = true
 			return m, m.loadEvents()
 		}
 
+	case EventCreatedMsg:
+		return m, m.handleEventCreated(msg)
+
+	case RSVPUpdatedMsg:
+		m.handleRSVPUpdated(msg)
+
 	case EventsLoadedMsg:
 		m.loading = false
 		if msg.Err != nil {
@@ -104,6 +173,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.events = msg.Events
 			m.err = nil
+			m.setConflicts()
+			m.lastFetchedAt = msg.FetchedAt
+			m.isOffline = msg.Offline
+			// All-day and offline rows take hours from the week grid
+			m.clampWeekScroll()
+		}
+		if m.isOffline && !m.retryPending {
+			m.retryPending = true
+			return m, panes.RetryOffline(panes.PaneCalendar)
+		}
+
+	case panes.OfflineRetryMsg:
+		m.retryPending = false
+		if m.isOffline {
+			// Keep the stale events on screen while retrying
+			return m, m.loadEvents()
 		}
 	}
 
@@ -119,6 +204,13 @@ func (m *Model) View() string {
 	b.WriteString(tabs)
 	b.WriteString("\n")
 
+	linesUsed := 1 // tabs line
+	if m.isOffline && !m.loading {
+		b.WriteString("  " + panes.OfflineBanner(m.styles, m.lastFetchedAt))
+		b.WriteString("\n")
+		linesUsed++
+	}
+
 	if m.loading {
 		b.WriteString(m.styles.Muted.Render("  Loading events..."))
 		return b.String()
@@ -129,6 +221,13 @@ func (m *Model) View() string {
 		return b.String()
 	}
 
+	if m.viewMode == ViewWeek {
+		b.WriteString(m.renderWeekGrid())
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.Muted.Render("  j/k:scroll hours  n:new  r:refresh"))
+		return b.String()
+	}
+
 	if len(m.events) == 0 {
 		b.WriteString(m.styles.Muted.Render("  No events"))
 		return b.String()
@@ -137,7 +236,6 @@ func (m *Model) View() string {
 	// Group events by date for agenda view
 	eventsByDate := m.groupEventsByDate()
 
-	linesUsed := 1 // tabs line
 	for date, events := range eventsByDate {
 		if linesUsed >= m.height-3 {
 			break
@@ -154,7 +252,8 @@ func (m *Model) View() string {
 				break
 			}
 
-			line := m.renderEvent(event)
+			isCursor := m.focused && m.cursor < len(m.events) && m.events[m.cursor].ID == event.ID
+			line := m.renderEvent(event, isCursor)
 			b.WriteString(line)
 			b.WriteString("\n")
 			linesUsed++
@@ -163,7 +262,11 @@ func (m *Model) View() string {
 
 	// Help
 	b.WriteString("\n")
-	b.WriteString(m.styles.Muted.Render("  j/k:nav  r:refresh"))
+	help := "  j/k:nav  enter:details  n:new  r:refresh"
+	if m.canRSVP() {
+		help = "  j/k:nav  enter:details  a/d/m:accept/decline/maybe  n:new  r:refresh"
+	}
+	b.WriteString(m.styles.Muted.Render(help))
 
 	return b.String()
 }
@@ -175,9 +278,9 @@ func (m *Model) renderTabs() string {
 		mode  ViewMode
 		label string
 	}{
-		{ViewToday, "1:Today"},
-		{ViewWeek, "2:Week"},
-		{ViewAgenda, "3:Agenda"},
+		{ViewToday, "Today"},
+		{ViewWeek, "Week"},
+		{ViewAgenda, "Agenda"},
 	}
 
 	for _, mode := range modes {
@@ -188,15 +291,18 @@ func (m *Model) renderTabs() string {
 		}
 	}
 
+	// The zone every time below is shown in
+	tabs = append(tabs, m.styles.Muted.Render("["+m.now().Format("MST")+"]"))
+
 	return "  " + strings.Join(tabs, "  ")
 }
 
-func (m *Model) renderEvent(event providers.CalendarEvent) string {
+func (m *Model) renderEvent(event providers.CalendarEvent, isCursor bool) string {
 	var timeStr string
 	if event.AllDay {
 		timeStr = "All day"
 	} else {
-		timeStr = event.StartTime.Format("3:04 PM")
+		timeStr = m.startTime(event).Format("3:04 PM")
 	}
 
 	// Truncate title if needed
@@ -209,8 +315,16 @@ func (m *Model) renderEvent(event providers.CalendarEvent) string {
 	// Format: "  10:00 AM  Meeting title [Cal]"
 	timeStyle := m.styles.Muted
 	titleStyle := m.styles.ListItem
+	if isCursor {
+		titleStyle = m.styles.ListItemSelected
+	}
 
-	line := fmt.Sprintf("  %s  %s",
+	prefix := "  "
+	if m.isConflicted(event) {
+		prefix = m.styles.Warning.Render(conflictMark) + " "
+	}
+	line := fmt.Sprintf("%s%s  %s",
+		prefix,
 		timeStyle.Render(fmt.Sprintf("%-8s", timeStr)),
 		titleStyle.Render(title),
 	)
@@ -222,7 +336,7 @@ func (m *Model) renderEvent(event providers.CalendarEvent) string {
 		if len(calShort) > 10 {
 			calShort = calShort[:10]
 		}
-		line += m.styles.Muted.Render(" [" + calShort + "]")
+		line += m.calendarStyle(event).Render(" [" + calShort + "]")
 	}
 
 	// Add location if present
@@ -234,14 +348,38 @@ func (m *Model) renderEvent(event providers.CalendarEvent) string {
 		line += m.styles.Muted.Render(" @ " + loc)
 	}
 
+	// The user's RSVP, when invited
+	switch mark := rsvpIndicator(event); mark {
+	case "":
+	case "✓":
+		line += m.styles.Success.Render(" " + mark)
+	case "✗":
+		line += m.styles.Error.Render(" " + mark)
+	default:
+		line += m.styles.Warning.Render(" " + mark)
+	}
+
 	return line
 }
 
+// calendarStyle colors an event's calendar tag with one of the theme
+// accents, chosen by hashing the calendar ID so each calendar keeps its
+// color across refreshes
+func (m *Model) calendarStyle(event providers.CalendarEvent) lipgloss.Style {
+	if event.CalendarID == "" {
+		return m.styles.Muted
+	}
+	h := fnv.New32a()
+	h.Write([]byte(event.CalendarID))
+	accents := m.styles.Palette.Accents
+	return lipgloss.NewStyle().Foreground(accents[h.Sum32()%uint32(len(accents))])
+}
+
 func (m *Model) groupEventsByDate() map[string][]providers.CalendarEvent {
 	result := make(map[string][]providers.CalendarEvent)
 
 	for _, event := range m.events {
-		dateKey := event.StartTime.Format("2006-01-02")
+		dateKey := m.startTime(event).Format("2006-01-02")
 		result[dateKey] = append(result[dateKey], event)
 	}
 
@@ -254,11 +392,10 @@ func (m *Model) formatDateHeader(dateStr string) string {
 		return dateStr
 	}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	tomorrow := today.Add(24 * time.Hour)
+	today := m.weekStart()
+	tomorrow := today.AddDate(0, 0, 1)
 
-	eventDate := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	eventDate := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, m.timezone)
 
 	if eventDate.Equal(today) {
 		return "Today - " + t.Format("Mon, Jan 2")
@@ -270,11 +407,12 @@ func (m *Model) formatDateHeader(dateStr string) string {
 }
 
 func (m *Model) loadEvents() tea.Cmd {
+	m.lastRefreshed = time.Now()
 	viewMode := m.viewMode
 	provider := m.provider
 
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx, offline := mcp.WithOffline(context.Background())
 
 		var events []providers.CalendarEvent
 		var err error
@@ -288,7 +426,8 @@ func (m *Model) loadEvents() tea.Cmd {
 			events, err = provider.GetUpcomingEvents(ctx, 14)
 		}
 
-		return EventsLoadedMsg{Events: events, Err: err}
+		fetchedAt, isOffline := offline.FetchedAt()
+		return EventsLoadedMsg{Events: events, Err: err, FetchedAt: fetchedAt, Offline: isOffline}
 	}
 }
 
@@ -302,6 +441,43 @@ func (m *Model) Title() string {
 	return "Calendar"
 }
 
+// Badge is the start time of the next timed event, with the weekday
+// when it isn't today, after the number of today's conflicts if any
+func (m *Model) Badge() string {
+	next := m.nextEventBadge()
+	n := m.todayConflicts()
+	switch {
+	case n == 0:
+		return next
+	case next == "":
+		return fmt.Sprintf("%s%d", conflictMark, n)
+	default:
+		return fmt.Sprintf("%s%d %s", conflictMark, n, next)
+	}
+}
+
+// nextEventBadge is the start time of the next timed event
+func (m *Model) nextEventBadge() string {
+	now := m.now()
+	var next *providers.CalendarEvent
+	for i, e := range m.events {
+		if e.AllDay || !e.StartTime.After(now) {
+			continue
+		}
+		if next == nil || e.StartTime.Before(next.StartTime) {
+			next = &m.events[i]
+		}
+	}
+	if next == nil {
+		return ""
+	}
+	start := m.startTime(*next)
+	if start.Format("2006-01-02") == now.Format("2006-01-02") {
+		return start.Format("3:04 PM")
+	}
+	return start.Format("Mon 3:04 PM")
+}
+
 func (m *Model) Focus() panes.Pane {
 	m.focused = true
 	return m
@@ -319,6 +495,12 @@ func (m *Model) IsFocused() bool {
 func (m *Model) SetSize(width, height int) panes.Pane {
 	m.width = width
 	m.height = height
+	m.clampWeekScroll()
+	return m
+}
+
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
 	return m
 }
 
@@ -326,19 +508,85 @@ func (m *Model) GetData() interface{} {
 	return m.events
 }
 
+func (m *Model) GetContextSummary() string {
+	if len(m.events) == 0 {
+		return ""
+	}
+
+	var eventList []string
+	for _, e := range m.events {
+		eventList = append(eventList, fmt.Sprintf("%s at %s", e.Title, m.startTime(e).Format("Mon 3:04 PM")))
+	}
+	summary := "Schedule:\n- " + strings.Join(eventList, "\n- ")
+	if note := m.conflictNote(); note != "" {
+		summary += "\n" + note
+	}
+	return summary
+}
+
+// SearchItems lists the loaded events for global search
+func (m *Model) SearchItems() []panes.SearchItem {
+	items := make([]panes.SearchItem, len(m.events))
+	for i, e := range m.events {
+		items[i] = panes.SearchItem{ID: e.ID, Title: e.Title}
+	}
+	return items
+}
+
+// SelectItem moves the cursor to the event with the given ID
+func (m *Model) SelectItem(id string) panes.Pane {
+	for i, e := range m.events {
+		if e.ID == id {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// CurrentViewMode returns the view mode for session persistence
+func (m *Model) CurrentViewMode() int {
+	return int(m.viewMode)
+}
+
+// RestoreViewMode sets a saved view mode, ignoring unknown values
+func (m *Model) RestoreViewMode(mode int) panes.Pane {
+	if v := ViewMode(mode); v >= ViewToday && v <= ViewAgenda {
+		m.viewMode = v
+	}
+	return m
+}
+
+// Refresh reloads unless the last load was under minRefreshInterval
+// ago; it returns nil when throttled
 func (m *Model) Refresh() tea.Cmd {
+	if time.Since(m.lastRefreshed) < m.minRefreshInterval {
+		return nil
+	}
+	return m.ForceRefresh()
+}
+
+// SetMinRefreshInterval sets the Refresh throttle; zero disables it
+func (m *Model) SetMinRefreshInterval(d time.Duration) {
+	m.minRefreshInterval = d
+}
+
+// ForceRefresh fetches fresh data, ignoring the throttle
+func (m *Model) ForceRefresh() tea.Cmd {
 	m.loading = true
 	return m.loadEvents()
 }
 
 func (m *Model) ShortHelp() []string {
-	return []string{"j/k:nav", "1-3:view", "r:refresh"}
+	return []string{"j/k:nav", "[/]:view", "r:refresh"}
 }
 
 func (m *Model) FullHelp() [][]string {
 	return [][]string{
-		{"j/k", "Navigate"},
-		{"1/2/3", "Today/Week/Agenda"},
+		{"j/k", "Navigate (scroll hours in Week)"},
+		{"[ ]", "Previous/next view: Today, Week, Agenda"},
+		{"n", "New event"},
+		{"a/d/m", "RSVP accept/decline/maybe"},
 		{"r", "Refresh"},
 	}
 }
@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes/tasks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minRelatedWords is how many significant words an event title and a
+// task must share to be shown as related
+const minRelatedWords = 2
+
+// SetTaskProvider lets the detail modal complete related tasks
+func (m *Model) SetTaskProvider(p *providers.ThingsProvider) {
+	m.taskProvider = p
+}
+
+// setTasks caches the tasks pane's list, fed from the bus
+func (m *Model) setTasks(list []providers.Task) {
+	m.tasks = list
+}
+
+// markTaskCompleted shows a related task as done until the tasks pane
+// reloads
+func (m *Model) markTaskCompleted(id string) {
+	for i := range m.tasks {
+		if m.tasks[i].UUID == id {
+			m.tasks[i].Status = "completed"
+		}
+	}
+}
+
+// significantWords lowercases s and returns its words longer than three
+// letters, with surrounding punctuation trimmed
+func significantWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, f := range strings.Fields(strings.ToLower(s)) {
+		f = strings.TrimFunc(f, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if utf8.RuneCountInString(f) > 3 {
+			words[f] = true
+		}
+	}
+	return words
+}
+
+// relatedTasks lists the cached tasks sharing at least minRelatedWords
+// significant words with the event title, in their title or notes
+func (m *Model) relatedTasks(event providers.CalendarEvent) []providers.Task {
+	eventWords := significantWords(event.Title)
+	if len(eventWords) < minRelatedWords {
+		return nil
+	}
+
+	var related []providers.Task
+	for _, t := range m.tasks {
+		shared := 0
+		for w := range significantWords(t.Title + " " + t.Notes) {
+			if eventWords[w] {
+				shared++
+			}
+		}
+		if shared >= minRelatedWords {
+			related = append(related, t)
+		}
+	}
+	return related
+}
+
+// completeRelatedTask marks a related task complete in Things. The result
+// is a tasks.TaskCompletedMsg, so the tasks pane records the undo and
+// reloads as if it had been completed there.
+func (m *Model) completeRelatedTask(task providers.Task) tea.Cmd {
+	if m.taskProvider == nil || task.Status == "completed" {
+		return nil
+	}
+	provider := m.taskProvider
+	return func() tea.Msg {
+		err := provider.MarkComplete(context.Background(), task.UUID)
+		return tasks.TaskCompletedMsg{ID: task.UUID, Title: task.Title, PreviousStatus: task.Status, Err: err}
+	}
+}
@@ -0,0 +1,99 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rsvpKeys maps keys to the response they send
+var rsvpKeys = map[string]string{
+	"a": providers.RSVPAccepted,
+	"d": providers.RSVPDeclined,
+	"m": providers.RSVPTentative,
+}
+
+// RSVPUpdatedMsg reports the result of answering an invitation.
+// Previous is restored if the update failed.
+type RSVPUpdatedMsg struct {
+	EventID  string
+	Status   string
+	Previous string
+	Err      error
+}
+
+// ClaimsKey takes "a" from the global AI binding while the cursor is on an
+// event the user can RSVP to
+func (m *Model) ClaimsKey(key string) bool {
+	_, ok := rsvpKeys[key]
+	return ok && m.canRSVP()
+}
+
+// canRSVP reports whether the cursor event is an invitation to the user
+func (m *Model) canRSVP() bool {
+	if _, ok := m.provider.(providers.RSVPUpdater); !ok {
+		return false
+	}
+	if m.viewMode == ViewWeek || m.cursor >= len(m.events) {
+		return false
+	}
+	_, ok := m.events[m.cursor].SelfAttendee()
+	return ok
+}
+
+// rsvp records status on the cursor event right away and sends it to the
+// provider
+func (m *Model) rsvp(status string) tea.Cmd {
+	if !m.canRSVP() {
+		return nil
+	}
+	event := &m.events[m.cursor]
+	self, _ := event.SelfAttendee()
+	previous := self.ResponseStatus
+	if previous == status {
+		return nil
+	}
+	self.ResponseStatus = status
+
+	updater := m.provider.(providers.RSVPUpdater)
+	id := event.ID
+	return func() tea.Msg {
+		err := updater.UpdateRSVP(context.Background(), id, status)
+		return RSVPUpdatedMsg{EventID: id, Status: status, Previous: previous, Err: err}
+	}
+}
+
+// handleRSVPUpdated rolls the event back when the update failed
+func (m *Model) handleRSVPUpdated(msg RSVPUpdatedMsg) {
+	if msg.Err == nil {
+		return
+	}
+	m.err = msg.Err
+	for i := range m.events {
+		if m.events[i].ID != msg.EventID {
+			continue
+		}
+		if self, ok := m.events[i].SelfAttendee(); ok && self.ResponseStatus == msg.Status {
+			self.ResponseStatus = msg.Previous
+		}
+	}
+}
+
+// rsvpIndicator is ✓, ? or ✗ for the user's response, empty when not
+// invited
+func rsvpIndicator(event providers.CalendarEvent) string {
+	self, ok := event.SelfAttendee()
+	if !ok {
+		return ""
+	}
+	switch self.ResponseStatus {
+	case providers.RSVPAccepted:
+		return "✓"
+	case providers.RSVPDeclined:
+		return "✗"
+	default:
+		return "?"
+	}
+}
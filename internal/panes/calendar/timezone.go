@@ -0,0 +1,41 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// SetTimezone shows event times in loc and takes "today" to mean today
+// there
+func (m *Model) SetTimezone(loc *time.Location) {
+	m.timezone = loc
+}
+
+// now is the current time in the pane's zone
+func (m *Model) now() time.Time {
+	return time.Now().In(m.timezone)
+}
+
+// startTime is when e starts in the pane's zone. All-day events are dates
+// and are returned unchanged.
+func (m *Model) startTime(e providers.CalendarEvent) time.Time {
+	if e.AllDay {
+		return e.StartTime
+	}
+	return e.StartTime.In(m.timezone)
+}
+
+// endTime is when e ends in the pane's zone
+func (m *Model) endTime(e providers.CalendarEvent) time.Time {
+	if e.AllDay {
+		return e.EndTime
+	}
+	return e.EndTime.In(m.timezone)
+}
+
+// weekStart is midnight today; the week view loads the next seven days
+func (m *Model) weekStart() time.Time {
+	now := m.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, m.timezone)
+}
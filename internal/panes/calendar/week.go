@@ -0,0 +1,200 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	weekDays         = 7
+	defaultStartHour = 7 // First hour shown when the week grid opens
+	hourGutterWidth  = 3 // "07 "
+	minDayColWidth   = 3
+)
+
+// scrollWeek moves the visible hour range by delta, clamped to the day
+func (m *Model) scrollWeek(delta int) {
+	m.weekStartHour += delta
+	m.clampWeekScroll()
+}
+
+// clampWeekScroll keeps the visible hours within 00-23
+func (m *Model) clampWeekScroll() {
+	maxStart := 24 - m.visibleHours()
+	if m.weekStartHour > maxStart {
+		m.weekStartHour = maxStart
+	}
+	if m.weekStartHour < 0 {
+		m.weekStartHour = 0
+	}
+}
+
+// visibleHours is how many hour rows fit in the pane
+func (m *Model) visibleHours() int {
+	// Tabs, day header, help and its blank line
+	rows := m.height - 4
+	if m.hasAllDayEvents() {
+		rows--
+	}
+	if m.isOffline {
+		rows--
+	}
+	if rows > 24 {
+		rows = 24
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+func (m *Model) hasAllDayEvents() bool {
+	for _, e := range m.events {
+		if e.AllDay {
+			return true
+		}
+	}
+	return false
+}
+
+// renderWeekGrid draws a seven-day time grid with hour rows
+func (m *Model) renderWeekGrid() string {
+	hours := m.visibleHours()
+	// SetSize and loads keep weekStartHour in range; this only guards a
+	// state they haven't seen
+	first := max(0, min(m.weekStartHour, 24-hours))
+	colWidth := (m.width - hourGutterWidth - 2) / weekDays
+	if colWidth < minDayColWidth {
+		colWidth = minDayColWidth
+	}
+
+	start := m.weekStart()
+	days := make([][]providers.CalendarEvent, weekDays)
+	for _, e := range m.events {
+		d := int(e.StartTime.In(start.Location()).Sub(start).Hours() / 24)
+		if e.StartTime.Before(start) || d >= weekDays {
+			continue
+		}
+		days[d] = append(days[d], e)
+	}
+
+	showAllDay := m.hasAllDayEvents()
+
+	// Hour gutter
+	gutter := []string{strings.Repeat(" ", hourGutterWidth)}
+	if showAllDay {
+		gutter = append(gutter, strings.Repeat(" ", hourGutterWidth))
+	}
+	for h := first; h < first+hours; h++ {
+		gutter = append(gutter, m.styles.Muted.Render(fmt.Sprintf("%02d ", h)))
+	}
+	columns := []string{lipgloss.JoinVertical(lipgloss.Left, gutter...)}
+
+	for i := 0; i < weekDays; i++ {
+		day := start.AddDate(0, 0, i)
+		cells := []string{m.weekDayHeader(day, colWidth)}
+		if showAllDay {
+			cells = append(cells, m.allDayCell(days[i], colWidth))
+		}
+		for h := first; h < first+hours; h++ {
+			cells = append(cells, m.hourCell(days[i], day, h, colWidth))
+		}
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, cells...))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+func (m *Model) weekDayHeader(day time.Time, width int) string {
+	label := fitCell(day.Format("Mon 2"), width)
+	if day.Equal(m.weekStart()) {
+		return m.styles.Title.Render(label)
+	}
+	return m.styles.Subtitle.Render(label)
+}
+
+// allDayCell shows the day's first all-day event and how many more there are
+func (m *Model) allDayCell(events []providers.CalendarEvent, width int) string {
+	var titles []string
+	for _, e := range events {
+		if e.AllDay {
+			titles = append(titles, e.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return fitCell("", width)
+	}
+	text := titles[0]
+	if len(titles) > 1 {
+		text = fmt.Sprintf("+%d %s", len(titles)-1, text)
+	}
+	return m.styles.Warning.Render(fitCell(text, width))
+}
+
+// hourCell renders one hour of one day. An event starting in the hour shows
+// its title; hours it continues through show a bar.
+func (m *Model) hourCell(events []providers.CalendarEvent, day time.Time, hour, width int) string {
+	slotStart := day.Add(time.Duration(hour) * time.Hour)
+	slotEnd := slotStart.Add(time.Hour)
+
+	var starting, continuing []providers.CalendarEvent
+	for _, e := range events {
+		if e.AllDay {
+			continue
+		}
+		end := e.EndTime
+		if !end.After(e.StartTime) {
+			end = e.StartTime.Add(time.Hour)
+		}
+		switch {
+		case !e.StartTime.Before(slotStart) && e.StartTime.Before(slotEnd):
+			starting = append(starting, e)
+		case e.StartTime.Before(slotStart) && end.After(slotStart):
+			continuing = append(continuing, e)
+		}
+	}
+
+	switch {
+	case len(starting) > 0:
+		e := starting[0]
+		text := e.Title
+		if len(starting) > 1 {
+			text = fmt.Sprintf("+%d %s", len(starting)-1, text)
+		}
+		style := m.styles.Base
+		if m.isConflicted(e) {
+			text = conflictMark + text
+			style = m.styles.Warning
+		}
+		if m.isCursorEvent(e) {
+			style = m.styles.Title
+		}
+		return style.Render(fitCell(text, width))
+	case len(continuing) > 0:
+		style := m.styles.Subtitle
+		if m.isCursorEvent(continuing[0]) {
+			style = m.styles.Title
+		}
+		return style.Render(fitCell("│", width))
+	}
+	return m.styles.Muted.Render(fitCell("·", width))
+}
+
+func (m *Model) isCursorEvent(e providers.CalendarEvent) bool {
+	return m.focused && m.cursor < len(m.events) && m.events[m.cursor].ID == e.ID
+}
+
+// fitCell truncates or pads text to exactly width columns, leaving a
+// one-column gap between days
+func fitCell(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width-1 {
+		runes = runes[:width-1]
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,40 @@
+package cos
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// briefingMaxAge is how long a morning briefing is reshown before b asks
+// for a new one
+const briefingMaxAge = 2 * time.Hour
+
+// requestBriefing asks the app for a morning briefing, passing the cached
+// one when the last run is recent enough
+func (m *Model) requestBriefing() tea.Cmd {
+	if m.state == nil {
+		return nil
+	}
+	msg := BriefingRequestMsg{State: m.state}
+	if last := m.state.Briefings.Morning.LastRun; m.lastBriefingText != "" && last != nil && time.Since(*last) < briefingMaxAge {
+		msg.Cached = m.lastBriefingText
+	}
+	return func() tea.Msg { return msg }
+}
+
+// handleBriefingClosed caches the briefing and, for a fresh one, records
+// the run in the state file
+func (m *Model) handleBriefingClosed(msg BriefingClosedMsg) tea.Cmd {
+	m.lastBriefingText = msg.Text
+	if !msg.Fresh || m.state == nil {
+		return nil
+	}
+
+	now := time.Now()
+	m.state.Briefings.Morning.LastRun = &now
+	provider, state := m.provider, m.state
+	return func() tea.Msg {
+		return BriefingSavedMsg{Err: provider.Save(state)}
+	}
+}
@@ -0,0 +1,142 @@
+package cos
+
+import (
+	"fmt"
+	"strings"
+
+	cosstate "github.com/szoloth/partner/internal/cos"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// actionConfirm is a pending execution shown as a before/after diff until
+// the user answers y or n
+type actionConfirm struct {
+	action cosstate.PendingAction
+	after  *cosstate.State // What the state will be once the action is marked done
+}
+
+// confirmExecute previews executing the action at index
+func (m *Model) confirmExecute(index int) {
+	if m.state == nil || index >= len(m.state.ActionQueue.Pending) {
+		return
+	}
+	after := cloneState(m.state)
+	action := m.state.ActionQueue.Pending[index]
+	m.provider.MarkActionComplete(after, action.ID)
+	m.confirm = &actionConfirm{action: action, after: after}
+}
+
+// updateConfirm handles keys while the diff is shown
+func (m *Model) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y":
+		id := m.confirm.action.ID
+		m.confirm = nil
+		// Go by ID; a refresh may have reordered the queue meanwhile
+		for i, a := range m.state.ActionQueue.Pending {
+			if a.ID == id {
+				return m.executeAction(i)
+			}
+		}
+	case "n", "esc":
+		m.confirm = nil
+	}
+	return nil
+}
+
+// cloneState copies the parts of state that MarkActionComplete changes
+func cloneState(state *cosstate.State) *cosstate.State {
+	c := *state
+	c.ActionQueue.Pending = append([]cosstate.PendingAction(nil), state.ActionQueue.Pending...)
+	c.ActionQueue.CompletedToday = append([]string(nil), state.ActionQueue.CompletedToday...)
+	return &c
+}
+
+// renderConfirm draws the pending queue before and after the action side
+// by side, the streaks it moves, and the y/n prompt
+func (m *Model) renderConfirm() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("  SEND " + strings.ToUpper(actionLabel(m.confirm.action)) + "?"))
+	b.WriteString("\n\n")
+
+	colWidth := max(10, (m.width-5)/2)
+	changes := m.streakChanges()
+	// Title, blank, column headers, blank, changes, blank, prompt
+	rows := max(1, m.height-6-len(changes))
+
+	item := m.styles.ListItem.UnsetPaddingLeft()
+	var before, after []string
+	for _, a := range m.state.ActionQueue.Pending {
+		line := fitColumn(fmt.Sprintf("[%d] %s", a.ID, actionLabel(a)), colWidth)
+		if a.ID == m.confirm.action.ID {
+			before = append(before, m.styles.Error.Render(line))
+		} else {
+			before = append(before, item.Render(line))
+		}
+	}
+	for _, a := range m.confirm.after.ActionQueue.Pending {
+		after = append(after, item.Render(fitColumn(fmt.Sprintf("[%d] %s", a.ID, actionLabel(a)), colWidth)))
+	}
+	if len(after) == 0 {
+		after = append(after, m.styles.Muted.Render(fitColumn("No pending actions", colWidth)))
+	}
+	before, after = m.clipRows(before, rows), m.clipRows(after, rows)
+
+	height := max(len(before), len(after)) + 1
+	left := lipgloss.JoinVertical(lipgloss.Left,
+		append([]string{m.styles.Subtitle.Render(fitColumn("BEFORE", colWidth))}, before...)...)
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		append([]string{m.styles.Subtitle.Render(fitColumn("AFTER", colWidth))}, after...)...)
+	separator := m.styles.Muted.Render(strings.TrimSuffix(strings.Repeat("│\n", height), "\n"))
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, "  ", left, " ", separator, " ", right))
+	b.WriteString("\n")
+
+	if len(changes) > 0 {
+		b.WriteString("\n")
+		for _, c := range changes {
+			b.WriteString(m.styles.Success.Render("  " + c))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Render("  y:confirm  n:cancel"))
+	return b.String()
+}
+
+// streakChanges lists the streak values the action moves, e.g.
+// "Outreach: 2 → 3"
+func (m *Model) streakChanges() []string {
+	old, updated := m.state.Streaks, m.confirm.after.Streaks
+	var changes []string
+	change := func(name string, from, to int) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %d → %d", name, from, to))
+		}
+	}
+	change("Outreach", old.Outreach.CurrentWeek, updated.Outreach.CurrentWeek)
+	change("Needle-mover", old.NeedleMover.Current, updated.NeedleMover.Current)
+	change("Training", old.Training.DaysThisWeek, updated.Training.DaysThisWeek)
+	return changes
+}
+
+// fitColumn truncates or pads text to width columns
+func fitColumn(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		runes = append(runes[:width-1], '…')
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
+
+// clipRows keeps at most n rows, ending with a "+k more" marker
+func (m *Model) clipRows(rows []string, n int) []string {
+	if len(rows) <= n {
+		return rows
+	}
+	return append(rows[:n-1], m.styles.Muted.Render(fmt.Sprintf("+%d more", len(rows)-n+1)))
+}
@@ -1,10 +1,13 @@
 package cos
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	cosstate "github.com/szoloth/partner/internal/cos"
 	"github.com/szoloth/partner/internal/panes"
@@ -25,6 +28,20 @@ type Model struct {
 	loading bool
 	err     error
 
+	// Why the loaded state fails Validate; edits can't be saved until the
+	// file is fixed and reloaded
+	invalid error
+
+	// Execution awaiting y/n, shown as a before/after diff; nil otherwise
+	confirm *actionConfirm
+
+	// Last morning briefing read, reshown by b until it is briefingMaxAge old
+	lastBriefingText string
+
+	// Refresh is a no-op within minRefreshInterval of the last load
+	minRefreshInterval time.Duration
+	lastRefreshed      time.Time
+
 	// Dimensions
 	width   int
 	height  int
@@ -35,7 +52,9 @@ type Model struct {
 func New() *Model {
 	return &Model{
 		provider: cosstate.NewProvider(),
-		styles:   theme.NewStyles(),
+		styles:   theme.NewStyles(theme.Default),
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
 	}
 }
 
@@ -51,6 +70,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.focused {
 			return m, nil
 		}
+		if m.confirm != nil {
+			return m, m.updateConfirm(msg)
+		}
 
 		switch msg.String() {
 		// Navigation
@@ -65,9 +87,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Actions
 		case "s":
-			// Send/execute selected action
+			// Preview, then send/execute the selected action on y
 			if m.state != nil && len(m.state.ActionQueue.Pending) > 0 {
-				return m, m.executeAction(m.cursor)
+				m.confirmExecute(m.cursor)
 			}
 		case "x":
 			// Skip selected action
@@ -82,6 +104,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.state != nil && len(m.state.ActionQueue.Pending) > m.cursor {
 				return m, m.openDraft(m.cursor)
 			}
+		case "W":
+			// Ask the AI for a weekly review of this state
+			if m.state != nil {
+				state := m.state
+				return m, func() tea.Msg { return WeeklyReviewRequestMsg{State: state} }
+			}
+		case "b":
+			// Morning briefing, from cache while it is fresh
+			return m, m.requestBriefing()
+		case "ctrl+d":
+			// Deduplicate action queue
+			if m.state != nil {
+				return m, m.mergeDuplicates()
+			}
+		case "ctrl+j":
+			// Move selected action down the queue
+			return m, m.moveAction(1)
+		case "ctrl+k":
+			// Move selected action up the queue
+			return m, m.moveAction(-1)
 		}
 
 	case StateLoadedMsg:
@@ -91,6 +133,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.state = msg.State
 			m.err = nil
+			m.invalid = msg.State.Validate()
 		}
 
 	case ActionExecutedMsg:
@@ -98,7 +141,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.Err
 		} else {
 			// Refresh to show updated state
-			return m, m.Refresh()
+			return m, m.ForceRefresh()
+		}
+
+	case DuplicatesMergedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.state = msg.State
+			m.cursor = min(m.cursor, max(0, len(m.state.ActionQueue.Pending)-1))
+		}
+
+	case BriefingClosedMsg:
+		return m, m.handleBriefingClosed(msg)
+
+	case BriefingSavedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
 		}
 	}
 
@@ -116,6 +175,11 @@ func (m *Model) View() string {
 
 	if m.err != nil {
 		b.WriteString(m.styles.Error.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		var verr *cosstate.ValidationError
+		if errors.As(m.err, &verr) {
+			b.WriteString("\n")
+			b.WriteString(m.styles.Muted.Render(fmt.Sprintf("  Nothing was saved. Fix %s in %s, then press r to reload.", verr.Field, m.provider.Path())))
+		}
 		return b.String()
 	}
 
@@ -124,6 +188,10 @@ func (m *Model) View() string {
 		return b.String()
 	}
 
+	if m.confirm != nil {
+		return m.renderConfirm()
+	}
+
 	// Needle Mover section
 	b.WriteString(m.renderNeedleMover())
 	b.WriteString("\n")
@@ -164,7 +232,7 @@ func (m *Model) renderNeedleMover() string {
 
 	// Show the primary action
 	targetStyle := lipgloss.NewStyle().
-		Foreground(theme.Current.Primary).
+		Foreground(m.styles.Palette.Primary).
 		Bold(true)
 
 	actionLine := fmt.Sprintf("  %s: %s", needle.Type, needle.Company)
@@ -220,6 +288,12 @@ func (m *Model) renderStreaks() string {
 	trStatus := fmt.Sprintf("  Training: %d days this week", tr.DaysThisWeek)
 	b.WriteString(m.styles.ListItem.Render(trStatus))
 
+	// Streaks that lapsed since the last visit
+	for _, reset := range m.state.StreakResets {
+		b.WriteString("\n")
+		b.WriteString(m.styles.Warning.Render("  ! " + reset))
+	}
+
 	return b.String()
 }
 
@@ -241,13 +315,7 @@ func (m *Model) renderActionQueue() string {
 			cursor = "> "
 		}
 
-		actionText := fmt.Sprintf("[%d] %s", action.ID, action.Type)
-		if action.Company != "" {
-			actionText += fmt.Sprintf(" - %s", action.Company)
-		}
-		if action.Contact != "" {
-			actionText += fmt.Sprintf(" (%s)", action.Contact)
-		}
+		actionText := fmt.Sprintf("[%d] %s", action.ID, actionLabel(action))
 
 		var style lipgloss.Style
 		if m.focused && i == m.cursor {
@@ -266,6 +334,12 @@ func (m *Model) renderActionQueue() string {
 func (m *Model) renderAlerts() string {
 	var alerts []string
 
+	// A state file that fails validation can be read but not saved
+	if m.invalid != nil {
+		alerts = append(alerts, m.styles.Error.Render(fmt.Sprintf("  ++ %v", m.invalid)))
+		alerts = append(alerts, m.styles.Muted.Render(fmt.Sprintf("  Changes won't save until it is fixed in %s (r reloads).", m.provider.Path())))
+	}
+
 	// Avoidance detection
 	if m.provider.IsAvoidanceDetected(m.state) {
 		alert := m.styles.Warning.Render("  ++ AVOIDANCE PATTERN DETECTED")
@@ -290,7 +364,7 @@ func (m *Model) renderAlerts() string {
 }
 
 func (m *Model) renderFooter() string {
-	shortcuts := "j/k:nav  s:send  x:skip  o:open draft  r:refresh"
+	shortcuts := "j/k:nav  s:send  x:skip  o:open draft  ^j/^k:move  ^d:dedupe  b:briefing  W:weekly review  r:refresh"
 	return m.styles.Muted.Render("  " + shortcuts)
 }
 
@@ -336,6 +410,45 @@ func (m *Model) skipAction(index int) tea.Cmd {
 	}
 }
 
+// mergeDuplicates removes duplicate actions from a copy of the queue and
+// saves it; Update swaps the copy in once it is written
+func (m *Model) mergeDuplicates() tea.Cmd {
+	merged := cloneState(m.state)
+	provider := m.provider
+
+	return func() tea.Msg {
+		removed := provider.MergeDuplicateActions(merged)
+
+		// Save updated state
+		if err := provider.Save(merged); err != nil {
+			return DuplicatesMergedMsg{Err: err}
+		}
+
+		return DuplicatesMergedMsg{State: merged, Removed: removed}
+	}
+}
+
+// moveAction swaps the selected action with its neighbor and saves; the
+// cursor follows the action
+func (m *Model) moveAction(delta int) tea.Cmd {
+	if m.state == nil {
+		return nil
+	}
+	target := m.cursor + delta
+	if err := m.provider.ReorderAction(m.state, m.cursor, target); err != nil {
+		return nil
+	}
+	m.cursor = target
+	action := m.state.ActionQueue.Pending[target]
+
+	return func() tea.Msg {
+		if err := m.provider.Save(m.state); err != nil {
+			return ActionExecutedMsg{Err: err}
+		}
+		return ActionExecutedMsg{ActionID: action.ID}
+	}
+}
+
 // openDraft opens the draft file in the default editor
 func (m *Model) openDraft(index int) tea.Cmd {
 	if index >= len(m.state.ActionQueue.Pending) {
@@ -389,6 +502,17 @@ func (m *Model) SetSize(width, height int) panes.Pane {
 	return m
 }
 
+// CapturingInput holds the keyboard while an execution awaits y/n
+func (m *Model) CapturingInput() bool {
+	return m.confirm != nil
+}
+
+// SetStyles replaces the pane styles (e.g. after a theme change)
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
+	return m
+}
+
 // Type returns the pane type
 func (m *Model) Type() panes.PaneType {
 	return panes.PaneCoS
@@ -399,8 +523,32 @@ func (m *Model) Title() string {
 	return "Chief of Staff"
 }
 
-// Refresh fetches fresh data
+// Badge is the number of pending actions
+func (m *Model) Badge() string {
+	if m.state == nil {
+		return ""
+	}
+	return fmt.Sprintf("(%d)", len(m.state.ActionQueue.Pending))
+}
+
+// Refresh reloads unless the last load was under minRefreshInterval
+// ago; it returns nil when throttled. After an error it always reloads, so
+// r recovers once the state file is fixed.
 func (m *Model) Refresh() tea.Cmd {
+	if m.err == nil && time.Since(m.lastRefreshed) < m.minRefreshInterval {
+		return nil
+	}
+	return m.ForceRefresh()
+}
+
+// SetMinRefreshInterval sets the Refresh throttle; zero disables it
+func (m *Model) SetMinRefreshInterval(d time.Duration) {
+	m.minRefreshInterval = d
+}
+
+// ForceRefresh fetches fresh data, ignoring the throttle
+func (m *Model) ForceRefresh() tea.Cmd {
+	m.lastRefreshed = time.Now()
 	m.loading = true
 
 	return func() tea.Msg {
@@ -423,6 +571,44 @@ func (m *Model) GetData() interface{} {
 	}
 }
 
+// SearchItems lists pending actions for global search
+func (m *Model) SearchItems() []panes.SearchItem {
+	if m.state == nil {
+		return nil
+	}
+	items := make([]panes.SearchItem, len(m.state.ActionQueue.Pending))
+	for i, a := range m.state.ActionQueue.Pending {
+		items[i] = panes.SearchItem{ID: strconv.Itoa(a.ID), Title: actionLabel(a)}
+	}
+	return items
+}
+
+// SelectItem moves the cursor to the pending action with the given ID
+func (m *Model) SelectItem(id string) panes.Pane {
+	if m.state == nil {
+		return m
+	}
+	for i, a := range m.state.ActionQueue.Pending {
+		if strconv.Itoa(a.ID) == id {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// actionLabel describes an action as "type - Company (Contact)"
+func actionLabel(action cosstate.PendingAction) string {
+	label := action.Type
+	if action.Company != "" {
+		label += fmt.Sprintf(" - %s", action.Company)
+	}
+	if action.Contact != "" {
+		label += fmt.Sprintf(" (%s)", action.Contact)
+	}
+	return label
+}
+
 // GetState returns the full state (for AI context)
 func (m *Model) GetState() *cosstate.State {
 	return m.state
@@ -439,6 +625,38 @@ type ActionExecutedMsg struct {
 	Err      error
 }
 
+// WeeklyReviewRequestMsg asks the app to have the AI write a weekly
+// review from State and the week's completed tasks
+type WeeklyReviewRequestMsg struct {
+	State *cosstate.State
+}
+
+// BriefingRequestMsg asks the app for a morning briefing from State, or
+// to show Cached as is when it is set
+type BriefingRequestMsg struct {
+	State  *cosstate.State
+	Cached string
+}
+
+// BriefingClosedMsg is sent back by the app when the briefing modal is
+// closed. Fresh is false for a cached briefing being reshown.
+type BriefingClosedMsg struct {
+	Text  string
+	Fresh bool
+}
+
+// BriefingSavedMsg reports saving the briefing's LastRun
+type BriefingSavedMsg struct {
+	Err error
+}
+
+// DuplicatesMergedMsg carries the deduplicated state once it is saved
+type DuplicatesMergedMsg struct {
+	State   *cosstate.State
+	Removed int
+	Err     error
+}
+
 // Helper to truncate file paths
 func truncatePath(path string, maxLen int) string {
 	if len(path) <= maxLen {
@@ -0,0 +1,372 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Source lists and searches the pages the pane shows
+type Source interface {
+	SearchPages(ctx context.Context, query string) ([]providers.NotionPage, error)
+}
+
+// Model is the Knowledge pane model, listing Notion pages or, without
+// Notion, the notes of an Obsidian vault
+type Model struct {
+	provider   Source
+	sourceName string // "Notion" or "Obsidian"
+	styles     *theme.Styles
+
+	// State
+	pages   []providers.NotionPage
+	cursor  int
+	loading bool
+	err     error
+
+	// "/" search: query is sent to the source on enter; loadedQuery is the
+	// query the current pages were fetched with
+	searching   bool
+	query       string
+	loadedQuery string
+
+	// Refresh is a no-op within minRefreshInterval of the last load
+	minRefreshInterval time.Duration
+	lastRefreshed      time.Time
+
+	// Dimensions
+	width   int
+	height  int
+	focused bool
+}
+
+// New creates a new Knowledge pane listing Notion pages
+func New(provider Source) *Model {
+	return &Model{
+		provider:   provider,
+		sourceName: "Notion",
+		styles:     theme.NewStyles(theme.Default),
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
+	}
+}
+
+// Init initializes the pane
+func (m *Model) Init() tea.Cmd {
+	return m.Refresh()
+}
+
+// Update handles messages
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.focused {
+			return m, nil
+		}
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+
+		switch msg.String() {
+		case "j", "down":
+			if m.cursor < len(m.pages)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		case "G":
+			m.cursor = max(0, len(m.pages)-1)
+		case "enter", "o":
+			return m, m.openPage()
+		case "/":
+			m.searching = true
+		case "esc":
+			// Back to recent pages
+			if m.loadedQuery != "" {
+				m.query = ""
+				return m, m.ForceRefresh()
+			}
+		case "r":
+			return m, m.Refresh()
+		}
+
+	case PagesLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.pages = msg.Pages
+			m.loadedQuery = msg.Query
+			m.err = nil
+			if m.cursor >= len(m.pages) {
+				m.cursor = max(0, len(m.pages)-1)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// updateSearch handles keys while the search prompt is open. Enter runs
+// the query; esc closes the prompt and keeps the current results.
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searching = false
+		m.query = m.loadedQuery
+	case tea.KeyEnter:
+		m.searching = false
+		m.cursor = 0
+		return m.ForceRefresh()
+	case tea.KeyBackspace:
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.query += " "
+	case tea.KeyRunes:
+		m.query += string(msg.Runes)
+	}
+	return nil
+}
+
+// openPage opens the cursor page in the default browser, Notion or Obsidian
+func (m *Model) openPage() tea.Cmd {
+	if m.cursor >= len(m.pages) || m.pages[m.cursor].URL == "" {
+		return nil
+	}
+	url := m.pages[m.cursor].URL
+	return func() tea.Msg {
+		// macOS specific, like the CoS draft opener
+		err := exec.Command("open", url).Start()
+		return PageOpenedMsg{URL: url, Err: err}
+	}
+}
+
+// View renders the pane
+func (m *Model) View() string {
+	var b strings.Builder
+
+	header := fmt.Sprintf("  %d recent pages", len(m.pages))
+	if m.loadedQuery != "" {
+		header = fmt.Sprintf("  %d pages matching %q", len(m.pages), m.loadedQuery)
+	}
+	b.WriteString(m.styles.Title.Render(header))
+	b.WriteString("\n")
+
+	contentHeight := m.height - 4 // header + footer
+
+	if m.searching {
+		b.WriteString(m.styles.StatusKey.Render("  /" + m.query + "_"))
+		b.WriteString("\n")
+		contentHeight--
+	}
+
+	if m.loading {
+		b.WriteString(m.styles.Muted.Render("\n  Loading..."))
+	} else if m.err != nil {
+		b.WriteString(m.styles.Error.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+	} else if len(m.pages) == 0 {
+		b.WriteString(m.styles.Muted.Render("\n  No pages"))
+	} else {
+		start := 0
+		if m.cursor >= contentHeight {
+			start = m.cursor - contentHeight + 1
+		}
+		end := min(start+contentHeight, len(m.pages))
+
+		for i := start; i < end; i++ {
+			b.WriteString(m.renderPage(m.pages[i], i == m.cursor))
+			b.WriteString("\n")
+		}
+	}
+
+	// Pad to fill height
+	lines := strings.Count(b.String(), "\n")
+	for i := lines; i < m.height-1; i++ {
+		b.WriteString("\n")
+	}
+	help := "  j/k:nav  enter:open  /:search  r:refresh"
+	if m.loadedQuery != "" {
+		help = "  j/k:nav  enter:open  /:search  esc:recent  r:refresh"
+	}
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
+
+// renderPage renders one page with its parent and edit date
+func (m *Model) renderPage(page providers.NotionPage, isCursor bool) string {
+	cursor := "  "
+	if isCursor {
+		cursor = "> "
+	}
+
+	var meta []string
+	if page.ParentTitle != "" {
+		meta = append(meta, page.ParentTitle)
+	}
+	if !page.LastEdited.IsZero() {
+		meta = append(meta, page.LastEdited.Local().Format("Jan 2"))
+	}
+	suffix := ""
+	if len(meta) > 0 {
+		suffix = "  " + strings.Join(meta, " · ")
+	}
+
+	line := cursor + page.Title
+	if room := m.width - 2 - len([]rune(suffix)); len([]rune(line)) > room && room > 5 {
+		line = string([]rune(line)[:room-3]) + "..."
+	}
+
+	style := m.styles.ListItem
+	if isCursor {
+		style = m.styles.ListItemSelected
+	}
+	return style.Render(line) + m.styles.Muted.Render(suffix)
+}
+
+// ClaimsKey takes "/" for page search instead of global search
+func (m *Model) ClaimsKey(key string) bool {
+	return key == "/"
+}
+
+// CapturingInput reports whether the search prompt has the keyboard
+func (m *Model) CapturingInput() bool {
+	return m.searching
+}
+
+// Focus sets the pane as focused
+func (m *Model) Focus() panes.Pane {
+	m.focused = true
+	return m
+}
+
+// Blur removes focus from the pane
+func (m *Model) Blur() panes.Pane {
+	m.focused = false
+	return m
+}
+
+// IsFocused returns whether the pane is focused
+func (m *Model) IsFocused() bool {
+	return m.focused
+}
+
+// SetSize sets the pane dimensions
+func (m *Model) SetSize(width, height int) panes.Pane {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyles replaces the pane styles (e.g. after a theme change)
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
+	return m
+}
+
+// Type returns the pane type
+func (m *Model) Type() panes.PaneType {
+	return panes.PaneKnowledge
+}
+
+// Title returns the pane title
+func (m *Model) Title() string {
+	return "Knowledge"
+}
+
+// Refresh reloads unless the last load was under minRefreshInterval
+// ago; it returns nil when throttled
+func (m *Model) Refresh() tea.Cmd {
+	if time.Since(m.lastRefreshed) < m.minRefreshInterval {
+		return nil
+	}
+	return m.ForceRefresh()
+}
+
+// SetMinRefreshInterval sets the Refresh throttle; zero disables it
+func (m *Model) SetMinRefreshInterval(d time.Duration) {
+	m.minRefreshInterval = d
+}
+
+// ForceRefresh runs the current query (recent pages when empty), ignoring
+// the throttle
+func (m *Model) ForceRefresh() tea.Cmd {
+	m.lastRefreshed = time.Now()
+	m.loading = true
+	query := m.query
+	return func() tea.Msg {
+		pages, err := m.provider.SearchPages(context.Background(), query)
+		return PagesLoadedMsg{Pages: pages, Query: query, Err: err}
+	}
+}
+
+// GetData returns the listed pages for headless mode
+func (m *Model) GetData() interface{} {
+	return map[string]interface{}{
+		"query": m.loadedQuery,
+		"pages": m.pages,
+		"count": len(m.pages),
+	}
+}
+
+// GetContextSummary describes the listed pages for AI context
+func (m *Model) GetContextSummary() string {
+	if len(m.pages) == 0 {
+		return ""
+	}
+	var titles []string
+	for _, p := range m.pages {
+		titles = append(titles, p.Title)
+	}
+	return m.sourceName + " pages:\n- " + strings.Join(titles, "\n- ")
+}
+
+// SearchItems lists the loaded pages for global search
+func (m *Model) SearchItems() []panes.SearchItem {
+	items := make([]panes.SearchItem, len(m.pages))
+	for i, p := range m.pages {
+		items[i] = panes.SearchItem{ID: p.ID, Title: p.Title}
+	}
+	return items
+}
+
+// SelectItem moves the cursor to the page with the given ID
+func (m *Model) SelectItem(id string) panes.Pane {
+	for i, p := range m.pages {
+		if p.ID == id {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// PagesLoadedMsg is sent when a search or the recent list returns
+type PagesLoadedMsg struct {
+	Pages []providers.NotionPage
+	Query string
+	Err   error
+}
+
+// PageOpenedMsg reports the result of opening a page URL
+type PageOpenedMsg struct {
+	URL string
+	Err error
+}
+
+// Ensure Model implements panes.Pane
+var _ panes.Pane = (*Model)(nil)
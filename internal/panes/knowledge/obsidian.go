@@ -0,0 +1,49 @@
+package knowledge
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/theme"
+)
+
+// NewObsidian creates a Knowledge pane listing the notes of an Obsidian
+// vault; enter opens a note in the Obsidian app
+func NewObsidian(provider *providers.ObsidianProvider) *Model {
+	return &Model{
+		provider:   obsidianSource{provider},
+		sourceName: "Obsidian",
+		styles:     theme.NewStyles(theme.Default),
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
+	}
+}
+
+// obsidianSource shows vault notes as pages: the note's folder is its
+// parent and its obsidian:// link its URL
+type obsidianSource struct {
+	provider *providers.ObsidianProvider
+}
+
+func (s obsidianSource) SearchPages(ctx context.Context, query string) ([]providers.NotionPage, error) {
+	notes, err := s.provider.SearchNotes(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make([]providers.NotionPage, len(notes))
+	for i, note := range notes {
+		pages[i] = providers.NotionPage{
+			ID:         note.Path,
+			Title:      note.Title,
+			URL:        s.provider.NoteURL(note),
+			LastEdited: note.LastModified,
+		}
+		if dir := filepath.Dir(note.Path); dir != "." {
+			pages[i].ParentTitle = dir
+		}
+	}
+	return pages, nil
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLines is how many entries the pane keeps; older ones are dropped
+const maxLines = 200
+
+// Level is the severity of a log entry
+type Level string
+
+const (
+	LevelInfo  Level = "info"
+	LevelError Level = "error"
+)
+
+// Entry is one line of the log
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Level   Level     `json:"level"`
+	Message string    `json:"message"`
+}
+
+// Model is the Log pane model: a ring buffer of the app's status and
+// error messages, newest at the bottom
+type Model struct {
+	styles *theme.Styles
+
+	// Ring buffer: count entries starting at entries[start]
+	entries [maxLines]Entry
+	start   int
+	count   int
+
+	// Lines scrolled up from the newest entry; 0 follows new entries
+	offset int
+
+	// Dimensions
+	width   int
+	height  int
+	focused bool
+}
+
+// New creates a new Log pane
+func New() *Model {
+	return &Model{
+		styles: theme.NewStyles(theme.Default),
+	}
+}
+
+// Append adds an entry, dropping the oldest once maxLines are held. A
+// scrolled-back view stays on the lines it shows.
+func (m *Model) Append(level Level, message string) {
+	e := Entry{Time: time.Now(), Level: level, Message: message}
+	if m.count < maxLines {
+		m.entries[(m.start+m.count)%maxLines] = e
+		m.count++
+	} else {
+		m.entries[m.start] = e
+		m.start = (m.start + 1) % maxLines
+	}
+	if m.offset > 0 {
+		m.offset = min(m.offset+1, m.count-1)
+	}
+}
+
+// Entries returns the entries oldest first
+func (m *Model) Entries() []Entry {
+	out := make([]Entry, m.count)
+	for i := range out {
+		out[i] = m.entries[(m.start+i)%maxLines]
+	}
+	return out
+}
+
+// Clear drops every entry
+func (m *Model) Clear() {
+	m.start, m.count, m.offset = 0, 0, 0
+}
+
+// Init initializes the pane
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "j", "down":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "k", "up":
+			if m.offset < m.count-m.visibleLines() {
+				m.offset++
+			}
+		case "c":
+			m.Clear()
+		}
+	}
+	return m, nil
+}
+
+// visibleLines is how many entries fit between the header and help line
+func (m *Model) visibleLines() int {
+	return max(1, m.height-4)
+}
+
+// View renders the pane
+func (m *Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render(fmt.Sprintf("  %d entries", m.count)))
+	b.WriteString("\n")
+
+	if m.count == 0 {
+		b.WriteString(m.styles.Muted.Render("\n  No log entries"))
+	} else {
+		entries := m.Entries()
+		end := len(entries) - m.offset
+		start := max(0, end-m.visibleLines())
+		for _, e := range entries[start:end] {
+			b.WriteString(m.renderEntry(e))
+			b.WriteString("\n")
+		}
+	}
+
+	// Pad to fill height
+	lines := strings.Count(b.String(), "\n")
+	for i := lines; i < m.height-1; i++ {
+		b.WriteString("\n")
+	}
+	help := "  j/k:scroll  c:clear"
+	if m.offset > 0 {
+		help += fmt.Sprintf("  (%d newer)", m.offset)
+	}
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
+
+func (m *Model) renderEntry(e Entry) string {
+	line := "  " + e.Time.Format("15:04:05") + "  " + e.Message
+	if w := m.width - 2; w > 0 && len([]rune(line)) > w {
+		line = string([]rune(line)[:w-1]) + "…"
+	}
+	if e.Level == LevelError {
+		return m.styles.Error.Render(line)
+	}
+	return m.styles.Muted.Render(line)
+}
+
+// Focus sets the pane as focused
+func (m *Model) Focus() panes.Pane {
+	m.focused = true
+	return m
+}
+
+// Blur removes focus from the pane
+func (m *Model) Blur() panes.Pane {
+	m.focused = false
+	return m
+}
+
+// IsFocused returns whether the pane is focused
+func (m *Model) IsFocused() bool {
+	return m.focused
+}
+
+// SetSize sets the pane dimensions
+func (m *Model) SetSize(width, height int) panes.Pane {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyles replaces the pane styles (e.g. after a theme change)
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
+	return m
+}
+
+// Type returns the pane type
+func (m *Model) Type() panes.PaneType {
+	return panes.PaneLog
+}
+
+// Title returns the pane title
+func (m *Model) Title() string {
+	return "Log"
+}
+
+// Refresh is a no-op: entries arrive through Append
+func (m *Model) Refresh() tea.Cmd {
+	return nil
+}
+
+// ForceRefresh is a no-op: entries arrive through Append
+func (m *Model) ForceRefresh() tea.Cmd {
+	return nil
+}
+
+// GetData returns the entries for headless mode
+func (m *Model) GetData() interface{} {
+	return map[string]interface{}{
+		"entries": m.Entries(),
+		"count":   m.count,
+	}
+}
@@ -0,0 +1,46 @@
+package panes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OfflineRetryInterval is how often a pane showing offline data tries its
+// server again
+const OfflineRetryInterval = 30 * time.Second
+
+// OfflineRetryMsg asks the pane of type Pane to reload while it is offline
+type OfflineRetryMsg struct {
+	Pane PaneType
+}
+
+// RetryOffline schedules an OfflineRetryMsg for pane
+func RetryOffline(pane PaneType) tea.Cmd {
+	return tea.Tick(OfflineRetryInterval, func(time.Time) tea.Msg {
+		return OfflineRetryMsg{Pane: pane}
+	})
+}
+
+// OfflineBanner renders "[offline - data from 2h ago]" for data fetched at
+// fetchedAt
+func OfflineBanner(styles *theme.Styles, fetchedAt time.Time) string {
+	return styles.Warning.Render(fmt.Sprintf("[offline - data from %s]", formatAge(time.Since(fetchedAt))))
+}
+
+// formatAge renders d in its largest whole unit
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
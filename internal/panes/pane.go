@@ -1,6 +1,13 @@
 package panes
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"time"
+
+	"github.com/szoloth/partner/internal/bus"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 // PaneType identifies different pane types
 type PaneType int
@@ -13,6 +20,7 @@ const (
 	PaneCRM
 	PaneProjects
 	PaneCoS // Chief of Staff pane
+	PaneLog // Status and error history
 )
 
 // String returns the pane name
@@ -32,6 +40,8 @@ func (p PaneType) String() string {
 		return "projects"
 	case PaneCoS:
 		return "cos"
+	case PaneLog:
+		return "log"
 	default:
 		return "unknown"
 	}
@@ -54,6 +64,8 @@ func ParsePaneType(s string) PaneType {
 		return PaneProjects
 	case "cos":
 		return PaneCoS
+	case "log":
+		return PaneLog
 	default:
 		return PaneTasks
 	}
@@ -71,11 +83,98 @@ type Pane interface {
 	// Dimensions
 	SetSize(width, height int) Pane
 
+	// Appearance
+	SetStyles(styles *theme.Styles) Pane
+
 	// Identity
 	Type() PaneType
 	Title() string
 
-	// Data operations
+	// Data operations. Refresh is throttled (see RefreshThrottler);
+	// ForceRefresh always reloads.
 	Refresh() tea.Cmd
+	ForceRefresh() tea.Cmd
 	GetData() interface{}
 }
+
+// DefaultMinRefreshInterval is how soon after a load a pane ignores Refresh
+const DefaultMinRefreshInterval = 10 * time.Second
+
+// RefreshThrottler is implemented by panes whose Refresh is throttled, so
+// the app can apply the configured interval
+type RefreshThrottler interface {
+	SetMinRefreshInterval(d time.Duration)
+}
+
+// ContextSummarizer is implemented by panes that can describe their
+// loaded data as plain text for AI context
+type ContextSummarizer interface {
+	GetContextSummary() string
+}
+
+// InputCapturer is implemented by panes that can take over the keyboard
+// (e.g. while a text input is open). While CapturingInput returns true
+// the app skips its global keybindings and sends every key to the pane.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
+// KeyClaimer is implemented by panes that sometimes need a key the app
+// binds globally (e.g. "/" or "a"). When ClaimsKey returns true the key
+// goes to the focused pane instead of the global binding.
+type KeyClaimer interface {
+	ClaimsKey(key string) bool
+}
+
+// UnreadCounter is implemented by panes with a count worth showing as a
+// badge in the status bar (unread threads, overdue tasks). UnreadCount
+// returns -1 when the count does not apply.
+type UnreadCounter interface {
+	UnreadCount() int
+}
+
+// Badger is implemented by panes that show a short piece of metadata next
+// to their title, such as "(7)" for seven visible tasks. Badge is called
+// on every render and returns "" when there is nothing to show.
+type Badger interface {
+	Badge() string
+}
+
+// BusSubscriber is implemented by panes that react to messages meant for
+// other panes. SubscribeBus is called once the panes are created.
+type BusSubscriber interface {
+	SubscribeBus(b *bus.EventBus)
+}
+
+// ModalRenderer is implemented by panes that can show a modal overlay.
+// The app draws the frame; ModalView renders the content to fit inside.
+type ModalRenderer interface {
+	ModalVisible() bool
+	ModalView(width, height int) string
+}
+
+// ModalSizer is implemented by modal panes that keep their modal's scroll
+// position in range. The app reports the size ModalView will be given.
+type ModalSizer interface {
+	SetModalSize(width, height int)
+}
+
+// SearchItem is one entry of a pane's loaded data offered to global search
+type SearchItem struct {
+	ID    string // Pane-specific identifier passed back to SelectItem
+	Title string
+}
+
+// Searchable is implemented by panes whose in-memory data can be found
+// from the global search overlay
+type Searchable interface {
+	SearchItems() []SearchItem
+	SelectItem(id string) Pane
+}
+
+// ViewModeSaver is implemented by panes with switchable view modes so the
+// app can persist them across sessions
+type ViewModeSaver interface {
+	CurrentViewMode() int
+	RestoreViewMode(mode int) Pane
+}
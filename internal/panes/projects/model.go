@@ -0,0 +1,358 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp"
+	"github.com/szoloth/partner/internal/mcp/providers"
+	"github.com/szoloth/partner/internal/panes"
+	"github.com/szoloth/partner/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Model is the Projects pane model
+type Model struct {
+	provider *providers.ThingsProvider
+	styles   *theme.Styles
+
+	// State
+	projects         []providers.Project
+	expandedProjects map[string]bool // Keyed by project UUID
+	cursor           int
+	loading          bool
+	err              error
+
+	// Offline data: when the shown data was fetched, and whether it was
+	// served from the cache because the server was unreachable
+	lastFetchedAt time.Time
+	isOffline     bool
+	retryPending  bool
+
+	// Refresh is a no-op within minRefreshInterval of the last load
+	minRefreshInterval time.Duration
+	lastRefreshed      time.Time
+
+	// Dimensions
+	width   int
+	height  int
+	focused bool
+}
+
+// New creates a new Projects pane
+func New(provider *providers.ThingsProvider) *Model {
+	return &Model{
+		provider:         provider,
+		styles:           theme.NewStyles(theme.Default),
+		expandedProjects: make(map[string]bool),
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
+	}
+}
+
+// projectRow is one line of the tree: a project header or one of its tasks
+type projectRow struct {
+	project int // Index into m.projects
+	task    int // Index into the project's Items, -1 for the header
+}
+
+func (r projectRow) isHeader() bool {
+	return r.task < 0
+}
+
+// rows returns the navigable lines: every project plus tasks of expanded ones
+func (m *Model) rows() []projectRow {
+	var rows []projectRow
+	for i, p := range m.projects {
+		rows = append(rows, projectRow{project: i, task: -1})
+		if m.expandedProjects[p.UUID] {
+			for j := range p.Items {
+				rows = append(rows, projectRow{project: i, task: j})
+			}
+		}
+	}
+	return rows
+}
+
+// Init initializes the pane
+func (m *Model) Init() tea.Cmd {
+	return m.Refresh()
+}
+
+// Update handles messages
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.focused {
+			return m, nil
+		}
+
+		switch msg.String() {
+		// Navigation
+		case "j", "down":
+			if m.cursor < len(m.rows())-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		case "G":
+			if n := len(m.rows()); n > 0 {
+				m.cursor = n - 1
+			}
+
+		// Tree
+		case "enter", "right", "l":
+			m.toggle()
+		case "left", "h":
+			m.collapse()
+
+		case "r":
+			return m, m.Refresh()
+		}
+
+	case ProjectsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.projects = msg.Projects
+			m.err = nil
+			m.lastFetchedAt = msg.FetchedAt
+			m.isOffline = msg.Offline
+			// Reset cursor if out of bounds
+			if n := len(m.rows()); m.cursor >= n {
+				m.cursor = max(0, n-1)
+			}
+		}
+		if m.isOffline && !m.retryPending {
+			m.retryPending = true
+			return m, panes.RetryOffline(panes.PaneProjects)
+		}
+
+	case panes.OfflineRetryMsg:
+		m.retryPending = false
+		if m.isOffline {
+			// Keep the stale tree on screen while retrying
+			cmd := m.ForceRefresh()
+			m.loading = false
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// toggle expands or collapses the project under the cursor
+func (m *Model) toggle() {
+	rows := m.rows()
+	if m.cursor >= len(rows) || !rows[m.cursor].isHeader() {
+		return
+	}
+	uuid := m.projects[rows[m.cursor].project].UUID
+	m.expandedProjects[uuid] = !m.expandedProjects[uuid]
+}
+
+// collapse closes the project under the cursor, moving to its header
+func (m *Model) collapse() {
+	rows := m.rows()
+	if m.cursor >= len(rows) {
+		return
+	}
+	row := rows[m.cursor]
+	m.expandedProjects[m.projects[row.project].UUID] = false
+	for i, r := range m.rows() {
+		if r.project == row.project && r.isHeader() {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// View renders the pane
+func (m *Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render(fmt.Sprintf("  %d projects", len(m.projects))))
+	b.WriteString("\n")
+
+	contentHeight := m.height - 4 // header + footer
+
+	if m.isOffline {
+		b.WriteString("  " + panes.OfflineBanner(m.styles, m.lastFetchedAt))
+		b.WriteString("\n")
+		contentHeight--
+	}
+
+	if m.loading {
+		b.WriteString(m.styles.Muted.Render("\n  Loading..."))
+	} else if m.err != nil {
+		b.WriteString(m.styles.Error.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+	} else if len(m.projects) == 0 {
+		b.WriteString(m.styles.Muted.Render("\n  No projects"))
+	} else {
+		rows := m.rows()
+		start := 0
+		if m.cursor >= contentHeight {
+			start = m.cursor - contentHeight + 1
+		}
+		end := min(start+contentHeight, len(rows))
+
+		for i := start; i < end; i++ {
+			b.WriteString(m.renderRow(rows[i], i == m.cursor))
+			b.WriteString("\n")
+		}
+	}
+
+	// Pad to fill height
+	lines := strings.Count(b.String(), "\n")
+	for i := lines; i < m.height-1; i++ {
+		b.WriteString("\n")
+	}
+	b.WriteString(m.styles.Muted.Render("  j/k:nav  enter/→:expand  ←:collapse  r:refresh"))
+
+	return b.String()
+}
+
+// renderRow renders a project header or an indented task
+func (m *Model) renderRow(row projectRow, isCursor bool) string {
+	cursor := "  "
+	if isCursor {
+		cursor = "> "
+	}
+
+	project := m.projects[row.project]
+
+	var line string
+	if row.isHeader() {
+		arrow := "▸"
+		if m.expandedProjects[project.UUID] {
+			arrow = "▾"
+		}
+		line = fmt.Sprintf("%s%s %s", cursor, arrow, project.Title)
+		if n := len(project.Items); n > 0 {
+			line += fmt.Sprintf(" (%d)", n)
+		}
+	} else {
+		task := project.Items[row.task]
+		status := "[ ]"
+		if task.Status == "completed" {
+			status = "[x]"
+		}
+		line = fmt.Sprintf("%s    %s %s", cursor, status, task.Title)
+	}
+
+	if len(line) > m.width-2 && m.width > 5 {
+		line = line[:m.width-5] + "..."
+	}
+
+	switch {
+	case isCursor:
+		return m.styles.ListItemSelected.Render(line)
+	case row.isHeader():
+		return m.styles.Subtitle.Render(line)
+	default:
+		return m.styles.ListItem.Render(line)
+	}
+}
+
+// Focus sets the pane as focused
+func (m *Model) Focus() panes.Pane {
+	m.focused = true
+	return m
+}
+
+// Blur removes focus from the pane
+func (m *Model) Blur() panes.Pane {
+	m.focused = false
+	return m
+}
+
+// IsFocused returns whether the pane is focused
+func (m *Model) IsFocused() bool {
+	return m.focused
+}
+
+// SetSize sets the pane dimensions
+func (m *Model) SetSize(width, height int) panes.Pane {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyles replaces the pane styles (e.g. after a theme change)
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
+	return m
+}
+
+// Type returns the pane type
+func (m *Model) Type() panes.PaneType {
+	return panes.PaneProjects
+}
+
+// Title returns the pane title
+func (m *Model) Title() string {
+	return "Projects"
+}
+
+// Refresh reloads unless the last load was under minRefreshInterval
+// ago; it returns nil when throttled
+func (m *Model) Refresh() tea.Cmd {
+	if time.Since(m.lastRefreshed) < m.minRefreshInterval {
+		return nil
+	}
+	return m.ForceRefresh()
+}
+
+// SetMinRefreshInterval sets the Refresh throttle; zero disables it
+func (m *Model) SetMinRefreshInterval(d time.Duration) {
+	m.minRefreshInterval = d
+}
+
+// ForceRefresh fetches fresh data, ignoring the throttle
+func (m *Model) ForceRefresh() tea.Cmd {
+	m.lastRefreshed = time.Now()
+	m.loading = true
+	return func() tea.Msg {
+		ctx, offline := mcp.WithOffline(context.Background())
+		projects, err := m.provider.GetProjects(ctx, true)
+		fetchedAt, isOffline := offline.FetchedAt()
+		return ProjectsLoadedMsg{Projects: projects, Err: err, FetchedAt: fetchedAt, Offline: isOffline}
+	}
+}
+
+// GetData returns the current projects for headless mode
+func (m *Model) GetData() interface{} {
+	return map[string]interface{}{
+		"projects": m.projects,
+		"count":    len(m.projects),
+	}
+}
+
+// GetContextSummary describes the loaded projects for AI context
+func (m *Model) GetContextSummary() string {
+	if len(m.projects) == 0 {
+		return ""
+	}
+
+	var list []string
+	for _, p := range m.projects {
+		list = append(list, fmt.Sprintf("%s (%d tasks)", p.Title, len(p.Items)))
+	}
+	return "Projects:\n- " + strings.Join(list, "\n- ")
+}
+
+// Messages
+type ProjectsLoadedMsg struct {
+	Projects  []providers.Project
+	Err       error
+	FetchedAt time.Time
+	Offline   bool // Served from the cache while Things was unreachable
+}
@@ -0,0 +1,215 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/fuzzy"
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PickerAreasLoadedMsg carries the areas offered by the area picker
+type PickerAreasLoadedMsg struct {
+	Areas []providers.Area
+	Err   error
+}
+
+// areaPicker is the modal opened with A that scopes the list to one Things
+// area. It filters m.allAreas as the user types.
+type areaPicker struct {
+	input   textinput.Model
+	matches []int // Indices into Model.allAreas, best first
+	cursor  int
+	loading bool
+	err     error
+}
+
+// toggleAreaFilter clears an active area filter, or opens the picker to
+// choose one
+func (m *Model) toggleAreaFilter() tea.Cmd {
+	if m.areaFilter != nil {
+		m.setAreaFilter(nil)
+		return m.setFlash("Area filter cleared")
+	}
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "area"
+	input.CharLimit = 60
+
+	m.areaPicker = &areaPicker{input: input}
+	cmds := []tea.Cmd{m.areaPicker.input.Focus()}
+	if m.allAreas == nil {
+		m.areaPicker.loading = true
+		cmds = append(cmds, m.loadPickerAreas())
+	} else {
+		m.filterAreas()
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadPickerAreas fetches every area, without their items
+func (m *Model) loadPickerAreas() tea.Cmd {
+	provider := m.provider
+	return func() tea.Msg {
+		areas, err := provider.GetAreas(context.Background(), false)
+		return PickerAreasLoadedMsg{Areas: areas, Err: err}
+	}
+}
+
+// handlePickerAreasLoaded caches the area list and fills the picker
+func (m *Model) handlePickerAreasLoaded(msg PickerAreasLoadedMsg) {
+	if msg.Err == nil {
+		areas := msg.Areas
+		if areas == nil {
+			areas = []providers.Area{}
+		}
+		m.allAreas = areas
+	}
+	if m.areaPicker == nil {
+		return
+	}
+	m.areaPicker.loading = false
+	m.areaPicker.err = msg.Err
+	m.filterAreas()
+}
+
+// filterAreas ranks the areas against the query; an empty query lists
+// them alphabetically
+func (m *Model) filterAreas() {
+	p := m.areaPicker
+	p.cursor = 0
+	p.matches = p.matches[:0]
+
+	query := strings.TrimSpace(p.input.Value())
+	scores := make(map[int]int)
+	for i, area := range m.allAreas {
+		score, ok := fuzzy.Score(area.Title, query)
+		if !ok {
+			continue
+		}
+		scores[i] = score
+		p.matches = append(p.matches, i)
+	}
+	sort.SliceStable(p.matches, func(a, b int) bool {
+		i, j := p.matches[a], p.matches[b]
+		if scores[i] != scores[j] {
+			return scores[i] > scores[j]
+		}
+		return strings.ToLower(m.allAreas[i].Title) < strings.ToLower(m.allAreas[j].Title)
+	})
+}
+
+// updateAreaPicker handles keys while the area picker is open. Esc closes
+// it and leaves the list unfiltered.
+func (m *Model) updateAreaPicker(msg tea.KeyMsg) tea.Cmd {
+	p := m.areaPicker
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.areaPicker = nil
+		m.setAreaFilter(nil)
+		return nil
+	case tea.KeyEnter:
+		if p.cursor >= len(p.matches) {
+			return nil
+		}
+		area := m.allAreas[p.matches[p.cursor]]
+		m.areaPicker = nil
+		m.setAreaFilter(&area)
+		return nil
+	case tea.KeyUp, tea.KeyCtrlK:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil
+	case tea.KeyDown, tea.KeyCtrlJ:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	query := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		m.filterAreas()
+	}
+	return cmd
+}
+
+// setAreaFilter scopes the list to area, or unscopes it when nil
+func (m *Model) setAreaFilter(area *providers.Area) {
+	m.areaFilter = area
+	m.cursor = 0
+	m.skipHeaders(1)
+}
+
+// inAreaFilter reports whether task belongs to the filtered area. Tasks
+// parsed without an area UUID are matched by area title instead.
+func (m *Model) inAreaFilter(task providers.Task) bool {
+	if m.areaFilter == nil {
+		return true
+	}
+	if task.AreaUUID != "" {
+		return task.AreaUUID == m.areaFilter.UUID
+	}
+	return task.AreaTitle != "" && strings.EqualFold(task.AreaTitle, m.areaFilter.Title)
+}
+
+// areaPickerView renders the area picker inside the modal
+func (m *Model) areaPickerView(width int) string {
+	p := m.areaPicker
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Filter by area"))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case p.loading:
+		b.WriteString(m.styles.Muted.Render("Loading areas..."))
+		b.WriteString("\n")
+	case len(p.matches) == 0 && p.err == nil:
+		b.WriteString(m.styles.Muted.Render("No matching areas"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor inside the visible window
+	start := 0
+	if p.cursor >= maxPickerResults {
+		start = p.cursor - maxPickerResults + 1
+	}
+	end := min(len(p.matches), start+maxPickerResults)
+	for i := start; i < end; i++ {
+		label := m.allAreas[p.matches[i]].Title
+		if i == p.cursor {
+			b.WriteString(m.styles.ListItemSelected.Render("> " + label))
+		} else {
+			b.WriteString(m.styles.ListItem.Render("  " + label))
+		}
+		b.WriteString("\n")
+	}
+	if n := len(p.matches) - end; n > 0 {
+		b.WriteString(m.styles.Muted.Render(fmt.Sprintf("  ...%d more", n)))
+		b.WriteString("\n")
+	}
+
+	if p.err != nil {
+		b.WriteString("\n")
+		b.WriteString(m.styles.Error.Width(width).Render(fmt.Sprintf("Error: %v", p.err)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Render("↑/↓:select  enter:filter  esc:clear"))
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// TasksBulkCompletedMsg reports the outcome of completing the selection
+type TasksBulkCompletedMsg struct {
+	Completed []providers.Task
+	Total     int
+	Errors    int
+}
+
+// selectedTasks returns the selected tasks that are still loaded
+func (m *Model) selectedTasks() []providers.Task {
+	var tasks []providers.Task
+	for _, task := range m.tasks {
+		if m.selected[task.UUID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// selectAllVisible selects every task row currently shown
+func (m *Model) selectAllVisible() {
+	for _, row := range m.rows() {
+		if !row.isHeader() {
+			m.selected[m.tasks[row.task].UUID] = true
+		}
+	}
+}
+
+// clearSelection deselects all tasks
+func (m *Model) clearSelection() {
+	m.selected = make(map[string]bool)
+}
+
+// completeSelected marks every selected task complete in parallel
+func (m *Model) completeSelected() tea.Cmd {
+	tasks := m.selectedTasks()
+	if len(tasks) == 0 {
+		return nil
+	}
+	provider := m.provider
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		var (
+			g         errgroup.Group
+			mu        sync.Mutex
+			completed []providers.Task
+		)
+		for _, task := range tasks {
+			task := task
+			g.Go(func() error {
+				if err := provider.MarkComplete(ctx, task.UUID); err != nil {
+					return err
+				}
+				mu.Lock()
+				completed = append(completed, task)
+				mu.Unlock()
+				return nil
+			})
+		}
+		// Failures are counted rather than aborting the rest
+		_ = g.Wait()
+
+		return TasksBulkCompletedMsg{
+			Completed: completed,
+			Total:     len(tasks),
+			Errors:    len(tasks) - len(completed),
+		}
+	}
+}
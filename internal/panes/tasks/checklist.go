@@ -0,0 +1,77 @@
+package tasks
+
+import "strings"
+
+// checklistIndent is the left margin of a checklist sub-row
+const checklistIndent = 4
+
+// withChecklists inserts the checklist items of each expanded task as
+// sub-rows right below its row
+func (m *Model) withChecklists(rows []taskRow) []taskRow {
+	if len(m.expandedTasks) == 0 {
+		return rows
+	}
+	out := make([]taskRow, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row)
+		if row.isHeader() {
+			continue
+		}
+		task := m.tasks[row.task]
+		if !m.expandedTasks[task.UUID] {
+			continue
+		}
+		for i := range task.ChecklistItems {
+			out = append(out, taskRow{checklist: true, task: row.task, item: i})
+		}
+	}
+	return out
+}
+
+// currentRow returns the row under the cursor
+func (m *Model) currentRow() (taskRow, bool) {
+	rows := m.rows()
+	if m.cursor >= len(rows) {
+		return taskRow{}, false
+	}
+	return rows[m.cursor], true
+}
+
+// toggleChecklist expands or collapses the checklist of the task the
+// cursor is on or inside. Collapsing from a sub-row moves the cursor up to
+// the task so it doesn't land on an unrelated row.
+func (m *Model) toggleChecklist(row taskRow) {
+	uuid := m.tasks[row.task].UUID
+	if m.expandedTasks[uuid] {
+		delete(m.expandedTasks, uuid)
+		if row.checklist {
+			m.cursor -= row.item + 1
+		}
+		return
+	}
+	m.expandedTasks[uuid] = true
+}
+
+// renderChecklistRow renders a checklist item as "    □ title", muted once
+// the item is completed
+func (m *Model) renderChecklistRow(row taskRow, isCursor bool) string {
+	item := m.tasks[row.task].ChecklistItems[row.item]
+
+	box := "□"
+	style := m.styles.ListItem
+	if item.Status == "completed" {
+		box = "☑"
+		style = m.styles.Muted
+	}
+	indent := strings.Repeat(" ", checklistIndent)
+	if isCursor {
+		indent = strings.Repeat(" ", checklistIndent-2) + "> "
+		style = m.styles.ListItemSelected
+	}
+
+	title := item.Title
+	if runes := []rune(title); len(runes) > m.titleWidth() {
+		title = string(runes[:max(0, m.titleWidth()-3)]) + "..."
+	}
+	return style.Render(indent + box + " " + title)
+}
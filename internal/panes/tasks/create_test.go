@@ -0,0 +1,38 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+func TestTaskCreatedKeepsCursorTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		sortMode SortMode
+		tasks    []providers.Task
+		cursor   int
+		want     string // UUID under the cursor afterwards
+	}{
+		{"default sort", SortDefault, []providers.Task{{UUID: "a", Title: "A"}, {UUID: "c", Title: "C"}}, 1, "c"},
+		{"new task sorts below", SortByTitle, []providers.Task{{UUID: "a", Title: "A"}, {UUID: "c", Title: "C"}}, 0, "a"},
+		{"new task sorts between", SortByTitle, []providers.Task{{UUID: "a", Title: "A"}, {UUID: "c", Title: "C"}}, 1, "c"},
+		{"empty list", SortDefault, nil, 0, "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(nil)
+			m.sortMode = tt.sortMode
+			m.tasks = tt.tasks
+			m.cursor = tt.cursor
+
+			m.Update(TaskCreatedMsg{Task: providers.Task{UUID: "new", Title: "B"}})
+
+			task, ok := m.currentTask()
+			if !ok || task.UUID != tt.want {
+				t.Errorf("cursor on %q (ok=%v), want %q", task.UUID, ok, tt.want)
+			}
+		})
+	}
+}
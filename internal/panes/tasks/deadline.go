@@ -0,0 +1,121 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TaskDeadlineSetMsg reports saving a deadline edited with D. Previous is
+// restored if the save failed.
+type TaskDeadlineSetMsg struct {
+	ID       string
+	Deadline *time.Time
+	Previous *time.Time
+	Err      error
+}
+
+// deadlineEditor is the date input opened with D at the bottom of the pane
+type deadlineEditor struct {
+	uuid  string
+	input textinput.Model
+}
+
+// openDeadlineEditor edits task's deadline, prefilled with the current one
+// or today
+func (m *Model) openDeadlineEditor(task providers.Task) tea.Cmd {
+	date := time.Now()
+	if task.Deadline != nil {
+		date = *task.Deadline
+	}
+
+	input := textinput.New()
+	input.Prompt = "Deadline: "
+	input.Placeholder = "YYYY-MM-DD"
+	input.CharLimit = len(providers.DateLayout)
+	input.Validate = func(s string) error {
+		_, err := providers.ParseDate(s)
+		return err
+	}
+	input.SetValue(date.Format(providers.DateLayout))
+
+	m.deadline = &deadlineEditor{uuid: task.UUID, input: input}
+	return m.deadline.input.Focus()
+}
+
+// updateDeadlineEditor handles keys while the date input is open. Enter
+// does nothing until the date is valid.
+func (m *Model) updateDeadlineEditor(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.deadline = nil
+		return nil
+	case tea.KeyEnter:
+		deadline, err := providers.ParseDate(m.deadline.input.Value())
+		if err != nil {
+			m.deadline.input.Err = err
+			return nil
+		}
+		id := m.deadline.uuid
+		m.deadline = nil
+		return m.setTaskDeadline(id, deadline)
+	}
+
+	var cmd tea.Cmd
+	m.deadline.input, cmd = m.deadline.input.Update(msg)
+	return cmd
+}
+
+// setTaskDeadline shows the new deadline at once and saves it to Things
+func (m *Model) setTaskDeadline(id string, deadline *time.Time) tea.Cmd {
+	var previous *time.Time
+	for i := range m.tasks {
+		if m.tasks[i].UUID == id {
+			previous = m.tasks[i].Deadline
+			m.tasks[i].Deadline = deadline
+		}
+	}
+
+	provider := m.provider
+	return func() tea.Msg {
+		err := provider.UpdateTodo(context.Background(), id, map[string]interface{}{
+			"deadline": deadline.Format(providers.DateLayout),
+		})
+		return TaskDeadlineSetMsg{ID: id, Deadline: deadline, Previous: previous, Err: err}
+	}
+}
+
+// handleTaskDeadlineSet confirms the save, or puts the old deadline back
+func (m *Model) handleTaskDeadlineSet(msg TaskDeadlineSetMsg) tea.Cmd {
+	if msg.Err != nil {
+		for i := range m.tasks {
+			if m.tasks[i].UUID == msg.ID {
+				m.tasks[i].Deadline = msg.Previous
+			}
+		}
+		return m.setFlash(fmt.Sprintf("Deadline not saved: %v", msg.Err))
+	}
+	return m.setFlash("Deadline set to " + msg.Deadline.Format("Mon Jan 2"))
+}
+
+// renderDeadlineEditor draws the date input in a box, red while the date
+// is invalid
+func (m *Model) renderDeadlineEditor() string {
+	border := m.styles.Palette.Border
+	if m.deadline.input.Err != nil {
+		border = m.styles.Palette.Error
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Render(m.deadline.input.View())
+	help := m.styles.Muted.Render("  enter:save  esc:cancel")
+	return lipgloss.JoinHorizontal(lipgloss.Center, "  ", box, help)
+}
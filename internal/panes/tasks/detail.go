@@ -0,0 +1,217 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TaskDetailMsg opens the detail modal for a task
+type TaskDetailMsg struct {
+	Task providers.Task
+}
+
+// ChecklistUpdatedMsg reports the result of saving a checklist toggle
+type ChecklistUpdatedMsg struct {
+	ID  string
+	Err error
+}
+
+// openDetail requests the detail modal for a task
+func openDetail(task providers.Task) tea.Cmd {
+	return func() tea.Msg {
+		return TaskDetailMsg{Task: task}
+	}
+}
+
+// detailTask returns the task shown in the detail modal, if still loaded
+func (m *Model) detailTask() (int, bool) {
+	for i, t := range m.tasks {
+		if t.UUID == m.detailUUID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// updateDetail handles keys while the detail modal is open
+func (m *Model) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	idx, ok := m.detailTask()
+	if !ok {
+		m.detailOpen = false
+		return nil
+	}
+	task := m.tasks[idx]
+
+	switch msg.String() {
+	case "esc":
+		m.detailOpen = false
+	case "j", "down":
+		m.detailScroll++
+	case "k", "up":
+		if m.detailScroll > 0 {
+			m.detailScroll--
+		}
+	case "tab":
+		if n := len(task.ChecklistItems); n > 0 {
+			m.detailItem = (m.detailItem + 1) % n
+		}
+	case "shift+tab":
+		if n := len(task.ChecklistItems); n > 0 {
+			m.detailItem = (m.detailItem - 1 + n) % n
+		}
+	case " ":
+		if m.detailItem < len(task.ChecklistItems) {
+			return m.toggleChecklistItem(idx, m.detailItem)
+		}
+	}
+	return nil
+}
+
+// toggleChecklistItem flips a checklist item locally and saves the checklist
+func (m *Model) toggleChecklistItem(taskIdx, itemIdx int) tea.Cmd {
+	task := &m.tasks[taskIdx]
+
+	// Copy so the refresh-owned slice isn't shared with the command
+	items := make([]providers.ChecklistItem, len(task.ChecklistItems))
+	copy(items, task.ChecklistItems)
+	if items[itemIdx].Status == "completed" {
+		items[itemIdx].Status = "incomplete"
+	} else {
+		items[itemIdx].Status = "completed"
+	}
+	task.ChecklistItems = items
+
+	id := task.UUID
+	saved := make([]providers.ChecklistItem, len(items))
+	copy(saved, items)
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := m.provider.UpdateChecklist(ctx, id, saved)
+		return ChecklistUpdatedMsg{ID: id, Err: err}
+	}
+}
+
+// ModalVisible reports whether the detail modal, a picker or the tag
+// editor is open
+func (m *Model) ModalVisible() bool {
+	return m.detailOpen || m.picker != nil || m.areaPicker != nil || m.tags != nil
+}
+
+// SetModalSize records the modal's content size and keeps the detail
+// scroll in range for it
+func (m *Model) SetModalSize(width, height int) {
+	m.modalWidth = width
+	m.modalHeight = height
+	m.clampDetailScroll()
+}
+
+// maxDetailScroll is the furthest the detail modal can scroll when lines
+// are shown in height rows
+func maxDetailScroll(lines []string, height int) int {
+	visible := max(1, height-2) // Reserve one line for the help footer
+	return max(0, len(lines)-visible)
+}
+
+// clampDetailScroll keeps detailScroll within the open task's details,
+// after a scroll key, a reload or a resize
+func (m *Model) clampDetailScroll() {
+	idx, ok := m.detailTask()
+	if !m.detailOpen || !ok || m.modalHeight <= 0 {
+		return
+	}
+	lines := m.detailLines(m.tasks[idx], m.modalWidth)
+	m.detailScroll = min(m.detailScroll, maxDetailScroll(lines, m.modalHeight))
+}
+
+// ModalView renders the task detail modal or picker content
+func (m *Model) ModalView(width, height int) string {
+	if m.picker != nil {
+		return m.pickerView(width)
+	}
+	if m.areaPicker != nil {
+		return m.areaPickerView(width)
+	}
+	if m.tags != nil {
+		return m.tagEditorView(width)
+	}
+
+	idx, ok := m.detailTask()
+	if !ok {
+		return m.styles.Muted.Render("Task no longer loaded")
+	}
+	task := m.tasks[idx]
+
+	lines := m.detailLines(task, width)
+
+	// Update keeps detailScroll in range; this only guards a size it
+	// hasn't seen yet
+	scroll := min(m.detailScroll, maxDetailScroll(lines, height))
+	end := min(scroll+max(1, height-2), len(lines))
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[scroll:end], "\n"))
+	b.WriteString("\n\n")
+
+	help := "j/k:scroll  esc:close"
+	if len(task.ChecklistItems) > 0 {
+		help = "j/k:scroll  tab:next item  space:toggle  esc:close"
+	}
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
+
+// detailLines lays out every field of a task as display lines
+func (m *Model) detailLines(task providers.Task, width int) []string {
+	var lines []string
+
+	lines = append(lines, m.styles.Title.Render(task.Title), "")
+
+	field := func(label, value string) {
+		if value == "" {
+			return
+		}
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("%-9s", label))+value)
+	}
+	field("Project:", task.ProjectTitle)
+	field("Area:", task.AreaTitle)
+	field("Tags:", strings.Join(task.Tags, ", "))
+	if task.Deadline != nil {
+		field("Deadline:", task.Deadline.Format("Mon, Jan 2 2006"))
+	}
+	if task.StartDate != nil {
+		field("Start:", task.StartDate.Format("Mon, Jan 2 2006"))
+	}
+
+	if task.Notes != "" {
+		lines = append(lines, "", m.styles.Subtitle.Render("Notes"))
+		wrapped := lipgloss.NewStyle().Width(width).Render(task.Notes)
+		lines = append(lines, strings.Split(wrapped, "\n")...)
+	}
+
+	if len(task.ChecklistItems) > 0 {
+		lines = append(lines, "", m.styles.Subtitle.Render("Checklist"))
+		for i, item := range task.ChecklistItems {
+			box := "□"
+			style := m.styles.Base
+			if item.Status == "completed" {
+				box = "☑"
+				style = m.styles.Muted
+			}
+			cursor := "  "
+			if i == m.detailItem {
+				cursor = "> "
+				style = m.styles.ListItemSelected.PaddingLeft(0)
+			}
+			lines = append(lines, cursor+style.Render(box+" "+item.Title))
+		}
+	}
+
+	return lines
+}
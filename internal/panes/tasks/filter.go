@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Filter keys, in filter bar order
+const (
+	filterTag     = "tag"
+	filterProject = "project"
+	filterArea    = "area"
+)
+
+var filterKeys = []string{filterTag, filterProject, filterArea}
+
+var filterLabels = map[string]string{
+	filterTag:     "Tag:",
+	filterProject: "Project:",
+	filterArea:    "Area:",
+}
+
+// openFilterBar shows the filter fields, prefilled with the active filters
+func (m *Model) openFilterBar() tea.Cmd {
+	m.filterInputs = make([]textinput.Model, len(filterKeys))
+	for i, key := range filterKeys {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = 12
+		input.SetValue(m.activeFilters[key])
+		m.filterInputs[i] = input
+	}
+	m.filterField = 0
+	m.filtering = true
+	return m.filterInputs[0].Focus()
+}
+
+// closeFilterBar hides the filter fields; active filters stay applied
+func (m *Model) closeFilterBar() {
+	m.filtering = false
+	for i := range m.filterInputs {
+		m.filterInputs[i].Blur()
+	}
+}
+
+// clearFilters removes every active filter
+func (m *Model) clearFilters() {
+	m.activeFilters = make(map[string]string)
+	for i := range m.filterInputs {
+		m.filterInputs[i].SetValue("")
+	}
+	m.clampCursor()
+}
+
+// updateFilterBar handles keys while the filter bar is open. Esc closes it,
+// as does f when the current field is empty (so f can still be typed).
+func (m *Model) updateFilterBar(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "enter":
+		m.closeFilterBar()
+		return nil
+	case "f":
+		if m.filterInputs[m.filterField].Value() == "" {
+			m.closeFilterBar()
+			return nil
+		}
+	case "tab", "shift+tab":
+		m.filterInputs[m.filterField].Blur()
+		step := 1
+		if msg.String() == "shift+tab" {
+			step = len(filterKeys) - 1
+		}
+		m.filterField = (m.filterField + step) % len(filterKeys)
+		return m.filterInputs[m.filterField].Focus()
+	}
+
+	var cmd tea.Cmd
+	m.filterInputs[m.filterField], cmd = m.filterInputs[m.filterField].Update(msg)
+
+	// Apply live
+	key := filterKeys[m.filterField]
+	if value := strings.TrimSpace(m.filterInputs[m.filterField].Value()); value != "" {
+		m.activeFilters[key] = value
+	} else {
+		delete(m.activeFilters, key)
+	}
+	m.clampCursor()
+
+	return cmd
+}
+
+// matchesFilters reports whether a task passes every active filter,
+// including the area picked with A
+func (m *Model) matchesFilters(task providers.Task) bool {
+	if !m.matchesSearch(task) {
+		return false
+	}
+	if tag, ok := m.activeFilters[filterTag]; ok {
+		found := false
+		for _, t := range task.Tags {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if project, ok := m.activeFilters[filterProject]; ok && !strings.EqualFold(task.ProjectTitle, project) {
+		return false
+	}
+	if area, ok := m.activeFilters[filterArea]; ok && !strings.EqualFold(task.AreaTitle, area) {
+		return false
+	}
+	return m.inAreaFilter(task)
+}
+
+// filterIndicator renders active filters as "[tag:work] [area:home]"
+func (m *Model) filterIndicator() string {
+	var parts []string
+	for _, key := range filterKeys {
+		if value, ok := m.activeFilters[key]; ok {
+			parts = append(parts, fmt.Sprintf("[%s:%s]", key, value))
+		}
+	}
+	if m.searchQuery != "" && !m.searching {
+		parts = append(parts, fmt.Sprintf("[/%s]", m.searchQuery))
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderFilterBar renders the filter fields, highlighting the focused one
+func (m *Model) renderFilterBar() string {
+	var parts []string
+	for i, key := range filterKeys {
+		label := m.styles.Muted.Render(filterLabels[key])
+		if i == m.filterField {
+			label = m.styles.Title.Render(filterLabels[key])
+		}
+		parts = append(parts, label+" "+m.filterInputs[i].View())
+	}
+	return "  " + strings.Join(parts, "  ")
+}
+
+// clampCursor keeps the cursor on a valid row after the list changes
+func (m *Model) clampCursor() {
+	if n := len(m.rows()); m.cursor >= n {
+		m.cursor = max(0, n-1)
+	}
+	m.skipHeaders(1)
+}
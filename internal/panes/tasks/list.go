@@ -0,0 +1,63 @@
+package tasks
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// navigationKeys only move the cursor or scroll its title, so the cached
+// rows stay valid
+var navigationKeys = map[string]bool{
+	"j": true, "down": true, "k": true, "up": true, "g": true, "G": true,
+	"h": true, "left": true, "l": true, "right": true,
+}
+
+// invalidateList marks the rendered rows stale unless msg is a cursor
+// movement of the list itself
+func (m *Model) invalidateList(msg tea.Msg) {
+	if key, ok := msg.(tea.KeyMsg); ok && navigationKeys[key.String()] && !m.CapturingInput() {
+		return
+	}
+	m.listStale = true
+}
+
+// syncList hands the current rows to the list after anything but a cursor
+// move, dropping its cached rows
+func (m *Model) syncList(rows []taskRow) {
+	if m.listStale {
+		m.list.SetItems(rows)
+		m.listStale = false
+	}
+}
+
+// renderRow renders row i of the list for the current view
+func (m *Model) renderRow(i int, row taskRow, isCursor bool) string {
+	switch {
+	case row.isHeader() && m.viewMode == ViewByTag:
+		return m.renderTagHeader(row)
+	case row.isHeader():
+		return m.renderGroupHeader(row, isCursor)
+	case row.isChecklist() && m.viewMode == ViewByTag:
+		return "  " + m.renderChecklistRow(row, isCursor)
+	case row.isChecklist():
+		return m.renderChecklistRow(row, isCursor)
+	}
+	task := m.tasks[row.task]
+	switch m.viewMode {
+	case ViewByTag:
+		return "  " + m.renderTask(task, isCursor, m.selected[task.UUID])
+	case ViewDeadlines:
+		return m.renderDeadlineTask(task, isCursor, m.selected[task.UUID])
+	default:
+		return m.renderTask(task, isCursor, m.selected[task.UUID])
+	}
+}
+
+// displayHeight is how many lines s takes once the pane wraps it to its
+// width
+func (m *Model) displayHeight(s string) int {
+	if m.width <= 0 {
+		return lipgloss.Height(s)
+	}
+	return lipgloss.Height(lipgloss.NewStyle().Width(m.width).Render(s))
+}
@@ -0,0 +1,38 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestGroupHeaderColumn(t *testing.T) {
+	m := New(nil)
+	m.SetSize(60, 20)
+	m.viewMode = ViewTodayByArea
+	m.tasks = []providers.Task{
+		{UUID: "a", Title: "One", AreaTitle: "Work"},
+		{UUID: "b", Title: "Two", AreaTitle: "Home"},
+	}
+	rows := m.rows()
+
+	// Whichever row has the cursor, every header arrow sits in the column
+	// of the task rows' checkboxes
+	for cursor := range rows {
+		for i, row := range rows {
+			line := ansi.Strip(m.renderRow(i, row, i == cursor))
+			var col int
+			if row.isHeader() {
+				col = strings.IndexAny(line, "▾▸")
+			} else {
+				col = strings.Index(line, "[")
+			}
+			if col != 4 {
+				t.Errorf("cursor on row %d: row %d %q has its marker at byte %d, want 4", cursor, i, line, col)
+			}
+		}
+	}
+}
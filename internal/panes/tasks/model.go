@@ -3,12 +3,17 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/szoloth/partner/internal/mcp"
 	"github.com/szoloth/partner/internal/mcp/providers"
 	"github.com/szoloth/partner/internal/panes"
 	"github.com/szoloth/partner/internal/theme"
+	"github.com/szoloth/partner/internal/ui/virtuallist"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -21,8 +26,16 @@ const (
 	ViewInbox
 	ViewUpcoming
 	ViewAnytime
+	ViewTodayByArea
+	ViewDeadlines
+	ViewByTag
+
+	viewCount = ViewByTag + 1
 )
 
+// urgentDays is how close a deadline must be to render as a warning
+const urgentDays = 3
+
 func (v ViewMode) String() string {
 	switch v {
 	case ViewToday:
@@ -33,6 +46,12 @@ func (v ViewMode) String() string {
 		return "Upcoming"
 	case ViewAnytime:
 		return "Anytime"
+	case ViewTodayByArea:
+		return "By Area"
+	case ViewDeadlines:
+		return "Deadlines"
+	case ViewByTag:
+		return "By Tag"
 	default:
 		return "Unknown"
 	}
@@ -51,6 +70,92 @@ type Model struct {
 	err      error
 	viewMode ViewMode
 
+	// Sorting; loadOrder and areaRank capture the server order
+	sortMode  SortMode
+	loadOrder map[string]int
+	areaRank  map[string]int
+
+	// Area grouping (ViewTodayByArea)
+	collapsedAreas map[string]bool
+
+	// Tasks whose checklist items are shown as sub-rows, by UUID
+	expandedTasks map[string]bool
+
+	// In-pane title search ("/"); searching is true while the prompt is open
+	searching   bool
+	searchQuery string
+
+	// Task the AI picked as the needle mover, marked in its row
+	needleMover string
+
+	// ViewByTag regroups the list loaded by this view instead of fetching
+	tagSource ViewMode
+
+	// Inline task creation
+	creating    bool
+	createInput textinput.Model
+	savedCursor int
+
+	// Filter bar
+	activeFilters map[string]string // Keyed by tag, project, area
+	filtering     bool
+	filterInputs  []textinput.Model
+	filterField   int
+
+	// Completions that can be reverted with u, most recent last
+	undoStack []undoEntry
+	flash     string // Transient footer message
+
+	// Notes editor (e), nil when closed
+	notes *notesEditor
+
+	// Project picker (p), nil when closed. allProjects is loaded on the
+	// first p and dropped on refresh.
+	picker      *projectPicker
+	allProjects []providers.Project
+
+	// Area picker (A) and the area it scoped the list to. allAreas is
+	// loaded on the first A and dropped on refresh.
+	areaPicker *areaPicker
+	allAreas   []providers.Area
+	areaFilter *providers.Area
+
+	// Deadline input (D), nil when closed
+	deadline *deadlineEditor
+
+	// Tag editor (t), nil when closed
+	tags *tagEditor
+
+	// Rendered window of rows; listStale is set by anything but a cursor
+	// move and makes the next View reload the rows (see syncList)
+	list      *virtuallist.List[taskRow]
+	listStale bool
+
+	// Horizontal scroll of a long title with h/l; only the task named by
+	// scrollUUID is scrolled, so moving the cursor resets it
+	scrollOffset int
+	scrollUUID   string
+
+	// Task detail modal
+	detailOpen   bool
+	detailUUID   string
+	detailScroll int
+	detailItem   int
+
+	// Content size of the modal, from SetModalSize
+	modalWidth  int
+	modalHeight int
+
+	// Offline data: when the shown data was fetched, and whether it was
+	// served from the cache because the server was unreachable
+	lastFetchedAt time.Time
+	isOffline     bool
+	retryPending  bool
+
+	// Refresh is a no-op within minRefreshInterval of the last load
+	minRefreshInterval time.Duration
+	lastRefreshed      time.Time
+
 	// Dimensions
 	width   int
 	height  int
@@ -59,12 +164,123 @@ type Model struct {
 
 // New creates a new Tasks pane
 func New(provider *providers.ThingsProvider) *Model {
-	return &Model{
-		provider: provider,
-		styles:   theme.NewStyles(),
-		selected: make(map[string]bool),
-		viewMode: ViewToday,
+	m := &Model{
+		provider:       provider,
+		styles:         theme.NewStyles(theme.Default),
+		selected:       make(map[string]bool),
+		viewMode:       ViewToday,
+		collapsedAreas: make(map[string]bool),
+		expandedTasks:  make(map[string]bool),
+		activeFilters:  make(map[string]string),
+		listStale:      true,
+
+		minRefreshInterval: panes.DefaultMinRefreshInterval,
+	}
+	m.list = virtuallist.New(nil, m.renderRow, 0)
+	return m
+}
+
+// taskRow is one line of the task list: a group header, a task, or one
+// checklist item of an expanded task
+type taskRow struct {
+	header    bool   // True for group header rows
+	group     string // Group key (header rows only)
+	count     int    // Tasks in the group (header rows only)
+	task      int    // Index into m.tasks (task and checklist rows)
+	checklist bool   // True for checklist sub-rows
+	item      int    // Index into the task's ChecklistItems (checklist rows only)
+}
+
+func (r taskRow) isHeader() bool {
+	return r.header
+}
+
+func (r taskRow) isChecklist() bool {
+	return r.checklist
+}
+
+// rows returns the navigable lines for the current view, skipping tasks
+// hidden by the active filters
+func (m *Model) rows() []taskRow {
+	return m.withChecklists(m.viewRows())
+}
+
+// viewRows returns the header and task rows for the current view
+func (m *Model) viewRows() []taskRow {
+	if m.viewMode == ViewByTag {
+		return m.tagRows()
+	}
+	if m.viewMode != ViewTodayByArea {
+		rows := make([]taskRow, 0, len(m.tasks))
+		for i, task := range m.tasks {
+			if m.matchesFilters(task) {
+				rows = append(rows, taskRow{task: i})
+			}
+		}
+		return rows
 	}
+
+	// Tasks arrive sorted by area; emit a header at each area boundary
+	var rows []taskRow
+	for i := 0; i < len(m.tasks); {
+		area := m.tasks[i].AreaTitle
+		var matched []int
+		j := i
+		for ; j < len(m.tasks) && m.tasks[j].AreaTitle == area; j++ {
+			if m.matchesFilters(m.tasks[j]) {
+				matched = append(matched, j)
+			}
+		}
+		i = j
+
+		if len(matched) == 0 {
+			continue
+		}
+		rows = append(rows, taskRow{header: true, group: area, count: len(matched)})
+		if !m.collapsedAreas[area] {
+			for _, k := range matched {
+				rows = append(rows, taskRow{task: k})
+			}
+		}
+	}
+	return rows
+}
+
+// currentTask returns the task under the cursor, if the cursor is on a task row
+func (m *Model) currentTask() (providers.Task, bool) {
+	rows := m.rows()
+	if m.cursor >= len(rows) || rows[m.cursor].isHeader() || rows[m.cursor].isChecklist() {
+		return providers.Task{}, false
+	}
+	return m.tasks[rows[m.cursor].task], true
+}
+
+// flattenAreas orders grouped tasks by area name, with unassigned tasks last
+func flattenAreas(grouped map[string][]providers.Task) []providers.Task {
+	areas := make([]string, 0, len(grouped))
+	for area := range grouped {
+		areas = append(areas, area)
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		if areas[i] == "" || areas[j] == "" {
+			return areas[j] == ""
+		}
+		return areas[i] < areas[j]
+	})
+
+	var tasks []providers.Task
+	for _, area := range areas {
+		tasks = append(tasks, grouped[area]...)
+	}
+	return tasks
+}
+
+// areaLabel returns the header text for an area group
+func areaLabel(area string) string {
+	if area == "" {
+		return "No Area"
+	}
+	return area
 }
 
 // Init initializes the pane
@@ -74,60 +290,171 @@ func (m *Model) Init() tea.Cmd {
 
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.invalidateList(msg)
+	defer m.clampDetailScroll()
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if !m.focused {
 			return m, nil
 		}
 
+		if m.notes != nil {
+			return m, m.updateNotesEditor(msg)
+		}
+		if m.picker != nil {
+			return m, m.updatePicker(msg)
+		}
+		if m.areaPicker != nil {
+			return m, m.updateAreaPicker(msg)
+		}
+		if m.deadline != nil {
+			return m, m.updateDeadlineEditor(msg)
+		}
+		if m.tags != nil {
+			return m, m.updateTagEditor(msg)
+		}
+		if m.creating {
+			return m, m.updateCreateInput(msg)
+		}
+		if m.detailOpen {
+			return m, m.updateDetail(msg)
+		}
+		if m.filtering {
+			return m, m.updateFilterBar(msg)
+		}
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+
 		switch msg.String() {
 		// Navigation
 		case "j", "down":
-			if m.cursor < len(m.tasks)-1 {
+			if m.cursor < len(m.rows())-1 {
 				m.cursor++
+				m.skipHeaders(1)
 			}
 		case "k", "up":
 			if m.cursor > 0 {
 				m.cursor--
+				m.skipHeaders(-1)
 			}
+		case "l", "right":
+			m.scrollTitle(scrollStep)
+		case "h", "left":
+			m.scrollTitle(-scrollStep)
 		case "g":
 			m.cursor = 0
+			m.skipHeaders(1)
 		case "G":
-			if len(m.tasks) > 0 {
-				m.cursor = len(m.tasks) - 1
+			if n := len(m.rows()); n > 0 {
+				m.cursor = n - 1
+				m.skipHeaders(-1)
 			}
 
-		// Selection
+		// Group and checklist toggling / task details
+		case "enter":
+			row, ok := m.currentRow()
+			switch {
+			case !ok:
+			case row.isHeader():
+				m.collapsedAreas[row.group] = !m.collapsedAreas[row.group]
+			case row.isChecklist() || len(m.tasks[row.task].ChecklistItems) > 0:
+				m.toggleChecklist(row)
+			default:
+				return m, openDetail(m.tasks[row.task])
+			}
+		case "o":
+			if task, ok := m.currentTask(); ok {
+				return m, openDetail(task)
+			}
+
+		// Selection; on a checklist row, toggle the item instead
 		case " ", "x":
-			if len(m.tasks) > 0 {
-				task := m.tasks[m.cursor]
+			if row, ok := m.currentRow(); ok && row.isChecklist() {
+				return m, m.toggleChecklistItem(row.task, row.item)
+			}
+			if task, ok := m.currentTask(); ok {
 				m.selected[task.UUID] = !m.selected[task.UUID]
 			}
+		case "ctrl+a":
+			// Select all; was A until the area filter took it
+			m.selectAllVisible()
+		case "A":
+			// Scope the list to one area, or clear the scope
+			return m, m.toggleAreaFilter()
+		case "esc":
+			if len(m.selectedTasks()) == 0 && m.searchQuery != "" {
+				m.clearSearch()
+			} else {
+				m.clearSelection()
+			}
 
 		// Actions
 		case "d":
 			// Mark complete
-			if len(m.tasks) > 0 {
-				return m, m.markComplete(m.tasks[m.cursor].UUID)
+			if task, ok := m.currentTask(); ok {
+				return m, m.markComplete(task)
+			}
+		case "ctrl+d":
+			// Complete every selected task; was D until the deadline editor took it
+			return m, m.completeSelected()
+		case "D":
+			// Edit the deadline
+			if task, ok := m.currentTask(); ok {
+				return m, m.openDeadlineEditor(task)
 			}
+		case "u":
+			// Undo the last completion
+			return m, m.undo()
+		case "I":
+			// Move back to Inbox (ctrl+i is indistinguishable from tab)
+			if task, ok := m.currentTask(); ok && task.ProjectTitle != "" {
+				return m, m.moveToInbox(task.UUID)
+			}
+		case "e":
+			// Edit the task's notes
+			if task, ok := m.currentTask(); ok {
+				return m, m.openNotesEditor(task)
+			}
+		case "m":
+			// Move the task to another project
+			if task, ok := m.currentTask(); ok {
+				return m, m.openProjectPicker(task)
+			}
+		case "t":
+			// Add or remove the task's tags
+			if task, ok := m.currentTask(); ok {
+				return m, m.openTagEditor(task)
+			}
+		case "n":
+			// New task in the current list
+			return m, m.startCreate()
+		case "N":
+			// Ask the AI which task moves the needle
+			return m, m.requestNeedleMover()
+		case "/":
+			// Search titles in this list
+			m.startSearch()
+		case "f":
+			// Filter by tag, project, area
+			return m, m.openFilterBar()
+		case "F":
+			m.clearFilters()
+		case "s":
+			// Cycle sort order
+			m.cycleSort()
 		case "r":
 			// Refresh
 			return m, m.Refresh()
 
-		// View switching
-		case "1":
-			m.viewMode = ViewToday
-			return m, m.Refresh()
-		case "2":
-			m.viewMode = ViewInbox
-			return m, m.Refresh()
-		case "3":
-			m.viewMode = ViewUpcoming
-			return m, m.Refresh()
-		case "4":
-			m.viewMode = ViewAnytime
-			return m, m.Refresh()
+		// View switching; the digits stay pane shortcuts
+		case "]":
+			return m, m.setView((m.viewMode + 1) % viewCount)
+		case "[":
+			return m, m.setView((m.viewMode + viewCount - 1) % viewCount)
 		}
+		m.resetScrollIfMoved()
 
 	case TasksLoadedMsg:
 		m.loading = false
@@ -136,18 +463,128 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.tasks = msg.Tasks
 			m.err = nil
+			m.lastFetchedAt = msg.FetchedAt
+			m.isOffline = msg.Offline
+			m.recordLoadOrder()
+			m.applySort()
 			// Reset cursor if out of bounds
-			if m.cursor >= len(m.tasks) {
-				m.cursor = max(0, len(m.tasks)-1)
-			}
+			m.clampCursor()
+		}
+		if m.isOffline && !m.retryPending {
+			m.retryPending = true
+			return m, panes.RetryOffline(panes.PaneTasks)
+		}
+
+	case panes.OfflineRetryMsg:
+		m.retryPending = false
+		if m.isOffline {
+			// Keep the stale list on screen while retrying
+			cmd := m.ForceRefresh()
+			m.loading = false
+			return m, cmd
 		}
 
 	case TaskCompletedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 		} else {
+			m.pushUndo(undoEntry{uuid: msg.ID, title: msg.Title, previousStatus: msg.PreviousStatus})
 			// Refresh to get updated list
-			return m, m.Refresh()
+			return m, m.ForceRefresh()
+		}
+
+	case TasksBulkCompletedMsg:
+		for _, task := range msg.Completed {
+			m.pushUndo(undoEntry{uuid: task.UUID, title: task.Title, previousStatus: task.Status})
+			delete(m.selected, task.UUID)
+		}
+		return m, m.ForceRefresh()
+
+	case TaskUncompletedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			// Keep the completion so the undo can be tried again
+			m.pushUndo(undoEntry{uuid: msg.ID, title: msg.Title, previousStatus: msg.PreviousStatus})
+		} else {
+			return m, tea.Batch(m.setFlash("Undone: "+msg.Title), m.ForceRefresh())
+		}
+
+	case FlashExpiredMsg:
+		if m.flash == msg.Text {
+			m.flash = ""
+		}
+
+	case HighlightTaskMsg:
+		m.highlightTask(msg.Title)
+
+	case TaskMovedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			return m, m.ForceRefresh()
+		}
+
+	case TaskCreatedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			// Show it immediately; the next refresh reconciles. Wherever
+			// the sort puts it, keep the cursor on the same task.
+			current, hasCurrent := m.currentTask()
+			m.tasks = append([]providers.Task{msg.Task}, m.tasks...)
+			m.applySort()
+			if hasCurrent {
+				m.SelectItem(current.UUID)
+			}
+			m.clampCursor()
+		}
+
+	case TaskDetailMsg:
+		m.detailOpen = true
+		m.detailUUID = msg.Task.UUID
+		m.detailScroll = 0
+		m.detailItem = 0
+
+	case NotesSavedMsg:
+		return m, m.handleNotesSaved(msg)
+
+	case PickerProjectsLoadedMsg:
+		m.handlePickerProjectsLoaded(msg)
+
+	case PickerAreasLoadedMsg:
+		m.handlePickerAreasLoaded(msg)
+
+	case TaskProjectSetMsg:
+		return m, m.handleTaskProjectSet(msg)
+
+	case TaskDeadlineSetMsg:
+		return m, m.handleTaskDeadlineSet(msg)
+
+	case TaskTagsSetMsg:
+		return m, m.handleTaskTagsSet(msg)
+
+	case ChecklistUpdatedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, m.ForceRefresh()
+		}
+
+	default:
+		// Cursor blink and other input messages
+		if m.notes != nil {
+			var cmd tea.Cmd
+			m.notes.area, cmd = m.notes.area.Update(msg)
+			return m, cmd
+		}
+		if m.creating {
+			var cmd tea.Cmd
+			m.createInput, cmd = m.createInput.Update(msg)
+			return m, cmd
+		}
+		if m.deadline != nil {
+			var cmd tea.Cmd
+			m.deadline.input, cmd = m.deadline.input.Update(msg)
+			return m, cmd
 		}
 	}
 
@@ -156,6 +593,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the pane
 func (m *Model) View() string {
+	if m.notes != nil {
+		return m.renderNotesEditor()
+	}
+
 	var b strings.Builder
 
 	// Header
@@ -163,8 +604,30 @@ func (m *Model) View() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Content area height
-	contentHeight := m.height - 4 // header + footer
+	if m.isOffline {
+		b.WriteString("  " + panes.OfflineBanner(m.styles, m.lastFetchedAt))
+		b.WriteString("\n")
+	}
+
+	// Filter bar sits above the list
+	if m.filtering {
+		b.WriteString(m.renderFilterBar())
+		b.WriteString("\n")
+	}
+
+	// Inline creation input sits above the list
+	if m.creating {
+		b.WriteString("  + " + m.createInput.View())
+		b.WriteString("\n")
+	}
+
+	// Footer with shortcuts, or the deadline input in its box. Both it
+	// and the lines above are measured as the pane wraps them, so a long
+	// header or shortcut line can't push the footer off the bottom.
+	footer := m.renderFooter()
+	footerHeight := m.displayHeight(footer)
+	top := strings.TrimSuffix(b.String(), "\n")
+	contentHeight := max(0, m.height-2-m.displayHeight(top)-footerHeight)
 
 	if m.loading {
 		b.WriteString(m.styles.Muted.Render("\n  Loading..."))
@@ -172,28 +635,22 @@ func (m *Model) View() string {
 		b.WriteString(m.styles.Error.Render(fmt.Sprintf("\n  Error: %v", m.err)))
 	} else if len(m.tasks) == 0 {
 		b.WriteString(m.styles.Muted.Render("\n  No tasks"))
+	} else if len(m.rows()) == 0 {
+		b.WriteString(m.styles.Muted.Render("\n  No tasks match the filters"))
 	} else {
-		// Render visible tasks
-		start := 0
-		if m.cursor >= contentHeight {
-			start = m.cursor - contentHeight + 1
-		}
-		end := min(start+contentHeight, len(m.tasks))
-
-		for i := start; i < end; i++ {
-			task := m.tasks[i]
-			line := m.renderTask(task, i == m.cursor, m.selected[task.UUID])
-			b.WriteString(line)
+		// Render only the rows in view
+		m.syncList(m.rows())
+		m.list.SetHeight(contentHeight)
+		if view := m.list.View(m.cursor); view != "" {
+			b.WriteString(view)
 			b.WriteString("\n")
 		}
 	}
 
-	// Footer with shortcuts
-	footer := m.renderFooter()
-
-	// Pad to fill height
-	lines := strings.Count(b.String(), "\n")
-	for i := lines; i < m.height-1; i++ {
+	// Pad to fill height, counting the lines the pane will wrap
+	content := b.String()
+	lines := strings.Count(content, "\n") + m.displayHeight(content) - lipgloss.Height(content)
+	for i := lines; i < m.height-footerHeight; i++ {
 		b.WriteString("\n")
 	}
 	b.WriteString(footer)
@@ -202,8 +659,8 @@ func (m *Model) View() string {
 }
 
 func (m *Model) renderHeader() string {
-	// View mode tabs
-	tabs := []string{"1:Today", "2:Inbox", "3:Upcoming", "4:Anytime"}
+	// View mode tabs, cycled with [ and ]
+	tabs := []string{"Today", "Inbox", "Upcoming", "Anytime", "By Area", "Deadlines", "By Tag"}
 	var tabParts []string
 
 	for i, tab := range tabs {
@@ -214,10 +671,23 @@ func (m *Model) renderHeader() string {
 		}
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Left, "  ", strings.Join(tabParts, "  "))
+	header := strings.Join(tabParts, "  ")
+	if m.sortMode != SortDefault {
+		header += "  " + m.styles.Subtitle.Render("[↑ "+m.sortMode.String()+"]")
+	}
+	if m.areaFilter != nil {
+		header += "  " + m.styles.Subtitle.Render("[Area: "+m.areaFilter.Title+"]")
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Left, "  ", header)
 }
 
 func (m *Model) renderTask(task providers.Task, isCursor, isSelected bool) string {
+	return m.renderLine(m.taskLine(task, isCursor, isSelected), m.taskStyle(task, isCursor))
+}
+
+// taskLine builds the unstyled "> [ ] title" text for a task row
+func (m *Model) taskLine(task providers.Task, isCursor, isSelected bool) string {
 	// Status indicator
 	var status string
 	if task.Status == "completed" {
@@ -234,31 +704,132 @@ func (m *Model) renderTask(task providers.Task, isCursor, isSelected bool) strin
 		cursor = "> "
 	}
 
-	// Title
+	// Title; the cursor row can be scrolled with h/l
 	title := task.Title
-	if len(title) > m.width-10 {
-		title = title[:m.width-13] + "..."
+	if isCursor {
+		title = m.renderTaskScrolled(task, m.titleOffset(task), m.titleWidth())
+	} else if runes := []rune(title); len(runes) > m.titleWidth() {
+		title = string(runes[:max(0, m.titleWidth()-3)]) + "..."
+	}
+	if task.UUID != "" && task.UUID == m.needleMover {
+		title = needleMoverMark + title
 	}
 
-	// Build line
-	line := fmt.Sprintf("%s%s %s", cursor, status, title)
+	return fmt.Sprintf("%s%s %s", cursor, status, title)
+}
 
-	// Style based on state
-	var style lipgloss.Style
+// taskStyle picks the row style based on task state
+func (m *Model) taskStyle(task providers.Task, isCursor bool) lipgloss.Style {
 	switch {
 	case task.Status == "completed":
-		style = m.styles.ListItemDone
+		return m.styles.ListItemDone
 	case isCursor:
-		style = m.styles.ListItemSelected
+		return m.styles.ListItemSelected
 	default:
-		style = m.styles.ListItem
+		return m.styles.ListItem
+	}
+}
+
+// renderDeadlineTask renders a task with its deadline right-aligned and
+// colored by urgency
+func (m *Model) renderDeadlineTask(task providers.Task, isCursor, isSelected bool) string {
+	days := getDaysUntil(task.Deadline)
+
+	var label string
+	switch {
+	case days < 0:
+		label = fmt.Sprintf("%s (%dd overdue)", task.Deadline.Format("Jan 2"), -days)
+	case days == 0:
+		label = "today"
+	default:
+		label = fmt.Sprintf("%s (%dd)", task.Deadline.Format("Jan 2"), days)
+	}
+
+	style := m.styles.ListItem
+	switch {
+	case days < 0:
+		style = m.styles.Error
+	case days <= urgentDays:
+		style = m.styles.Warning
+	}
+
+	// The cursor row keeps its highlight; the date always shows urgency
+	rowStyle := style
+	if isCursor || task.Status == "completed" {
+		rowStyle = m.taskStyle(task, isCursor)
+	}
+	left := m.renderLine(m.taskLine(task, isCursor, isSelected), rowStyle)
+	right := style.Render(label + " ")
+	rightWidth := max(0, m.width-lipgloss.Width(left))
+	return left + lipgloss.PlaceHorizontal(rightWidth, lipgloss.Right, right)
+}
+
+// withDeadlines keeps tasks that have a deadline, soonest first
+func withDeadlines(tasks []providers.Task) []providers.Task {
+	var due []providers.Task
+	for _, t := range tasks {
+		if t.Deadline != nil {
+			due = append(due, t)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Deadline.Before(*due[j].Deadline)
+	})
+	return due
+}
+
+// getDaysUntil returns the number of calendar days from today until t;
+// negative for past dates
+func getDaysUntil(t *time.Time) int {
+	if t == nil {
+		return 0
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	due := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(due.Sub(today).Hours() / 24)
+}
+
+// renderGroupHeader renders a collapsible section header with its task count
+func (m *Model) renderGroupHeader(row taskRow, isCursor bool) string {
+	arrow := "▾"
+	if m.collapsedAreas[row.group] {
+		arrow = "▸"
 	}
 
-	return style.Render(line)
+	cursor := "  "
+	if isCursor {
+		cursor = "> "
+	}
+
+	// Padded like the task rows so the arrow lines up with their boxes
+	line := fmt.Sprintf("%s%s %s (%d)", cursor, arrow, areaLabel(row.group), row.count)
+	if isCursor {
+		return m.styles.ListItemSelected.Render(line)
+	}
+	return m.styles.Subtitle.PaddingLeft(2).Render(line)
 }
 
 func (m *Model) renderFooter() string {
-	shortcuts := "j/k:nav  d:done  space:select  r:refresh"
+	if m.deadline != nil {
+		return m.renderDeadlineEditor()
+	}
+	if m.creating {
+		return m.styles.Muted.Render("  enter:create  esc:cancel")
+	}
+	if m.filtering {
+		return m.styles.Muted.Render("  tab:next field  enter/esc:close")
+	}
+	if m.searching {
+		return m.searchPrompt()
+	}
+	if m.flash != "" {
+		return m.styles.Success.Render("  " + m.flash)
+	}
+	if n := len(m.selectedTasks()); n > 0 {
+		return m.styles.Muted.Render(fmt.Sprintf("  %d selected  ^d:done all  ^a:select all  esc:clear", n))
+	}
+	shortcuts := "j/k:nav  enter:details/checklist  o:details  e:notes  m:project  [/]:view  t:tags  A:area  D:deadline  h/l:scroll  d:done  u:undo  n:new  N:needle mover  /:search  f:filter  s:sort  space:select  ^a:all  I:to inbox  r:refresh"
 	return m.styles.Muted.Render("  " + shortcuts)
 }
 
@@ -283,6 +854,20 @@ func (m *Model) IsFocused() bool {
 func (m *Model) SetSize(width, height int) panes.Pane {
 	m.width = width
 	m.height = height
+	m.listStale = true
+	m.resizeNotesEditor()
+	return m
+}
+
+// CapturingInput reports whether a text input has the keyboard
+func (m *Model) CapturingInput() bool {
+	return m.notes != nil || m.picker != nil || m.areaPicker != nil || m.deadline != nil || m.tags != nil || m.creating || m.detailOpen || m.filtering || m.searching
+}
+
+// SetStyles replaces the pane styles (e.g. after a theme change)
+func (m *Model) SetStyles(styles *theme.Styles) panes.Pane {
+	m.styles = styles
+	m.listStale = true
 	return m
 }
 
@@ -291,18 +876,74 @@ func (m *Model) Type() panes.PaneType {
 	return panes.PaneTasks
 }
 
-// Title returns the pane title
+// Title returns the pane title, with any active filters
 func (m *Model) Title() string {
+	if m.notes != nil && m.notes.modified() {
+		return "Tasks [modified]"
+	}
+	if indicator := m.filterIndicator(); indicator != "" {
+		return "Tasks " + indicator
+	}
 	return "Tasks"
 }
 
-// Refresh fetches fresh data
+// CurrentViewMode returns the view mode for session persistence
+func (m *Model) CurrentViewMode() int {
+	return int(m.viewMode)
+}
+
+// setView switches to view v. By Tag regroups the loaded list, so only the
+// other views fetch.
+func (m *Model) setView(v ViewMode) tea.Cmd {
+	if v != ViewByTag {
+		m.viewMode = v
+		return m.ForceRefresh()
+	}
+	if m.viewMode != ViewByTag {
+		m.tagSource = m.viewMode
+		m.viewMode = ViewByTag
+		m.cursor = 0
+		m.skipHeaders(1)
+	}
+	return nil
+}
+
+// RestoreViewMode sets a saved view mode, ignoring unknown values
+func (m *Model) RestoreViewMode(mode int) panes.Pane {
+	if v := ViewMode(mode); v >= ViewToday && v <= ViewByTag {
+		m.viewMode = v
+		m.listStale = true
+	}
+	return m
+}
+
+// Refresh reloads unless the last load was under minRefreshInterval
+// ago; it returns nil when throttled
 func (m *Model) Refresh() tea.Cmd {
+	if time.Since(m.lastRefreshed) < m.minRefreshInterval {
+		return nil
+	}
+	return m.ForceRefresh()
+}
+
+// SetMinRefreshInterval sets the Refresh throttle; zero disables it
+func (m *Model) SetMinRefreshInterval(d time.Duration) {
+	m.minRefreshInterval = d
+}
+
+// ForceRefresh fetches fresh data, ignoring the throttle
+func (m *Model) ForceRefresh() tea.Cmd {
+	m.lastRefreshed = time.Now()
 	m.loading = true
+	m.allProjects = nil // The pickers reload them on their next use
+	m.allAreas = nil
 	viewMode := m.viewMode
+	if viewMode == ViewByTag {
+		viewMode = m.tagSource
+	}
 
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx, offline := mcp.WithOffline(context.Background())
 		var tasks []providers.Task
 		var err error
 
@@ -315,9 +956,17 @@ func (m *Model) Refresh() tea.Cmd {
 			tasks, err = m.provider.GetUpcoming(ctx)
 		case ViewAnytime:
 			tasks, err = m.provider.GetAnytime(ctx)
+		case ViewTodayByArea:
+			var grouped map[string][]providers.Task
+			grouped, err = m.provider.GetTodayWithAreas(ctx)
+			tasks = flattenAreas(grouped)
+		case ViewDeadlines:
+			tasks, err = m.provider.GetUpcoming(ctx)
+			tasks = withDeadlines(tasks)
 		}
 
-		return TasksLoadedMsg{Tasks: tasks, Err: err}
+		fetchedAt, isOffline := offline.FetchedAt()
+		return TasksLoadedMsg{Tasks: tasks, Err: err, FetchedAt: fetchedAt, Offline: isOffline}
 	}
 }
 
@@ -330,26 +979,185 @@ func (m *Model) GetData() interface{} {
 	}
 }
 
+// UnreadCount is the number of open tasks past their deadline in the
+// loaded view, shown as the pane's status bar badge
+func (m *Model) UnreadCount() int {
+	n := 0
+	for _, t := range m.tasks {
+		if t.Status != "completed" && t.Deadline != nil && getDaysUntil(t.Deadline) < 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Badge is the number of tasks the current filters leave visible
+func (m *Model) Badge() string {
+	n := 0
+	for _, t := range m.tasks {
+		if m.matchesFilters(t) {
+			n++
+		}
+	}
+	return fmt.Sprintf("(%d)", n)
+}
+
+// GetContextSummary describes the loaded tasks for AI context
+func (m *Model) GetContextSummary() string {
+	if len(m.tasks) == 0 {
+		return ""
+	}
+
+	var taskList []string
+	for _, t := range m.tasks {
+		taskList = append(taskList, t.Title)
+	}
+	return m.viewMode.String() + "'s tasks:\n- " + strings.Join(taskList, "\n- ")
+}
+
+// SearchItems lists the loaded tasks for global search
+func (m *Model) SearchItems() []panes.SearchItem {
+	items := make([]panes.SearchItem, len(m.tasks))
+	for i, t := range m.tasks {
+		items[i] = panes.SearchItem{ID: t.UUID, Title: t.Title}
+	}
+	return items
+}
+
+// SelectItem moves the cursor to the task with the given UUID, expanding
+// its area group if collapsed
+func (m *Model) SelectItem(id string) panes.Pane {
+	for i, t := range m.tasks {
+		if t.UUID != id {
+			continue
+		}
+		delete(m.collapsedAreas, t.AreaTitle)
+		for r, row := range m.rows() {
+			if !row.isHeader() && !row.isChecklist() && row.task == i {
+				m.cursor = r
+				break
+			}
+		}
+		break
+	}
+	return m
+}
+
 // markComplete marks a task as complete
-func (m *Model) markComplete(id string) tea.Cmd {
+func (m *Model) markComplete(task providers.Task) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		err := m.provider.MarkComplete(ctx, id)
-		return TaskCompletedMsg{ID: id, Err: err}
+		err := m.provider.MarkComplete(ctx, task.UUID)
+		return TaskCompletedMsg{ID: task.UUID, Title: task.Title, PreviousStatus: task.Status, Err: err}
+	}
+}
+
+// startCreate opens the inline new-task input
+func (m *Model) startCreate() tea.Cmd {
+	m.createInput = textinput.New()
+	m.createInput.Placeholder = "New task in " + m.viewMode.String()
+	m.createInput.Prompt = ""
+	m.createInput.Width = m.width - 6
+	m.savedCursor = m.cursor
+	m.creating = true
+	return m.createInput.Focus()
+}
+
+// stopCreate dismisses the input and restores the cursor
+func (m *Model) stopCreate() {
+	m.creating = false
+	m.createInput.Blur()
+	m.cursor = m.savedCursor
+}
+
+// updateCreateInput handles keys while the new-task input is open
+func (m *Model) updateCreateInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.stopCreate()
+		return nil
+	case tea.KeyEnter:
+		title := strings.TrimSpace(m.createInput.Value())
+		m.stopCreate()
+		if title == "" {
+			return nil
+		}
+		return m.createTask(title)
+	}
+
+	var cmd tea.Cmd
+	m.createInput, cmd = m.createInput.Update(msg)
+	return cmd
+}
+
+// createTask creates a task in the list matching the current view
+func (m *Model) createTask(title string) tea.Cmd {
+	list := listForView(m.viewMode)
+	return func() tea.Msg {
+		ctx := context.Background()
+		task, err := m.provider.CreateTask(ctx, providers.CreateTaskInput{Title: title, List: list})
+		return TaskCreatedMsg{Task: task, Err: err}
+	}
+}
+
+// listForView maps a view mode to the Things list new tasks should land in
+func listForView(v ViewMode) string {
+	switch v {
+	case ViewToday, ViewTodayByArea:
+		return "today"
+	case ViewAnytime:
+		return "anytime"
+	default:
+		return "inbox"
+	}
+}
+
+// moveToInbox moves a task out of its project into the Inbox
+func (m *Model) moveToInbox(id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := m.provider.MoveToInbox(ctx, id)
+		return TaskMovedMsg{ID: id, Err: err}
 	}
 }
 
 // Messages
 type TasksLoadedMsg struct {
-	Tasks []providers.Task
-	Err   error
+	Tasks     []providers.Task
+	Err       error
+	FetchedAt time.Time
+	Offline   bool // Served from the cache while Things was unreachable
 }
 
 type TaskCompletedMsg struct {
+	ID             string
+	Title          string
+	PreviousStatus string
+	Err            error
+}
+
+type TaskUncompletedMsg struct {
+	ID             string
+	Title          string
+	PreviousStatus string
+	Err            error
+}
+
+// FlashExpiredMsg clears a footer message once its time is up
+type FlashExpiredMsg struct {
+	Text string
+}
+
+type TaskMovedMsg struct {
 	ID  string
 	Err error
 }
 
+type TaskCreatedMsg struct {
+	Task providers.Task
+	Err  error
+}
+
 // Helper functions
 func max(a, b int) int {
 	if a > b {
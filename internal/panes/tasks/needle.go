@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// needleMoverMark prefixes the row of the AI-picked needle mover
+const needleMoverMark = "🎯 "
+
+// NeedleMoverRequestMsg asks the app to have the AI pick the most
+// important of Titles
+type NeedleMoverRequestMsg struct {
+	Titles []string
+}
+
+// HighlightTaskMsg moves the cursor to the task titled Title and marks it
+// as the needle mover
+type HighlightTaskMsg struct {
+	Title string
+}
+
+// requestNeedleMover sends the loaded task titles to the app
+func (m *Model) requestNeedleMover() tea.Cmd {
+	if len(m.tasks) == 0 {
+		return nil
+	}
+	titles := make([]string, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		if t.Status != "completed" {
+			titles = append(titles, t.Title)
+		}
+	}
+	return func() tea.Msg {
+		return NeedleMoverRequestMsg{Titles: titles}
+	}
+}
+
+// highlightTask marks the task titled title and scrolls to it, clearing
+// filters that would hide it
+func (m *Model) highlightTask(title string) {
+	for _, t := range m.tasks {
+		if strings.EqualFold(t.Title, title) {
+			m.needleMover = t.UUID
+			if !m.matchesFilters(t) {
+				m.clearFilters()
+				m.searchQuery = ""
+			}
+			m.SelectItem(t.UUID)
+			return
+		}
+	}
+}
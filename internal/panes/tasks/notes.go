@@ -0,0 +1,138 @@
+package tasks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// NotesSavedMsg reports the result of saving a task's notes
+type NotesSavedMsg struct {
+	ID    string
+	Notes string
+	Err   error
+}
+
+// notesEditor is the multi-line notes editor opened with e. While open it
+// replaces the task list.
+type notesEditor struct {
+	uuid     string
+	title    string
+	original string
+	area     textarea.Model
+	saving   bool
+	err      error // Error from the last save attempt
+}
+
+// modified reports whether the notes differ from the task's saved notes
+func (e *notesEditor) modified() bool {
+	return e.area.Value() != e.original
+}
+
+// openNotesEditor starts editing task's notes
+func (m *Model) openNotesEditor(task providers.Task) tea.Cmd {
+	area := textarea.New()
+	area.ShowLineNumbers = false
+	area.Prompt = ""
+	area.CharLimit = 0
+	area.SetValue(task.Notes)
+
+	m.notes = &notesEditor{uuid: task.UUID, title: task.Title, original: task.Notes, area: area}
+	m.resizeNotesEditor()
+	return m.notes.area.Focus()
+}
+
+// resizeNotesEditor fits the textarea to the pane, wrapping at width-4
+func (m *Model) resizeNotesEditor() {
+	if m.notes == nil {
+		return
+	}
+	m.notes.area.SetWidth(max(10, m.width-4))
+	// Heading, blank line, status line and help
+	m.notes.area.SetHeight(max(1, m.height-4))
+}
+
+// updateNotesEditor handles keys while the notes editor is open
+func (m *Model) updateNotesEditor(msg tea.KeyMsg) tea.Cmd {
+	e := m.notes
+	if e.saving {
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.notes = nil
+		return nil
+	case "ctrl+s":
+		if !e.modified() {
+			m.notes = nil
+			return nil
+		}
+		e.saving = true
+		e.err = nil
+		return m.saveNotes(e.uuid, e.area.Value())
+	}
+
+	var cmd tea.Cmd
+	e.area, cmd = e.area.Update(msg)
+	return cmd
+}
+
+// saveNotes writes notes to Things
+func (m *Model) saveNotes(id, notes string) tea.Cmd {
+	provider := m.provider
+	return func() tea.Msg {
+		err := provider.UpdateTodo(context.Background(), id, map[string]interface{}{
+			"notes": notes,
+		})
+		return NotesSavedMsg{ID: id, Notes: notes, Err: err}
+	}
+}
+
+// handleNotesSaved closes the editor and updates the task in place, or
+// keeps the editor open with the error
+func (m *Model) handleNotesSaved(msg NotesSavedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if m.notes != nil && m.notes.uuid == msg.ID {
+			m.notes.saving = false
+			m.notes.err = msg.Err
+		}
+		return nil
+	}
+
+	for i := range m.tasks {
+		if m.tasks[i].UUID == msg.ID {
+			m.tasks[i].Notes = msg.Notes
+		}
+	}
+	if m.notes != nil && m.notes.uuid == msg.ID {
+		m.notes = nil
+	}
+	return m.setFlash("Notes saved")
+}
+
+// renderNotesEditor draws the editor over the whole pane
+func (m *Model) renderNotesEditor() string {
+	e := m.notes
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Notes: " + e.title))
+	b.WriteString("\n\n")
+	b.WriteString(e.area.View())
+	b.WriteString("\n")
+
+	switch {
+	case e.saving:
+		b.WriteString(m.styles.Muted.Render("Saving..."))
+	case e.err != nil:
+		b.WriteString(m.styles.Error.Render("Error: " + e.err.Error()))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Render("ctrl+s:save  esc:discard"))
+
+	return b.String()
+}
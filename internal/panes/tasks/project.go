@@ -0,0 +1,249 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/fuzzy"
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxPickerResults caps how many projects the picker lists at once
+const maxPickerResults = 10
+
+// PickerProjectsLoadedMsg carries the projects offered by the picker
+type PickerProjectsLoadedMsg struct {
+	Projects []providers.Project
+	Err      error
+}
+
+// TaskProjectSetMsg reports the result of moving a task to a project
+type TaskProjectSetMsg struct {
+	ID           string
+	ProjectUUID  string
+	ProjectTitle string
+	Err          error
+}
+
+// projectPicker is the modal opened with p that moves a task to another
+// project. It filters m.allProjects as the user types.
+type projectPicker struct {
+	uuid    string // Task being moved
+	title   string
+	input   textinput.Model
+	matches []int // Indices into Model.allProjects, best first
+	cursor  int
+	loading bool
+	saving  bool
+	err     error
+}
+
+// openProjectPicker shows the picker for task, loading the project list
+// on first use
+func (m *Model) openProjectPicker(task providers.Task) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "project"
+	input.CharLimit = 60
+
+	m.picker = &projectPicker{uuid: task.UUID, title: task.Title, input: input}
+	cmds := []tea.Cmd{m.picker.input.Focus()}
+	if m.allProjects == nil {
+		m.picker.loading = true
+		cmds = append(cmds, m.loadPickerProjects())
+	} else {
+		m.filterProjects()
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadPickerProjects fetches every project, without their tasks
+func (m *Model) loadPickerProjects() tea.Cmd {
+	provider := m.provider
+	return func() tea.Msg {
+		projects, err := provider.GetProjects(context.Background(), false)
+		return PickerProjectsLoadedMsg{Projects: projects, Err: err}
+	}
+}
+
+// handlePickerProjectsLoaded caches the project list and fills the picker
+func (m *Model) handlePickerProjectsLoaded(msg PickerProjectsLoadedMsg) {
+	if msg.Err == nil {
+		projects := msg.Projects
+		if projects == nil {
+			projects = []providers.Project{}
+		}
+		m.allProjects = projects
+	}
+	if m.picker == nil {
+		return
+	}
+	m.picker.loading = false
+	m.picker.err = msg.Err
+	m.filterProjects()
+}
+
+// filterProjects ranks the projects against the query; an empty query
+// lists them alphabetically
+func (m *Model) filterProjects() {
+	p := m.picker
+	p.cursor = 0
+	p.matches = p.matches[:0]
+
+	query := strings.TrimSpace(p.input.Value())
+	scores := make(map[int]int)
+	for i, project := range m.allProjects {
+		score, ok := fuzzy.Score(project.Title, query)
+		if !ok {
+			continue
+		}
+		scores[i] = score
+		p.matches = append(p.matches, i)
+	}
+	sort.SliceStable(p.matches, func(a, b int) bool {
+		i, j := p.matches[a], p.matches[b]
+		if scores[i] != scores[j] {
+			return scores[i] > scores[j]
+		}
+		return strings.ToLower(m.allProjects[i].Title) < strings.ToLower(m.allProjects[j].Title)
+	})
+}
+
+// updatePicker handles keys while the project picker is open
+func (m *Model) updatePicker(msg tea.KeyMsg) tea.Cmd {
+	p := m.picker
+	if p.saving {
+		return nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.picker = nil
+		return nil
+	case tea.KeyEnter:
+		if p.cursor >= len(p.matches) {
+			return nil
+		}
+		p.saving = true
+		p.err = nil
+		return m.setTaskProject(p.uuid, m.allProjects[p.matches[p.cursor]])
+	case tea.KeyUp, tea.KeyCtrlK:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil
+	case tea.KeyDown, tea.KeyCtrlJ:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	query := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		m.filterProjects()
+	}
+	return cmd
+}
+
+// setTaskProject moves a task to project in Things
+func (m *Model) setTaskProject(id string, project providers.Project) tea.Cmd {
+	provider := m.provider
+	return func() tea.Msg {
+		err := provider.UpdateTodo(context.Background(), id, map[string]interface{}{
+			"project": project.UUID,
+		})
+		return TaskProjectSetMsg{ID: id, ProjectUUID: project.UUID, ProjectTitle: project.Title, Err: err}
+	}
+}
+
+// handleTaskProjectSet closes the picker and updates the task in place,
+// or keeps the picker open with the error
+func (m *Model) handleTaskProjectSet(msg TaskProjectSetMsg) tea.Cmd {
+	if msg.Err != nil {
+		if m.picker != nil && m.picker.uuid == msg.ID {
+			m.picker.saving = false
+			m.picker.err = msg.Err
+		}
+		return nil
+	}
+
+	for i := range m.tasks {
+		if m.tasks[i].UUID == msg.ID {
+			m.tasks[i].ProjectUUID = msg.ProjectUUID
+			m.tasks[i].ProjectTitle = msg.ProjectTitle
+		}
+	}
+	if m.picker != nil && m.picker.uuid == msg.ID {
+		m.picker = nil
+	}
+	return m.setFlash("Moved to " + msg.ProjectTitle)
+}
+
+// pickerView renders the project picker inside the modal
+func (m *Model) pickerView(width int) string {
+	p := m.picker
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Move to project"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Width(width).Render(p.title))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case p.loading:
+		b.WriteString(m.styles.Muted.Render("Loading projects..."))
+		b.WriteString("\n")
+	case len(p.matches) == 0 && p.err == nil:
+		b.WriteString(m.styles.Muted.Render("No matching projects"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor inside the visible window
+	start := 0
+	if p.cursor >= maxPickerResults {
+		start = p.cursor - maxPickerResults + 1
+	}
+	end := min(len(p.matches), start+maxPickerResults)
+	for i := start; i < end; i++ {
+		project := m.allProjects[p.matches[i]]
+		label := project.Title
+		if project.AreaTitle != "" {
+			label += m.styles.Muted.Render("  " + project.AreaTitle)
+		}
+		if i == p.cursor {
+			b.WriteString(m.styles.ListItemSelected.Render("> " + label))
+		} else {
+			b.WriteString(m.styles.ListItem.Render("  " + label))
+		}
+		b.WriteString("\n")
+	}
+	if n := len(p.matches) - end; n > 0 {
+		b.WriteString(m.styles.Muted.Render(fmt.Sprintf("  ...%d more", n)))
+		b.WriteString("\n")
+	}
+
+	if p.err != nil {
+		b.WriteString("\n")
+		b.WriteString(m.styles.Error.Width(width).Render(fmt.Sprintf("Error: %v", p.err)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	help := "↑/↓:select  enter:move  esc:cancel"
+	if p.saving {
+		help = "Moving..."
+	}
+	b.WriteString(m.styles.Muted.Render(help))
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package tasks
+
+import "github.com/szoloth/partner/internal/mcp/providers"
+
+// scrollStep is how many characters h and l move a long title
+const scrollStep = 5
+
+// titleWidth is the room a title has after the cursor and status prefix
+func (m *Model) titleWidth() int {
+	return m.width - 10
+}
+
+// scrollTitle moves the cursor task's title by delta characters, keeping
+// the end of the title at the right edge at most. It reports whether the
+// title is wide enough to scroll.
+func (m *Model) scrollTitle(delta int) bool {
+	task, ok := m.currentTask()
+	if !ok {
+		return false
+	}
+	overflow := len([]rune(task.Title)) - m.titleWidth()
+	if overflow <= 0 {
+		return false
+	}
+	if task.UUID != m.scrollUUID {
+		m.scrollUUID = task.UUID
+		m.scrollOffset = 0
+	}
+	m.scrollOffset = min(max(m.scrollOffset+delta, 0), overflow)
+	return true
+}
+
+// resetScrollIfMoved drops the scroll once the cursor is on another task
+func (m *Model) resetScrollIfMoved() {
+	if task, ok := m.currentTask(); !ok || task.UUID != m.scrollUUID {
+		m.scrollOffset, m.scrollUUID = 0, ""
+	}
+}
+
+// titleOffset is the scroll offset for task: the cursor task keeps its
+// offset, every other task starts at 0
+func (m *Model) titleOffset(task providers.Task) int {
+	if task.UUID == "" || task.UUID != m.scrollUUID {
+		return 0
+	}
+	return m.scrollOffset
+}
+
+// renderTaskScrolled returns the width columns of task's title starting
+// offset runes in, ending in ">" when more of the title is hidden
+func (m *Model) renderTaskScrolled(task providers.Task, offset, width int) string {
+	runes := []rune(task.Title)
+	if width <= 0 {
+		return ""
+	}
+	offset = min(max(offset, 0), len(runes))
+	runes = runes[offset:]
+	if len(runes) > width {
+		return string(runes[:width-1]) + ">"
+	}
+	return string(runes)
+}
@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ClaimsKey takes "/" for the in-pane search instead of global search
+func (m *Model) ClaimsKey(key string) bool {
+	return key == "/"
+}
+
+// startSearch opens the "/" prompt, keeping any previous query for editing
+func (m *Model) startSearch() {
+	m.searching = true
+}
+
+// clearSearch drops the query and restores the full list
+func (m *Model) clearSearch() {
+	m.searching = false
+	m.setSearchQuery("")
+}
+
+// setSearchQuery applies a new query and resets the cursor
+func (m *Model) setSearchQuery(query string) {
+	if query == m.searchQuery {
+		return
+	}
+	m.searchQuery = query
+	m.cursor = 0
+	m.skipHeaders(1)
+}
+
+// updateSearch handles keys while the search prompt is open. Enter keeps
+// the filter and hides the prompt; esc clears it.
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearSearch()
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.searchQuery); len(runes) > 0 {
+			m.setSearchQuery(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		m.setSearchQuery(m.searchQuery + " ")
+	case tea.KeyRunes:
+		m.setSearchQuery(m.searchQuery + string(msg.Runes))
+	}
+	return nil
+}
+
+// matchesSearch reports whether a task title contains the query
+func (m *Model) matchesSearch(task providers.Task) bool {
+	if m.searchQuery == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(task.Title), strings.ToLower(m.searchQuery))
+}
+
+// taskPrefixLen is the width of the "> [ ] " cursor and status prefix
+const taskPrefixLen = 6
+
+// renderLine renders a task row in style, picking out the first match of
+// the search query within the title in the warning color
+func (m *Model) renderLine(line string, style lipgloss.Style) string {
+	query := strings.ToLower(m.searchQuery)
+	if query == "" || len(line) <= taskPrefixLen {
+		return style.Render(line)
+	}
+	idx := strings.Index(strings.ToLower(line[taskPrefixLen:]), query)
+	if idx < 0 || taskPrefixLen+idx+len(query) > len(line) {
+		return style.Render(line)
+	}
+
+	start := taskPrefixLen + idx
+	end := start + len(query)
+	rest := style.UnsetPaddingLeft()
+	return style.Render(line[:start]) +
+		rest.Foreground(m.styles.Palette.Warning).Render(line[start:end]) +
+		rest.Render(line[end:])
+}
+
+// searchPrompt renders the "/ query_" footer prompt
+func (m *Model) searchPrompt() string {
+	return m.styles.Warning.Render("  / " + m.searchQuery + "_")
+}
@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// SortMode orders the task list
+type SortMode int
+
+const (
+	SortDefault SortMode = iota // Order returned by Things
+	SortByDeadline
+	SortByProject
+	SortByTitle
+)
+
+func (s SortMode) String() string {
+	switch s {
+	case SortByDeadline:
+		return "Deadline"
+	case SortByProject:
+		return "Project"
+	case SortByTitle:
+		return "Title"
+	default:
+		return "Default"
+	}
+}
+
+// next cycles to the following sort mode
+func (s SortMode) next() SortMode {
+	return (s + 1) % (SortByTitle + 1)
+}
+
+// recordLoadOrder remembers the server order so SortDefault can restore it
+func (m *Model) recordLoadOrder() {
+	m.loadOrder = make(map[string]int, len(m.tasks))
+	m.areaRank = make(map[string]int)
+	for i, t := range m.tasks {
+		m.loadOrder[t.UUID] = i
+		if _, ok := m.areaRank[t.AreaTitle]; !ok {
+			m.areaRank[t.AreaTitle] = len(m.areaRank)
+		}
+	}
+}
+
+// applySort sorts m.tasks in place by the current sort mode. In the By Area
+// view tasks stay grouped by area and are sorted within each group.
+func (m *Model) applySort() {
+	less := m.sortLess()
+	sort.SliceStable(m.tasks, func(i, j int) bool {
+		a, b := m.tasks[i], m.tasks[j]
+		if m.viewMode == ViewTodayByArea && a.AreaTitle != b.AreaTitle {
+			return m.areaRank[a.AreaTitle] < m.areaRank[b.AreaTitle]
+		}
+		return less(a, b)
+	})
+}
+
+// sortLess returns the comparison for the current sort mode
+func (m *Model) sortLess() func(a, b providers.Task) bool {
+	byLoadOrder := func(a, b providers.Task) bool {
+		// Tasks created since the last load (not in loadOrder) come first
+		ia, okA := m.loadOrder[a.UUID]
+		ib, okB := m.loadOrder[b.UUID]
+		if okA != okB {
+			return !okA
+		}
+		return ia < ib
+	}
+
+	switch m.sortMode {
+	case SortByDeadline:
+		return func(a, b providers.Task) bool {
+			switch {
+			case a.Deadline == nil && b.Deadline == nil:
+				return byLoadOrder(a, b)
+			case a.Deadline == nil:
+				return false
+			case b.Deadline == nil:
+				return true
+			case a.Deadline.Equal(*b.Deadline):
+				return byLoadOrder(a, b)
+			}
+			return a.Deadline.Before(*b.Deadline)
+		}
+	case SortByProject:
+		return func(a, b providers.Task) bool {
+			// Tasks outside a project go last
+			pa, pb := strings.ToLower(a.ProjectTitle), strings.ToLower(b.ProjectTitle)
+			if pa == pb {
+				return byLoadOrder(a, b)
+			}
+			if pa == "" || pb == "" {
+				return pb == ""
+			}
+			return pa < pb
+		}
+	case SortByTitle:
+		return func(a, b providers.Task) bool {
+			ta, tb := strings.ToLower(a.Title), strings.ToLower(b.Title)
+			if ta == tb {
+				return byLoadOrder(a, b)
+			}
+			return ta < tb
+		}
+	default:
+		return byLoadOrder
+	}
+}
+
+// cycleSort advances the sort mode, keeping the cursor on the same task
+func (m *Model) cycleSort() {
+	current, hasCurrent := m.currentTask()
+
+	m.sortMode = m.sortMode.next()
+	m.applySort()
+
+	if hasCurrent {
+		m.SelectItem(current.UUID)
+	}
+}
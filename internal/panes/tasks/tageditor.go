@@ -0,0 +1,259 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/szoloth/partner/internal/fuzzy"
+	"github.com/szoloth/partner/internal/mcp/providers"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TaskTagsSetMsg reports saving the tags edited with t
+type TaskTagsSetMsg struct {
+	ID   string
+	Tags []string
+	Err  error
+}
+
+// tagEditor is the modal opened with t. Tags are edited on a copy and
+// only written to Things with ctrl+s.
+type tagEditor struct {
+	uuid        string
+	title       string
+	tags        []string // Working copy of the task's tags
+	known       []string // Tags on any loaded task, offered as suggestions
+	input       textinput.Model
+	suggestions []string // Known tags matching the input, best first
+	cursor      int      // Highlighted suggestion; -1 adds the typed text
+	saving      bool
+	err         error
+}
+
+// openTagEditor edits task's tags, suggesting the tags used across the
+// loaded list
+func (m *Model) openTagEditor(task providers.Task) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "+ "
+	input.Placeholder = "tag"
+	input.CharLimit = 40
+
+	m.tags = &tagEditor{
+		uuid:  task.UUID,
+		title: task.Title,
+		tags:  slices.Clone(task.Tags),
+		known: m.knownTags(),
+		input: input,
+	}
+	m.filterTagSuggestions()
+	return m.tags.input.Focus()
+}
+
+// knownTags lists every tag on a loaded task, alphabetically
+func (m *Model) knownTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, t := range m.tasks {
+		for _, tag := range t.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i]) < strings.ToLower(tags[j])
+	})
+	return tags
+}
+
+// hasTag reports whether the working copy already has tag, ignoring case
+func (e *tagEditor) hasTag(tag string) bool {
+	for _, t := range e.tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTagSuggestions ranks the known tags not yet on the task against
+// the input
+func (m *Model) filterTagSuggestions() {
+	e := m.tags
+	e.cursor = -1
+	e.suggestions = e.suggestions[:0]
+
+	query := strings.TrimSpace(e.input.Value())
+	scores := make(map[string]int)
+	for _, tag := range e.known {
+		if e.hasTag(tag) {
+			continue
+		}
+		score, ok := fuzzy.Score(tag, query)
+		if !ok {
+			continue
+		}
+		scores[tag] = score
+		e.suggestions = append(e.suggestions, tag)
+	}
+	sort.SliceStable(e.suggestions, func(a, b int) bool {
+		return scores[e.suggestions[a]] > scores[e.suggestions[b]]
+	})
+	if len(e.suggestions) > maxPickerResults {
+		e.suggestions = e.suggestions[:maxPickerResults]
+	}
+}
+
+// updateTagEditor handles keys while the tag editor is open
+func (m *Model) updateTagEditor(msg tea.KeyMsg) tea.Cmd {
+	e := m.tags
+	if e.saving {
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.tags = nil
+		return nil
+	case "ctrl+s":
+		e.saving = true
+		e.err = nil
+		return m.setTaskTags(e.uuid, e.tags)
+	case "enter":
+		tag := strings.TrimSpace(e.input.Value())
+		if e.cursor >= 0 && e.cursor < len(e.suggestions) {
+			tag = e.suggestions[e.cursor]
+		}
+		if tag != "" && !e.hasTag(tag) {
+			e.tags = append(e.tags, tag)
+		}
+		e.input.SetValue("")
+		m.filterTagSuggestions()
+		return nil
+	case "backspace":
+		if e.input.Value() == "" {
+			if len(e.tags) > 0 {
+				e.tags = e.tags[:len(e.tags)-1]
+				m.filterTagSuggestions()
+			}
+			return nil
+		}
+	case "up", "ctrl+k":
+		if e.cursor >= 0 {
+			e.cursor--
+		}
+		return nil
+	case "down", "ctrl+j":
+		if e.cursor < len(e.suggestions)-1 {
+			e.cursor++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	query := e.input.Value()
+	e.input, cmd = e.input.Update(msg)
+	if e.input.Value() != query {
+		m.filterTagSuggestions()
+	}
+	return cmd
+}
+
+// setTaskTags replaces a task's tags in Things
+func (m *Model) setTaskTags(id string, tags []string) tea.Cmd {
+	provider := m.provider
+	tags = slices.Clone(tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return func() tea.Msg {
+		err := provider.UpdateTodo(context.Background(), id, map[string]interface{}{
+			"tags": tags,
+		})
+		return TaskTagsSetMsg{ID: id, Tags: tags, Err: err}
+	}
+}
+
+// handleTaskTagsSet closes the editor and updates the task in place, or
+// keeps the editor open with the error
+func (m *Model) handleTaskTagsSet(msg TaskTagsSetMsg) tea.Cmd {
+	if msg.Err != nil {
+		if m.tags != nil && m.tags.uuid == msg.ID {
+			m.tags.saving = false
+			m.tags.err = msg.Err
+		}
+		return nil
+	}
+
+	for i := range m.tasks {
+		if m.tasks[i].UUID == msg.ID {
+			m.tasks[i].Tags = msg.Tags
+		}
+	}
+	if m.tags != nil && m.tags.uuid == msg.ID {
+		m.tags = nil
+	}
+	if len(msg.Tags) == 0 {
+		return m.setFlash("Tags cleared")
+	}
+	return m.setFlash("Tags: " + strings.Join(msg.Tags, ", "))
+}
+
+// tagEditorView renders the tag editor inside the modal
+func (m *Model) tagEditorView(width int) string {
+	e := m.tags
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Tags"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Width(width).Render(e.title))
+	b.WriteString("\n\n")
+
+	if len(e.tags) == 0 {
+		b.WriteString(m.styles.Muted.Render("No tags"))
+	} else {
+		chip := lipgloss.NewStyle().
+			Foreground(m.styles.Palette.Background).
+			Background(m.styles.Palette.Secondary).
+			Padding(0, 1)
+		chips := make([]string, len(e.tags))
+		for i, tag := range e.tags {
+			chips[i] = chip.Render(tag)
+		}
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(strings.Join(chips, " ")))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(e.input.View())
+	b.WriteString("\n")
+
+	for i, tag := range e.suggestions {
+		if i == e.cursor {
+			b.WriteString(m.styles.ListItemSelected.Render("> " + tag))
+		} else {
+			b.WriteString(m.styles.ListItem.Render("  " + tag))
+		}
+		b.WriteString("\n")
+	}
+
+	if e.err != nil {
+		b.WriteString("\n")
+		b.WriteString(m.styles.Error.Width(width).Render(fmt.Sprintf("Error: %v", e.err)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	help := "enter:add  backspace:remove last  ↑/↓:suggestion  ctrl+s:save  esc:discard"
+	if e.saving {
+		help = "Saving..."
+	}
+	b.WriteString(m.styles.Muted.Width(width).Render(help))
+
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/szoloth/partner/internal/mcp/providers"
+)
+
+// untaggedLabel heads the group of tasks without tags
+const untaggedLabel = "[untagged]"
+
+// groupTasksByTag files each task under every one of its tags; tasks
+// without tags are keyed by ""
+func groupTasksByTag(tasks []providers.Task) map[string][]providers.Task {
+	groups := make(map[string][]providers.Task)
+	for _, t := range tasks {
+		if len(t.Tags) == 0 {
+			groups[""] = append(groups[""], t)
+			continue
+		}
+		for _, tag := range t.Tags {
+			groups[tag] = append(groups[tag], t)
+		}
+	}
+	return groups
+}
+
+// sortedTags orders tag keys alphabetically with untagged last
+func sortedTags(groups map[string][]providers.Task) []string {
+	tags := make([]string, 0, len(groups))
+	for tag := range groups {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i] == "" || tags[j] == "" {
+			return tags[j] == ""
+		}
+		return tags[i] < tags[j]
+	})
+	return tags
+}
+
+// tagRows lays out the filtered tasks under a header per tag
+func (m *Model) tagRows() []taskRow {
+	index := make(map[string]int, len(m.tasks))
+	var matched []providers.Task
+	for i, t := range m.tasks {
+		if m.matchesFilters(t) {
+			index[t.UUID] = i
+			matched = append(matched, t)
+		}
+	}
+
+	groups := groupTasksByTag(matched)
+	var rows []taskRow
+	for _, tag := range sortedTags(groups) {
+		rows = append(rows, taskRow{header: true, group: tag, count: len(groups[tag])})
+		for _, t := range groups[tag] {
+			rows = append(rows, taskRow{task: index[t.UUID]})
+		}
+	}
+	return rows
+}
+
+// tagLabel returns the header text for a tag group
+func tagLabel(tag string) string {
+	if tag == "" {
+		return untaggedLabel
+	}
+	return tag
+}
+
+// renderTagHeader renders a bold, non-selectable tag section header
+func (m *Model) renderTagHeader(row taskRow) string {
+	return m.styles.Title.Render(fmt.Sprintf("  %s (%d)", tagLabel(row.group), row.count))
+}
+
+// skipHeaders moves the cursor off a header row in the tag view, in the
+// direction of travel (dir is +1 or -1), falling back the other way at the
+// ends of the list
+func (m *Model) skipHeaders(dir int) {
+	if m.viewMode != ViewByTag {
+		return
+	}
+	rows := m.rows()
+	for _, d := range []int{dir, -dir} {
+		for i := m.cursor; i >= 0 && i < len(rows); i += d {
+			if !rows[i].isHeader() {
+				m.cursor = i
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxUndoDepth bounds how many completions can be undone
+const maxUndoDepth = 10
+
+// undoFlashDuration is how long the "Undone" message stays in the footer
+const undoFlashDuration = 2 * time.Second
+
+// undoEntry records a completion so it can be reverted
+type undoEntry struct {
+	uuid           string
+	title          string
+	previousStatus string
+}
+
+// pushUndo records a completed task, dropping the oldest entry when full.
+// Tasks that were already completed are skipped; undoing them would
+// reopen a task the user never changed.
+func (m *Model) pushUndo(e undoEntry) {
+	if e.previousStatus == "completed" {
+		return
+	}
+	m.undoStack = append(m.undoStack, e)
+	if len(m.undoStack) > maxUndoDepth {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndoDepth:]
+	}
+}
+
+// undo pops the most recent completion and gives the task back its
+// previous status. A failed undo pushes the entry back (see Update).
+func (m *Model) undo() tea.Cmd {
+	if len(m.undoStack) == 0 {
+		return nil
+	}
+	e := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := m.provider.RestoreStatus(ctx, e.uuid, e.previousStatus)
+		return TaskUncompletedMsg{ID: e.uuid, Title: e.title, PreviousStatus: e.previousStatus, Err: err}
+	}
+}
+
+// setFlash shows text in the footer until it expires
+func (m *Model) setFlash(text string) tea.Cmd {
+	m.flash = text
+	return tea.Tick(undoFlashDuration, func(time.Time) tea.Msg {
+		return FlashExpiredMsg{Text: text}
+	})
+}
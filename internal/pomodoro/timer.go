@@ -0,0 +1,161 @@
+// Package pomodoro implements a Pomodoro technique timer: 25-minute work
+// sessions separated by 5-minute breaks, with a 15-minute break after every
+// fourth session.
+package pomodoro
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	WorkDuration       = 25 * time.Minute
+	ShortBreakDuration = 5 * time.Minute
+	LongBreakDuration  = 15 * time.Minute
+	LongBreakEvery     = 4 // Completed pomodoros between long breaks
+)
+
+// Phase is the current interval type
+type Phase int
+
+const (
+	PhaseWork Phase = iota
+	PhaseShortBreak
+	PhaseLongBreak
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseShortBreak:
+		return "short break"
+	case PhaseLongBreak:
+		return "long break"
+	default:
+		return "work"
+	}
+}
+
+// Duration is how long the phase lasts
+func (p Phase) Duration() time.Duration {
+	switch p {
+	case PhaseShortBreak:
+		return ShortBreakDuration
+	case PhaseLongBreak:
+		return LongBreakDuration
+	default:
+		return WorkDuration
+	}
+}
+
+// Timer tracks the current phase and time left. It pauses at the end of
+// each phase so the next one starts when the user is ready.
+type Timer struct {
+	phase     Phase
+	remaining time.Duration
+	running   bool
+	started   bool // Set once the timer has run; cleared by Reset
+	completed int  // Work sessions finished
+	lastTick  time.Time
+}
+
+// New creates a timer ready to start a work session
+func New() *Timer {
+	t := &Timer{}
+	t.Reset()
+	return t
+}
+
+// Toggle starts or pauses the timer
+func (t *Timer) Toggle(now time.Time) {
+	if t.running {
+		t.advance(now)
+		t.running = false
+		return
+	}
+	t.running = true
+	t.started = true
+	t.lastTick = now
+}
+
+// Reset stops the timer and returns to a fresh work session
+func (t *Timer) Reset() {
+	t.phase = PhaseWork
+	t.remaining = WorkDuration
+	t.running = false
+	t.started = false
+	t.completed = 0
+}
+
+// Tick counts down the time elapsed since the last tick. When the phase
+// runs out it moves to the next one and returns the phase that finished.
+func (t *Timer) Tick(now time.Time) (finished Phase, done bool) {
+	if !t.running {
+		return 0, false
+	}
+	t.advance(now)
+	if t.remaining > 0 {
+		return 0, false
+	}
+
+	finished = t.phase
+	if t.phase == PhaseWork {
+		t.completed++
+		if t.completed%LongBreakEvery == 0 {
+			t.phase = PhaseLongBreak
+		} else {
+			t.phase = PhaseShortBreak
+		}
+	} else {
+		t.phase = PhaseWork
+	}
+	t.remaining = t.phase.Duration()
+	t.running = false
+	return finished, true
+}
+
+func (t *Timer) advance(now time.Time) {
+	t.remaining -= now.Sub(t.lastTick)
+	t.lastTick = now
+	if t.remaining < 0 {
+		t.remaining = 0
+	}
+}
+
+// Phase returns the current phase
+func (t *Timer) Phase() Phase {
+	return t.phase
+}
+
+// Remaining returns the time left in the current phase
+func (t *Timer) Remaining() time.Duration {
+	return t.remaining
+}
+
+// Running reports whether the timer is counting down
+func (t *Timer) Running() bool {
+	return t.running
+}
+
+// Started reports whether the timer has been used since the last reset
+func (t *Timer) Started() bool {
+	return t.started
+}
+
+// Completed returns the number of finished work sessions
+func (t *Timer) Completed() int {
+	return t.completed
+}
+
+// Label renders the countdown, e.g. "🍅 23:45" or "☕ 04:12"
+func (t *Timer) Label() string {
+	icon := "🍅"
+	if t.phase != PhaseWork {
+		icon = "☕"
+	}
+	secs := int(t.remaining.Round(time.Second).Seconds())
+	label := fmt.Sprintf("%s %02d:%02d", icon, secs/60, secs%60)
+	if !t.running {
+		label += " ⏸"
+	}
+	return label
+}
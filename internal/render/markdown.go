@@ -0,0 +1,224 @@
+// Package render turns the Markdown in AI responses into styled terminal
+// text. It handles the handful of constructs Claude uses in short answers
+// (headers, bullets, bold, inline and fenced code) and is deliberately not
+// a CommonMark parser.
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/szoloth/partner/internal/theme"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// inlineKind is how a run of text inside a line is styled
+type inlineKind int
+
+const (
+	inlinePlain inlineKind = iota
+	inlineBold
+	inlineCode
+)
+
+// word is a space-free piece of a line with its inline style. space
+// records whether whitespace preceded it, so "**Note**:" stays joined.
+type word struct {
+	text  string
+	kind  inlineKind
+	space bool
+}
+
+// markdownStyles are the styles Markdown derives from the theme
+type markdownStyles struct {
+	plain, bold, code, header, bullet lipgloss.Style
+}
+
+func newMarkdownStyles(s *theme.Styles) markdownStyles {
+	return markdownStyles{
+		plain:  s.Base,
+		bold:   lipgloss.NewStyle().Bold(true).Foreground(s.Palette.Text),
+		code:   lipgloss.NewStyle().Foreground(s.Palette.Secondary).Background(s.Palette.Surface),
+		header: s.Title,
+		bullet: s.ListItem,
+	}
+}
+
+// Markdown renders text wrapped to width columns: "#" headers in the title
+// style, "- " and "* " items with a bullet and a hanging indent, **bold**,
+// `code` on a highlighted background, and ``` fences kept verbatim but
+// broken at width
+func Markdown(text string, width int, styles *theme.Styles) string {
+	st := newMarkdownStyles(styles)
+	var out []string
+	inFence := false
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			if width > 0 {
+				line = ansi.Hardwrap(line, width, true)
+			}
+			for _, l := range strings.Split(line, "\n") {
+				out = append(out, st.code.Render(l))
+			}
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+
+		case strings.HasPrefix(trimmed, "#"):
+			title := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			for _, l := range wrapWords(parseInline(title), width) {
+				out = append(out, st.header.Render(plainText(l)))
+			}
+
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " ")))
+			bullet := indent + st.bullet.Render("•") + " "
+			hang := strings.Repeat(" ", lipgloss.Width(bullet))
+			for i, l := range wrapWords(parseInline(trimmed[2:]), width-lipgloss.Width(bullet)) {
+				prefix := hang
+				if i == 0 {
+					prefix = bullet
+				}
+				out = append(out, prefix+st.renderWords(l))
+			}
+
+		default:
+			for _, l := range wrapWords(parseInline(trimmed), width) {
+				out = append(out, st.renderWords(l))
+			}
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// parseInline splits a line into words, tracking ** and ` spans. An
+// unclosed marker is kept as literal text.
+func parseInline(s string) []word {
+	var words []word
+	gap := false // Whitespace since the last word, possibly in another span
+	emit := func(text string, kind inlineKind) {
+		for {
+			trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+			if len(trimmed) < len(text) {
+				gap = true
+			}
+			if trimmed == "" {
+				return
+			}
+			end := strings.IndexFunc(trimmed, unicode.IsSpace)
+			if end < 0 {
+				end = len(trimmed)
+			}
+			words = append(words, word{text: trimmed[:end], kind: kind, space: gap})
+			gap = false
+			text = trimmed[end:]
+		}
+	}
+
+	for s != "" {
+		bold := strings.Index(s, "**")
+		code := strings.IndexByte(s, '`')
+		switch {
+		case code >= 0 && (bold < 0 || code < bold):
+			end := strings.IndexByte(s[code+1:], '`')
+			if end < 0 {
+				emit(s, inlinePlain)
+				return words
+			}
+			emit(s[:code], inlinePlain)
+			emit(s[code+1:code+1+end], inlineCode)
+			s = s[code+2+end:]
+		case bold >= 0:
+			end := strings.Index(s[bold+2:], "**")
+			if end < 0 {
+				emit(s, inlinePlain)
+				return words
+			}
+			emit(s[:bold], inlinePlain)
+			emit(s[bold+2:bold+2+end], inlineBold)
+			s = s[bold+4+end:]
+		default:
+			emit(s, inlinePlain)
+			return words
+		}
+	}
+	return words
+}
+
+// wrapWords breaks words into lines of at most width columns, only where
+// the text had whitespace; a run longer than width gets a line of its own
+func wrapWords(words []word, width int) [][]word {
+	var lines [][]word
+	var line []word
+	n := 0
+	for i := 0; i < len(words); {
+		// Words joined without whitespace wrap as one run
+		j, l := i+1, lipgloss.Width(words[i].text)
+		for ; j < len(words) && !words[j].space; j++ {
+			l += lipgloss.Width(words[j].text)
+		}
+		if len(line) > 0 && width > 0 && n+1+l > width {
+			lines = append(lines, line)
+			line, n = nil, 0
+		}
+		if len(line) > 0 {
+			n++
+		}
+		line = append(line, words[i:j]...)
+		n += l
+		i = j
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderWords styles a wrapped line. The space between two code words is
+// highlighted too so a code span reads as one block.
+func (st markdownStyles) renderWords(words []word) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 && w.space {
+			if w.kind == inlineCode && words[i-1].kind == inlineCode {
+				b.WriteString(st.code.Render(" "))
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		switch w.kind {
+		case inlineBold:
+			b.WriteString(st.bold.Render(w.text))
+		case inlineCode:
+			b.WriteString(st.code.Render(w.text))
+		default:
+			b.WriteString(st.plain.Render(w.text))
+		}
+	}
+	return b.String()
+}
+
+// plainText joins words without their inline styles
+func plainText(words []word) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 && w.space {
+			b.WriteString(" ")
+		}
+		b.WriteString(w.text)
+	}
+	return b.String()
+}
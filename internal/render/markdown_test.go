@@ -0,0 +1,76 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/szoloth/partner/internal/theme"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// plainMarkdown renders text and strips the styling from each line
+func plainMarkdown(text string, width int) []string {
+	out := Markdown(text, width, theme.NewStyles(theme.Default))
+	lines := strings.Split(out, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(ansi.Strip(l), " ")
+	}
+	return lines
+}
+
+func TestMarkdownSpacing(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"bold then colon", "**Note**: do it.", "Note: do it."},
+		{"code then comma", "Run `go test`, then **ship**.", "Run go test, then ship."},
+		{"spaces around spans", "a **b** `c` d", "a b c d"},
+		{"span inside a word", "un**believ**able", "unbelievable"},
+		{"parenthesised code", "(see `main.go`)", "(see main.go)"},
+		{"header", "## **Plan**: today", "Plan: today"},
+		{"bullet", "- **Fix**: the bug", "  • Fix: the bug"},
+		{"unclosed marker", "a **b c", "a **b c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plainMarkdown(tt.text, 80)
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("Markdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"plain", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"punctuation stays with its span", "aaaa **bbbb**, cc", 9, []string{"aaaa", "bbbb, cc"}},
+		{"wide runes", "日本語 テスト ok", 9, []string{"日本語", "テスト ok"}},
+		{"bullet hang", "- one two three", 11, []string{"  • one two", "    three"}},
+		{"fenced code", "```\nabcdefghij\n```", 4, []string{"abcd", "efgh", "ij"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plainMarkdown(tt.text, tt.width)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Markdown(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+			for _, l := range got {
+				if w := ansi.StringWidth(l); w > tt.width {
+					t.Errorf("line %q is %d columns, over %d", l, w, tt.width)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package system watches for machine-level events, such as waking from
+// sleep, that invalidate long-lived connections
+package system
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is how often SleepWakeDetector checks for a wake
+const DefaultPollInterval = 5 * time.Second
+
+// sleepThreshold is how far the wall clock must run ahead of the monotonic
+// clock between two polls to count as a sleep. The monotonic clock stops
+// while the machine is asleep; the wall clock does not.
+const sleepThreshold = 10 * time.Second
+
+// SleepWakeDetector polls for the machine waking from sleep and signals
+// each wake on Woke
+type SleepWakeDetector struct {
+	interval time.Duration
+	woke     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSleepWakeDetector starts polling every interval (DefaultPollInterval
+// when zero or negative)
+func NewSleepWakeDetector(interval time.Duration) *SleepWakeDetector {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	d := &SleepWakeDetector{
+		interval: interval,
+		woke:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+	go d.run(newWakeProbe())
+	return d
+}
+
+// Woke receives a value after each wake. Wakes that happen while the
+// previous one is still unread are merged into it.
+func (d *SleepWakeDetector) Woke() <-chan struct{} {
+	return d.woke
+}
+
+// Stop ends polling; Woke is never closed
+func (d *SleepWakeDetector) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+func (d *SleepWakeDetector) run(woke func() bool) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if !woke() {
+				continue
+			}
+			select {
+			case d.woke <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// clockJumpProbe reports a wake when the wall clock advanced well past the
+// monotonic clock since the previous call
+func clockJumpProbe() func() bool {
+	last := time.Now()
+	return func() bool {
+		now := time.Now()
+		monotonic := now.Sub(last)
+		wall := now.Round(0).Sub(last.Round(0))
+		last = now
+		return wall-monotonic > sleepThreshold
+	}
+}
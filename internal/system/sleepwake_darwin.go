@@ -0,0 +1,33 @@
+package system
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// newWakeProbe watches kern.waketime, which macOS updates on every wake,
+// and falls back to comparing clocks if the sysctl is unavailable
+func newWakeProbe() func() bool {
+	last, err := wakeTime()
+	if err != nil {
+		return clockJumpProbe()
+	}
+	return func() bool {
+		t, err := wakeTime()
+		if err != nil || !t.After(last) {
+			return false
+		}
+		last = t
+		return true
+	}
+}
+
+// wakeTime returns when the machine last woke from sleep
+func wakeTime() (time.Time, error) {
+	tv, err := unix.SysctlTimeval("kern.waketime")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(tv.Unix()), nil
+}
@@ -0,0 +1,9 @@
+//go:build !darwin
+
+package system
+
+// newWakeProbe compares clocks; other platforms have no wake timestamp
+// as cheap to read as macOS's kern.waketime
+func newWakeProbe() func() bool {
+	return clockJumpProbe()
+}
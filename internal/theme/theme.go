@@ -1,6 +1,10 @@
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Theme defines the color palette for the TUI
 type Theme struct {
@@ -16,6 +20,10 @@ type Theme struct {
 	Success     lipgloss.Color
 	Warning     lipgloss.Color
 	Error       lipgloss.Color
+
+	// Accents are six distinct colors for telling items apart (e.g.
+	// calendars); callers pick one by hashing a stable ID
+	Accents [6]lipgloss.Color
 }
 
 // CatppuccinMocha theme
@@ -32,6 +40,14 @@ var CatppuccinMocha = Theme{
 	Success:     lipgloss.Color("#a6e3a1"), // Green
 	Warning:     lipgloss.Color("#f9e2af"), // Yellow
 	Error:       lipgloss.Color("#f38ba8"), // Red
+	Accents: [6]lipgloss.Color{
+		lipgloss.Color("#cba6f7"), // Mauve
+		lipgloss.Color("#89b4fa"), // Blue
+		lipgloss.Color("#a6e3a1"), // Green
+		lipgloss.Color("#fab387"), // Peach
+		lipgloss.Color("#94e2d5"), // Teal
+		lipgloss.Color("#f5c2e7"), // Pink
+	},
 }
 
 // TeenageEngineering - inspired by EP-133 K.O. II
@@ -48,13 +64,125 @@ var TeenageEngineering = Theme{
 	Success:     lipgloss.Color("#4ECDC4"), // Teal
 	Warning:     lipgloss.Color("#FFE66D"), // Yellow
 	Error:       lipgloss.Color("#FF4757"), // Red
+	Accents: [6]lipgloss.Color{
+		lipgloss.Color("#FF6B35"), // Orange
+		lipgloss.Color("#4ECDC4"), // Teal
+		lipgloss.Color("#FFE66D"), // Yellow
+		lipgloss.Color("#FF4757"), // Red
+		lipgloss.Color("#A29BFE"), // Lavender
+		lipgloss.Color("#7BED9F"), // Mint
+	},
+}
+
+// Nord - arctic palette from nordtheme.com
+var Nord = Theme{
+	Name:        "nord",
+	Primary:     lipgloss.Color("#81A1C1"), // Frost nord9
+	Secondary:   lipgloss.Color("#88C0D0"), // Frost nord8
+	Background:  lipgloss.Color("#2E3440"), // Polar Night nord0
+	Surface:     lipgloss.Color("#3B4252"), // Polar Night nord1
+	Text:        lipgloss.Color("#ECEFF4"), // Snow Storm nord6
+	TextMuted:   lipgloss.Color("#4C566A"), // Polar Night nord3
+	Border:      lipgloss.Color("#434C5E"), // Polar Night nord2
+	BorderFocus: lipgloss.Color("#81A1C1"), // Frost nord9
+	Success:     lipgloss.Color("#A3BE8C"), // Aurora green nord14
+	Warning:     lipgloss.Color("#EBCB8B"), // Aurora yellow nord13
+	Error:       lipgloss.Color("#BF616A"), // Aurora red nord11
+	Accents: [6]lipgloss.Color{
+		lipgloss.Color("#88C0D0"), // nord8
+		lipgloss.Color("#81A1C1"), // nord9
+		lipgloss.Color("#A3BE8C"), // nord14
+		lipgloss.Color("#EBCB8B"), // nord13
+		lipgloss.Color("#D08770"), // nord12
+		lipgloss.Color("#B48EAD"), // nord15
+	},
+}
+
+// GruvboxDark - retro groove palette from github.com/morhetz/gruvbox
+var GruvboxDark = Theme{
+	Name:        "gruvbox",
+	Primary:     lipgloss.Color("#b16286"), // Purple
+	Secondary:   lipgloss.Color("#458588"), // Blue
+	Background:  lipgloss.Color("#282828"), // bg
+	Surface:     lipgloss.Color("#3c3836"), // bg1
+	Text:        lipgloss.Color("#ebdbb2"), // fg
+	TextMuted:   lipgloss.Color("#928374"), // Gray
+	Border:      lipgloss.Color("#3c3836"), // bg1
+	BorderFocus: lipgloss.Color("#b16286"), // Purple
+	Success:     lipgloss.Color("#98971a"), // Green
+	Warning:     lipgloss.Color("#d79921"), // Yellow
+	Error:       lipgloss.Color("#cc241d"), // Red
+	Accents: [6]lipgloss.Color{
+		lipgloss.Color("#b16286"), // Purple
+		lipgloss.Color("#458588"), // Blue
+		lipgloss.Color("#98971a"), // Green
+		lipgloss.Color("#d79921"), // Yellow
+		lipgloss.Color("#d65d0e"), // Orange
+		lipgloss.Color("#689d6a"), // Aqua
+	},
+}
+
+// Dracula - palette from draculatheme.com
+var Dracula = Theme{
+	Name:        "dracula",
+	Primary:     lipgloss.Color("#BD93F9"), // Purple
+	Secondary:   lipgloss.Color("#8BE9FD"), // Cyan
+	Background:  lipgloss.Color("#282A36"), // Background
+	Surface:     lipgloss.Color("#44475A"), // Current Line
+	Text:        lipgloss.Color("#F8F8F2"), // Foreground
+	TextMuted:   lipgloss.Color("#6272A4"), // Comment
+	Border:      lipgloss.Color("#44475A"), // Current Line
+	BorderFocus: lipgloss.Color("#FF79C6"), // Pink
+	Success:     lipgloss.Color("#50FA7B"), // Green
+	Warning:     lipgloss.Color("#FFB86C"), // Orange
+	Error:       lipgloss.Color("#FF5555"), // Red
+	Accents: [6]lipgloss.Color{
+		lipgloss.Color("#BD93F9"), // Purple
+		lipgloss.Color("#8BE9FD"), // Cyan
+		lipgloss.Color("#50FA7B"), // Green
+		lipgloss.Color("#FFB86C"), // Orange
+		lipgloss.Color("#FF79C6"), // Pink
+		lipgloss.Color("#F1FA8C"), // Yellow
+	},
 }
 
-// Current is the active theme
-var Current = TeenageEngineering
+// Default is the theme used before any configuration is applied
+var Default = TeenageEngineering
+
+// Names lists the names ParseTheme accepts, one per theme
+func Names() []string {
+	return []string{
+		CatppuccinMocha.Name,
+		TeenageEngineering.Name,
+		Nord.Name,
+		GruvboxDark.Name,
+		Dracula.Name,
+	}
+}
+
+// ParseTheme returns the theme registered under name
+func ParseTheme(name string) (Theme, error) {
+	switch name {
+	case "catppuccin_mocha", "catppuccin":
+		return CatppuccinMocha, nil
+	case "teenage_engineering":
+		return TeenageEngineering, nil
+	case "nord":
+		return Nord, nil
+	case "gruvbox":
+		return GruvboxDark, nil
+	case "dracula":
+		return Dracula, nil
+	default:
+		return Theme{}, fmt.Errorf("unknown theme: %q", name)
+	}
+}
 
 // Styles provides pre-configured lipgloss styles
 type Styles struct {
+	// Palette is the theme the styles were built from
+	Palette Theme
+
 	// Base styles
 	Base       lipgloss.Style
 	Title      lipgloss.Style
@@ -82,11 +210,22 @@ type Styles struct {
 	StatusValue      lipgloss.Style
 }
 
-// NewStyles creates styles from the current theme
-func NewStyles() *Styles {
-	t := Current
+// NewStyles creates styles from a theme palette
+func NewStyles(t Theme) *Styles {
+	return buildStyles(t)
+}
+
+// SetTheme regenerates every style from t in place, so panes sharing
+// this *Styles pick up the new colors on their next View()
+func (s *Styles) SetTheme(t Theme) {
+	*s = *buildStyles(t)
+}
 
+// buildStyles derives all styles from a theme palette
+func buildStyles(t Theme) *Styles {
 	return &Styles{
+		Palette: t,
+
 		Base: lipgloss.NewStyle().
 			Foreground(t.Text),
 
@@ -0,0 +1,67 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseThemeNames(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			th, err := ParseTheme(name)
+			if err != nil {
+				t.Fatalf("ParseTheme(%q) = %v", name, err)
+			}
+			if th.Name != name {
+				t.Errorf("Name = %q, want %q", th.Name, name)
+			}
+		})
+	}
+}
+
+func TestParseThemeUnknown(t *testing.T) {
+	for _, name := range []string{"", "solarized", "Nord"} {
+		if th, err := ParseTheme(name); err == nil {
+			t.Errorf("ParseTheme(%q) = %q, want an error", name, th.Name)
+		}
+	}
+}
+
+// TestThemesPopulated checks that no built-in theme leaves a color, or a
+// style derived from one, unset
+func TestThemesPopulated(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			th, _ := ParseTheme(name)
+
+			palette := reflect.ValueOf(th)
+			for i := 0; i < palette.NumField(); i++ {
+				if palette.Field(i).IsZero() {
+					t.Errorf("Theme.%s is unset", palette.Type().Field(i).Name)
+				}
+			}
+			for i, c := range th.Accents {
+				if c == "" {
+					t.Errorf("Theme.Accents[%d] is unset", i)
+				}
+			}
+
+			styles := reflect.ValueOf(*NewStyles(th))
+			for i := 0; i < styles.NumField(); i++ {
+				if styles.Field(i).IsZero() {
+					t.Errorf("Styles.%s is zero", styles.Type().Field(i).Name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseGruvbox(t *testing.T) {
+	th, err := ParseTheme("gruvbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Background != "#282828" || th.Text != "#ebdbb2" || th.Error != "#cc241d" {
+		t.Errorf("gruvbox palette = %+v", th)
+	}
+}
@@ -0,0 +1,90 @@
+// Package virtuallist renders long lists a window at a time. Only the rows
+// in view are rendered, and rendered rows are cached until the list is
+// invalidated, so a pane with hundreds of items costs the same per frame
+// as one with a screenful.
+package virtuallist
+
+import "strings"
+
+// RenderFunc renders item i of the list. cursor reports whether the item
+// is under the cursor; cursor rows are never cached.
+type RenderFunc[T any] func(i int, item T, cursor bool) string
+
+// List is a scrolling window over items. The window moves only as far as
+// needed to keep the cursor in view.
+type List[T any] struct {
+	items  []T
+	render RenderFunc[T]
+	height int
+	offset int // Index of the first visible item
+
+	// Rendered non-cursor rows by item index; cleared by Invalidate
+	renderedCache map[int]string
+}
+
+// New creates a list showing height rows of items
+func New[T any](items []T, render RenderFunc[T], height int) *List[T] {
+	return &List[T]{
+		items:         items,
+		render:        render,
+		height:        height,
+		renderedCache: make(map[int]string),
+	}
+}
+
+// SetItems replaces the items and drops every cached row
+func (l *List[T]) SetItems(items []T) {
+	l.items = items
+	l.Invalidate()
+}
+
+// SetHeight sets how many rows are shown
+func (l *List[T]) SetHeight(height int) {
+	l.height = height
+}
+
+// Invalidate drops every cached row, for when something the render
+// function reads has changed
+func (l *List[T]) Invalidate() {
+	clear(l.renderedCache)
+}
+
+// Len is the number of items
+func (l *List[T]) Len() int {
+	return len(l.items)
+}
+
+// Window returns the [start, end) range of items in view with cursor
+// visible, scrolling the list if needed
+func (l *List[T]) Window(cursor int) (int, int) {
+	height := max(0, l.height)
+	if cursor < l.offset {
+		l.offset = cursor
+	}
+	if cursor >= l.offset+height {
+		l.offset = cursor - height + 1
+	}
+	// Don't leave blank rows below the last item
+	l.offset = max(0, min(l.offset, len(l.items)-height))
+	return l.offset, min(l.offset+height, len(l.items))
+}
+
+// View renders the visible rows, one per line. Only rows in the window
+// are rendered; those off the cursor come from the cache when possible.
+func (l *List[T]) View(cursor int) string {
+	start, end := l.Window(cursor)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			lines = append(lines, l.render(i, l.items[i], true))
+			continue
+		}
+		line, ok := l.renderedCache[i]
+		if !ok {
+			line = l.render(i, l.items[i], false)
+			l.renderedCache[i] = line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
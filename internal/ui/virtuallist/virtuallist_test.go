@@ -0,0 +1,119 @@
+package virtuallist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numbers returns the items 0..n-1
+func numbers(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func plain(i, item int, cursor bool) string {
+	if cursor {
+		return fmt.Sprintf("> %d", item)
+	}
+	return fmt.Sprintf("  %d", item)
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		height    int
+		cursors   []int // Cursor positions in order; the last is checked
+		wantStart int
+		wantEnd   int
+	}{
+		{"top", 10, 3, []int{0}, 0, 3},
+		{"within first window", 10, 3, []int{2}, 0, 3},
+		{"one past the window", 10, 3, []int{0, 3}, 1, 4},
+		{"jump to the end", 10, 3, []int{9}, 7, 10},
+		{"back to the top", 10, 3, []int{9, 0}, 0, 3},
+		{"up keeps the offset", 10, 3, []int{9, 8}, 7, 10},
+		{"up past the window", 10, 3, []int{9, 6}, 6, 9},
+		{"exact fit", 3, 3, []int{2}, 0, 3},
+		{"short list", 2, 5, []int{1}, 0, 2},
+		{"empty list", 0, 5, []int{0}, 0, 0},
+		{"zero height", 10, 0, []int{4}, 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(numbers(tt.items), plain, tt.height)
+			var start, end int
+			for _, c := range tt.cursors {
+				start, end = l.Window(c)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("Window() = [%d, %d), want [%d, %d)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestWindowAfterShrink(t *testing.T) {
+	l := New(numbers(10), plain, 3)
+	l.Window(9)
+
+	// With fewer items the window slides back rather than showing blanks
+	l.SetItems(numbers(4))
+	if start, end := l.Window(1); start != 1 || end != 4 {
+		t.Errorf("Window() = [%d, %d), want [1, 4)", start, end)
+	}
+}
+
+func TestViewCaching(t *testing.T) {
+	calls := make(map[string]int)
+	render := func(i, item int, cursor bool) string {
+		calls[fmt.Sprintf("%d/%v", i, cursor)]++
+		return plain(i, item, cursor)
+	}
+	l := New(numbers(5), render, 3)
+
+	if got, want := l.View(1), "  0\n> 1\n  2"; got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+	l.View(1)
+	if calls["0/false"] != 1 || calls["2/false"] != 1 {
+		t.Errorf("non-cursor rows rendered %d and %d times, want once each", calls["0/false"], calls["2/false"])
+	}
+	if calls["1/true"] != 2 {
+		t.Errorf("cursor row rendered %d times, want on every View", calls["1/true"])
+	}
+	if calls["3/false"] != 0 {
+		t.Error("a row outside the window was rendered")
+	}
+}
+
+func TestSetItemsInvalidates(t *testing.T) {
+	l := New([]int{1, 2, 3}, plain, 3)
+	l.View(0)
+
+	l.SetItems([]int{7, 8, 9})
+	if got := l.View(0); !strings.Contains(got, "8") || strings.Contains(got, "2") {
+		t.Errorf("View() after SetItems = %q, want the new items", got)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	prefix := "a"
+	render := func(i, item int, cursor bool) string { return prefix + plain(i, item, cursor) }
+	l := New(numbers(2), render, 2)
+	l.View(0)
+
+	prefix = "b"
+	if got := l.View(0); got != "b> 0\na  1" {
+		t.Fatalf("View() before Invalidate = %q, want the cached row kept", got)
+	}
+	l.Invalidate()
+	if got := l.View(0); got != "b> 0\nb  1" {
+		t.Errorf("View() after Invalidate = %q, want every row re-rendered", got)
+	}
+}
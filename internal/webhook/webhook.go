@@ -0,0 +1,143 @@
+// Package webhook delivers AI responses to an external HTTP endpoint
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each POST when no timeout is configured
+const DefaultTimeout = 5 * time.Second
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>" when a secret
+// is configured
+const SignatureHeader = "X-Partner-Signature"
+
+// Payload is the JSON body POSTed for each AI response
+type Payload struct {
+	Pane      string  `json:"pane"`
+	Response  string  `json:"response"`
+	Action    *Action `json:"action"`
+	Timestamp string  `json:"timestamp"` // RFC 3339
+}
+
+// Action is the suggested action attached to a response, if any
+type Action struct {
+	Type        string                 `json:"type"`
+	Description string                 `json:"description"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// Dispatcher POSTs payloads to a single URL
+type Dispatcher struct {
+	url     string
+	secret  string
+	timeout time.Duration
+	client  *http.Client
+	logger  *slog.Logger
+}
+
+// Option configures a Dispatcher
+type Option func(*Dispatcher)
+
+// WithSecret signs every body with HMAC-SHA256 in SignatureHeader
+func WithSecret(secret string) Option {
+	return func(d *Dispatcher) {
+		d.secret = secret
+	}
+}
+
+// WithTimeout overrides DefaultTimeout; zero or negative keeps the default
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) {
+		if timeout > 0 {
+			d.timeout = timeout
+		}
+	}
+}
+
+// WithHTTPClient overrides the HTTP client used for requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithLogger logs failed deliveries
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *Dispatcher) {
+		d.logger = logger
+	}
+}
+
+// New creates a dispatcher for url
+func New(url string, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		url:     url,
+		timeout: DefaultTimeout,
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Send POSTs p as JSON. Errors, including non-2xx responses, are logged
+// and returned; they never panic.
+func (d *Dispatcher) Send(ctx context.Context, p Payload) error {
+	err := d.send(ctx, p)
+	if err != nil && d.logger != nil {
+		d.logger.Warn("webhook delivery failed", "url", d.url, "error", err)
+	}
+	return err
+}
+
+func (d *Dispatcher) send(ctx context.Context, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, d.secret))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// Sign is the hex HMAC-SHA256 of body keyed by secret, as sent in
+// SignatureHeader after "sha256="
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// received is one request captured by newReceiver
+type received struct {
+	contentType string
+	signature   string
+	body        []byte
+}
+
+// newReceiver starts a server that records each request and answers with
+// status and reply
+func newReceiver(t *testing.T, status int, reply string) (*httptest.Server, chan received) {
+	t.Helper()
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		got <- received{
+			contentType: r.Header.Get("Content-Type"),
+			signature:   r.Header.Get(SignatureHeader),
+			body:        body,
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func TestSendBody(t *testing.T) {
+	srv, got := newReceiver(t, http.StatusNoContent, "")
+	p := Payload{
+		Pane:      "tasks",
+		Response:  "Ship the release",
+		Action:    &Action{Type: "complete_task", Description: "Done", Data: map[string]interface{}{"id": "abc"}},
+		Timestamp: "2026-10-15T09:00:00Z",
+	}
+
+	if err := New(srv.URL).Send(context.Background(), p); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	req := <-got
+	if req.contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", req.contentType)
+	}
+	if req.signature != "" {
+		t.Errorf("%s = %q without a secret, want none", SignatureHeader, req.signature)
+	}
+	var sent Payload
+	if err := json.Unmarshal(req.body, &sent); err != nil {
+		t.Fatalf("body %s is not a payload: %v", req.body, err)
+	}
+	if sent.Pane != p.Pane || sent.Response != p.Response || sent.Timestamp != p.Timestamp ||
+		sent.Action == nil || sent.Action.Type != p.Action.Type || sent.Action.Data["id"] != "abc" {
+		t.Errorf("body = %+v, want %+v", sent, p)
+	}
+}
+
+func TestSendSignature(t *testing.T) {
+	srv, got := newReceiver(t, http.StatusOK, "")
+	const secret = "s3cret"
+
+	if err := New(srv.URL, WithSecret(secret)).Send(context.Background(), Payload{Pane: "calendar"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// A receiver verifies the header against the raw body it was sent
+	req := <-got
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(req.body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if req.signature != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, req.signature, want)
+	}
+}
+
+func TestSendErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		reply  string
+		want   string
+	}{
+		{"server error", http.StatusInternalServerError, "boom\n", "500 Internal Server Error: boom"},
+		{"unauthorized", http.StatusUnauthorized, "", "401 Unauthorized"},
+		{"not modified", http.StatusNotModified, "", "304 Not Modified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := newReceiver(t, tt.status, tt.reply)
+
+			err := New(srv.URL).Send(context.Background(), Payload{})
+			<-got
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Send() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	if err := New(url).Send(context.Background(), Payload{}); err == nil {
+		t.Error("Send() to a closed server succeeded")
+	}
+}